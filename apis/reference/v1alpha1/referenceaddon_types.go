@@ -1,20 +1,148 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// ReferenceAddonFinalizer ensures that all resources managed on behalf of a
+// ReferenceAddon are torn down in order before the ReferenceAddon is deleted.
+const ReferenceAddonFinalizer = "reference.addons.managed.openshift.io/teardown"
+
 // ReferenceAddonSpec defines the desired state of ReferenceAddon.
+// Fields set on the spec take precedence over the corresponding
+// values in the addon parameters Secret. Fields left unset fall
+// back to the values found in the Secret.
 type ReferenceAddonSpec struct {
+	// ApplyNetworkPolicies determines whether the addon's NetworkPolicies
+	// are applied (true) or removed (false).
+	// +optional
+	ApplyNetworkPolicies *bool `json:"applyNetworkPolicies,omitempty"`
+	// EnableSmokeTest toggles the smoke test metric.
+	// +optional
+	EnableSmokeTest *bool `json:"enableSmokeTest,omitempty"`
+	// Size is the requested size of the addon. The "custom" size
+	// takes the sample workload's sizing from CustomSize.
+	// +kubebuilder:validation:Enum=small;medium;large;custom
+	// +optional
+	Size *string `json:"size,omitempty"`
+	// CustomSize sizes the sample workload when Size is "custom".
+	// +optional
+	CustomSize *WorkloadSize `json:"customSize,omitempty"`
+	// SampleURLs are the URLs probed to produce the sample availability
+	// and response time metrics.
+	// +optional
+	SampleURLs []string `json:"sampleURLs,omitempty"`
+	// NetworkPolicies are the names of the NetworkPolicy templates
+	// applied when ApplyNetworkPolicies is true.
+	// +kubebuilder:validation:items:Enum=allow-dns;allow-from-cidrs;allow-from-monitoring;allow-from-same-namespace;deny-all-ingress;restrict-egress
+	// +optional
+	NetworkPolicies []string `json:"networkPolicies,omitempty"`
+	// AllowedCIDRs are substituted into the "allow-from-cidrs"
+	// and "restrict-egress" NetworkPolicy templates.
+	// +optional
+	AllowedCIDRs []string `json:"allowedCIDRs,omitempty"`
+}
+
+// WorkloadSize describes the replicas and compute resources
+// of the sample workload.
+type WorkloadSize struct {
+	// Replicas is the desired number of sample workload replicas.
+	// +kubebuilder:validation:Minimum=0
+	Replicas int32 `json:"replicas"`
+	// Resources are the compute resources of each replica.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
 // ReferenceAddonStatus defines the observed state of ReferenceAddon
 type ReferenceAddonStatus struct {
 	ObservedGeneration int64              `json:"observedGeneration,omitempty"`
 	Conditions         []metav1.Condition `json:"conditions,omitempty"`
+	// UninstallScheduledAt is the time at which a signaled uninstall
+	// proceeds. Withdrawing the uninstall signal before this time
+	// aborts the uninstall.
+	// +optional
+	UninstallScheduledAt *metav1.Time `json:"uninstallScheduledAt,omitempty"`
+	// Uninstall reports the progress of the most recent teardown
+	// attempt once the ReferenceAddon is being deleted.
+	// +optional
+	Uninstall *UninstallReport `json:"uninstall,omitempty"`
+	// ObservedParametersHash is the hash of the effective addon
+	// parameters which were last fully reconciled.
+	// +optional
+	ObservedParametersHash string `json:"observedParametersHash,omitempty"`
+	// Parameters summarizes the effective addon parameters which
+	// were last fully reconciled. Sensitive values are redacted.
+	// +optional
+	Parameters []ObservedParameter `json:"parameters,omitempty"`
+}
+
+// ObservedParameter summarizes a single effective addon parameter.
+type ObservedParameter struct {
+	// Key is the key of the parameter.
+	Key string `json:"key"`
+	// Value is the value of the parameter with sensitive
+	// content redacted.
+	Value string `json:"value"`
+	// Source is where the value was retrieved from.
+	// +optional
+	Source string `json:"source,omitempty"`
+}
+
+// UninstallReport records the outcome of each teardown step.
+type UninstallReport struct {
+	// Steps lists all teardown steps in execution order.
+	Steps []UninstallStepReport `json:"steps,omitempty"`
+	// Complete is true once every teardown step has finished.
+	Complete bool `json:"complete,omitempty"`
+}
+
+// FailedStep returns the first step which failed or nil
+// if no step has failed.
+func (r *UninstallReport) FailedStep() *UninstallStepReport {
+	if r == nil {
+		return nil
+	}
+
+	for i := range r.Steps {
+		if r.Steps[i].Result == UninstallStepResultFailed {
+			return &r.Steps[i]
+		}
+	}
+
+	return nil
+}
+
+// UninstallStepReport records the outcome of a single teardown step.
+type UninstallStepReport struct {
+	// Name identifies the teardown step.
+	Name TearingDownReason `json:"name"`
+	// Result is the outcome of the step.
+	Result UninstallStepResult `json:"result"`
+	// Message provides details on the outcome such as the
+	// error encountered or why the step was skipped.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Found lists the resources which the step found to remove.
+	// +optional
+	Found []string `json:"found,omitempty"`
+	// Removed lists the resources which the step removed.
+	// +optional
+	Removed []string `json:"removed,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=Pending;Succeeded;Skipped;Failed
+type UninstallStepResult string
+
+const (
+	UninstallStepResultPending   UninstallStepResult = "Pending"
+	UninstallStepResultSucceeded UninstallStepResult = "Succeeded"
+	UninstallStepResultSkipped   UninstallStepResult = "Skipped"
+	UninstallStepResultFailed    UninstallStepResult = "Failed"
+)
+
 type ReferenceAddonCondition string
 
 func (c ReferenceAddonCondition) String() string {
@@ -22,7 +150,18 @@ func (c ReferenceAddonCondition) String() string {
 }
 
 const (
-	ReferenceAddonConditionAvailable ReferenceAddonCondition = "Available"
+	ReferenceAddonConditionAvailable              ReferenceAddonCondition = "Available"
+	ReferenceAddonConditionDegraded               ReferenceAddonCondition = "Degraded"
+	ReferenceAddonConditionNetworkPoliciesApplied ReferenceAddonCondition = "NetworkPoliciesApplied"
+	ReferenceAddonConditionSmokeTestConfigured    ReferenceAddonCondition = "SmokeTestConfigured"
+	ReferenceAddonConditionMetricsSampled         ReferenceAddonCondition = "MetricsSampled"
+	ReferenceAddonConditionUninstallPending       ReferenceAddonCondition = "UninstallPending"
+	ReferenceAddonConditionTearingDown            ReferenceAddonCondition = "TearingDown"
+	ReferenceAddonConditionParametersValid        ReferenceAddonCondition = "ParametersValid"
+	ReferenceAddonConditionWorkloadAvailable      ReferenceAddonCondition = "WorkloadAvailable"
+	// ReferenceAddonConditionResourceGuardrailsApplied reports whether the
+	// ResourceQuota and LimitRange sized by the 'size' parameter are applied.
+	ReferenceAddonConditionResourceGuardrailsApplied ReferenceAddonCondition = "ResourceGuardrailsApplied"
 )
 
 type ReferenceAddonAvailableReason string
@@ -35,7 +174,7 @@ func (r ReferenceAddonAvailableReason) Status() metav1.ConditionStatus {
 	switch r {
 	case ReferenceAddonAvailableReasonReady:
 		return "True"
-	case ReferenceAddonAvailableReasonPending:
+	case ReferenceAddonAvailableReasonPending, ReferenceAddonAvailableReasonDegraded:
 		return "False"
 	default:
 		return "Unknown"
@@ -46,11 +185,286 @@ const (
 	ReferenceAddonAvailableReasonReady        ReferenceAddonAvailableReason = "Ready"
 	ReferenceAddonAvailableReasonPending      ReferenceAddonAvailableReason = "Pending"
 	ReferenceAddonAvailableReasonUninstalling ReferenceAddonAvailableReason = "Uninstalling"
+	ReferenceAddonAvailableReasonDegraded     ReferenceAddonAvailableReason = "Degraded"
+)
+
+type ReferenceAddonDegradedReason string
+
+func (r ReferenceAddonDegradedReason) String() string {
+	return string(r)
+}
+
+func (r ReferenceAddonDegradedReason) Status() metav1.ConditionStatus {
+	switch r {
+	case ReferenceAddonDegradedReasonPhaseFailed, ReferenceAddonDegradedReasonPhaseErrored:
+		return "True"
+	case ReferenceAddonDegradedReasonAsExpected:
+		return "False"
+	default:
+		return "Unknown"
+	}
+}
+
+const (
+	ReferenceAddonDegradedReasonAsExpected   ReferenceAddonDegradedReason = "AsExpected"
+	ReferenceAddonDegradedReasonPhaseFailed  ReferenceAddonDegradedReason = "PhaseFailed"
+	ReferenceAddonDegradedReasonPhaseErrored ReferenceAddonDegradedReason = "PhaseErrored"
+)
+
+type NetworkPoliciesAppliedReason string
+
+func (r NetworkPoliciesAppliedReason) String() string {
+	return string(r)
+}
+
+func (r NetworkPoliciesAppliedReason) Status() metav1.ConditionStatus {
+	switch r {
+	case NetworkPoliciesAppliedReasonApplied,
+		NetworkPoliciesAppliedReasonDriftCorrected:
+		return "True"
+	case NetworkPoliciesAppliedReasonRemoved,
+		NetworkPoliciesAppliedReasonNotConfigured,
+		NetworkPoliciesAppliedReasonApplyFailed,
+		NetworkPoliciesAppliedReasonRemoveFailed,
+		NetworkPoliciesAppliedReasonPruneFailed,
+		NetworkPoliciesAppliedReasonConflict,
+		NetworkPoliciesAppliedReasonDriftDetected,
+		NetworkPoliciesAppliedReasonInvalidTemplate:
+		return "False"
+	default:
+		return "Unknown"
+	}
+}
+
+const (
+	NetworkPoliciesAppliedReasonApplied NetworkPoliciesAppliedReason = "Applied"
+	// NetworkPoliciesAppliedReasonDriftCorrected indicates that manual
+	// changes to the spec of applied NetworkPolicies were reverted.
+	NetworkPoliciesAppliedReasonDriftCorrected NetworkPoliciesAppliedReason = "DriftCorrected"
+	// NetworkPoliciesAppliedReasonDriftDetected indicates that the spec of
+	// applied NetworkPolicies was changed manually and the changes were
+	// only reported since drift correction runs in report-only mode.
+	NetworkPoliciesAppliedReasonDriftDetected NetworkPoliciesAppliedReason = "DriftDetected"
+	NetworkPoliciesAppliedReasonRemoved       NetworkPoliciesAppliedReason = "Removed"
+	NetworkPoliciesAppliedReasonNotConfigured NetworkPoliciesAppliedReason = "NotConfigured"
+	NetworkPoliciesAppliedReasonApplyFailed   NetworkPoliciesAppliedReason = "ApplyFailed"
+	NetworkPoliciesAppliedReasonRemoveFailed  NetworkPoliciesAppliedReason = "RemoveFailed"
+	// NetworkPoliciesAppliedReasonPruneFailed indicates that NetworkPolicies
+	// which are no longer desired could not be pruned.
+	NetworkPoliciesAppliedReasonPruneFailed NetworkPoliciesAppliedReason = "PruneFailed"
+	// NetworkPoliciesAppliedReasonConflict indicates that applying a
+	// NetworkPolicy conflicts with fields owned by another field manager.
+	NetworkPoliciesAppliedReasonConflict NetworkPoliciesAppliedReason = "Conflict"
+	// NetworkPoliciesAppliedReasonInvalidTemplate indicates that an unknown
+	// NetworkPolicy template was selected or a template failed to render.
+	NetworkPoliciesAppliedReasonInvalidTemplate NetworkPoliciesAppliedReason = "InvalidTemplate"
+)
+
+type SmokeTestConfiguredReason string
+
+func (r SmokeTestConfiguredReason) String() string {
+	return string(r)
+}
+
+func (r SmokeTestConfiguredReason) Status() metav1.ConditionStatus {
+	switch r {
+	case SmokeTestConfiguredReasonEnabled, SmokeTestConfiguredReasonDisabled:
+		return "True"
+	case SmokeTestConfiguredReasonNotConfigured:
+		return "False"
+	default:
+		return "Unknown"
+	}
+}
+
+const (
+	SmokeTestConfiguredReasonEnabled       SmokeTestConfiguredReason = "Enabled"
+	SmokeTestConfiguredReasonDisabled      SmokeTestConfiguredReason = "Disabled"
+	SmokeTestConfiguredReasonNotConfigured SmokeTestConfiguredReason = "NotConfigured"
+)
+
+type MetricsSampledReason string
+
+func (r MetricsSampledReason) String() string {
+	return string(r)
+}
+
+func (r MetricsSampledReason) Status() metav1.ConditionStatus {
+	switch r {
+	case MetricsSampledReasonSampled:
+		return "True"
+	case MetricsSampledReasonNoSampleURLs:
+		return "False"
+	default:
+		return "Unknown"
+	}
+}
+
+const (
+	MetricsSampledReasonSampled      MetricsSampledReason = "Sampled"
+	MetricsSampledReasonNoSampleURLs MetricsSampledReason = "NoSampleURLs"
+)
+
+type UninstallPendingReason string
+
+func (r UninstallPendingReason) String() string {
+	return string(r)
+}
+
+func (r UninstallPendingReason) Status() metav1.ConditionStatus {
+	switch r {
+	case UninstallPendingReasonScheduled,
+		UninstallPendingReasonInProgress,
+		UninstallPendingReasonFailed:
+		return "True"
+	case UninstallPendingReasonNotRequested,
+		UninstallPendingReasonAborted,
+		UninstallPendingReasonDryRun:
+		return "False"
+	default:
+		return "Unknown"
+	}
+}
+
+const (
+	UninstallPendingReasonNotRequested UninstallPendingReason = "NotRequested"
+	UninstallPendingReasonScheduled    UninstallPendingReason = "Scheduled"
+	UninstallPendingReasonAborted      UninstallPendingReason = "Aborted"
+	UninstallPendingReasonDryRun       UninstallPendingReason = "DryRun"
+	UninstallPendingReasonInProgress   UninstallPendingReason = "InProgress"
+	UninstallPendingReasonFailed       UninstallPendingReason = "Failed"
+)
+
+// ParametersValidReason reports whether the most recently retrieved
+// addon parameters could be parsed. While they are invalid the last
+// valid parameters remain in use.
+type ParametersValidReason string
+
+func (r ParametersValidReason) String() string {
+	return string(r)
+}
+
+func (r ParametersValidReason) Status() metav1.ConditionStatus {
+	switch r {
+	case ParametersValidReasonValid, ParametersValidReasonNotFound:
+		return "True"
+	case ParametersValidReasonInvalidValue, ParametersValidReasonLookupFailed:
+		return "False"
+	default:
+		return "Unknown"
+	}
+}
+
+const (
+	ParametersValidReasonValid        ParametersValidReason = "Valid"
+	ParametersValidReasonNotFound     ParametersValidReason = "NotFound"
+	ParametersValidReasonInvalidValue ParametersValidReason = "InvalidValue"
+	ParametersValidReasonLookupFailed ParametersValidReason = "LookupFailed"
+)
+
+// WorkloadAvailableReason reports the rollout state of the
+// sample workload sized by the 'size' parameter.
+type WorkloadAvailableReason string
+
+func (r WorkloadAvailableReason) String() string {
+	return string(r)
+}
+
+func (r WorkloadAvailableReason) Status() metav1.ConditionStatus {
+	switch r {
+	case WorkloadAvailableReasonAvailable:
+		return "True"
+	case WorkloadAvailableReasonProgressing,
+		WorkloadAvailableReasonNotConfigured,
+		WorkloadAvailableReasonInvalidSize,
+		WorkloadAvailableReasonApplyFailed,
+		WorkloadAvailableReasonConflict,
+		WorkloadAvailableReasonRolloutFailed:
+		return "False"
+	default:
+		return "Unknown"
+	}
+}
+
+const (
+	WorkloadAvailableReasonAvailable     WorkloadAvailableReason = "Available"
+	WorkloadAvailableReasonProgressing   WorkloadAvailableReason = "Progressing"
+	WorkloadAvailableReasonNotConfigured WorkloadAvailableReason = "NotConfigured"
+	WorkloadAvailableReasonInvalidSize   WorkloadAvailableReason = "InvalidSize"
+	WorkloadAvailableReasonApplyFailed   WorkloadAvailableReason = "ApplyFailed"
+	WorkloadAvailableReasonRolloutFailed WorkloadAvailableReason = "RolloutFailed"
+	// WorkloadAvailableReasonConflict indicates that applying the sample
+	// workload conflicts with fields owned by another field manager.
+	WorkloadAvailableReasonConflict WorkloadAvailableReason = "Conflict"
+)
+
+// ResourceGuardrailsAppliedReason reports the state of the addon
+// namespace's ResourceQuota and LimitRange.
+type ResourceGuardrailsAppliedReason string
+
+func (r ResourceGuardrailsAppliedReason) String() string {
+	return string(r)
+}
+
+func (r ResourceGuardrailsAppliedReason) Status() metav1.ConditionStatus {
+	switch r {
+	case ResourceGuardrailsAppliedReasonApplied,
+		ResourceGuardrailsAppliedReasonDriftCorrected:
+		return "True"
+	case ResourceGuardrailsAppliedReasonRemoved,
+		ResourceGuardrailsAppliedReasonInvalidSize,
+		ResourceGuardrailsAppliedReasonApplyFailed,
+		ResourceGuardrailsAppliedReasonRemoveFailed:
+		return "False"
+	default:
+		return "Unknown"
+	}
+}
+
+const (
+	ResourceGuardrailsAppliedReasonApplied ResourceGuardrailsAppliedReason = "Applied"
+	// ResourceGuardrailsAppliedReasonDriftCorrected indicates that manual
+	// changes to the ResourceQuota or LimitRange were reverted.
+	ResourceGuardrailsAppliedReasonDriftCorrected ResourceGuardrailsAppliedReason = "DriftCorrected"
+	ResourceGuardrailsAppliedReasonRemoved        ResourceGuardrailsAppliedReason = "Removed"
+	ResourceGuardrailsAppliedReasonInvalidSize    ResourceGuardrailsAppliedReason = "InvalidSize"
+	ResourceGuardrailsAppliedReasonApplyFailed    ResourceGuardrailsAppliedReason = "ApplyFailed"
+	ResourceGuardrailsAppliedReasonRemoveFailed   ResourceGuardrailsAppliedReason = "RemoveFailed"
+)
+
+// TearingDownReason identifies the teardown step which is
+// currently in progress while a ReferenceAddon is being deleted.
+type TearingDownReason string
+
+func (r TearingDownReason) String() string {
+	return string(r)
+}
+
+func (r TearingDownReason) Status() metav1.ConditionStatus {
+	switch r {
+	case TearingDownReasonDrainingPhases,
+		TearingDownReasonRemovingNetworkPolicies,
+		TearingDownReasonResettingMetrics,
+		TearingDownReasonDeletingCSVs,
+		TearingDownReasonReleasingFinalizer:
+		return "True"
+	default:
+		return "Unknown"
+	}
+}
+
+const (
+	TearingDownReasonDrainingPhases          TearingDownReason = "DrainingPhases"
+	TearingDownReasonRemovingNetworkPolicies TearingDownReason = "RemovingNetworkPolicies"
+	TearingDownReasonResettingMetrics        TearingDownReason = "ResettingMetrics"
+	TearingDownReasonDeletingCSVs            TearingDownReason = "DeletingCSVs"
+	TearingDownReasonReleasingFinalizer      TearingDownReason = "ReleasingFinalizer"
 )
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Parameters",type="string",JSONPath=".status.observedParametersHash",priority=1
 type ReferenceAddon struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
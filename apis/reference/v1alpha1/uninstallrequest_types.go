@@ -0,0 +1,37 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// UninstallRequestSpec defines the desired state of UninstallRequest.
+type UninstallRequestSpec struct {
+	// Reason optionally records why the uninstall was requested.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// UninstallRequest signals that the addon should be uninstalled.
+// An UninstallRequest is only honored when it is named after the
+// operator and created in the addon namespace.
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".spec.reason"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type UninstallRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec UninstallRequestSpec `json:"spec,omitempty"`
+}
+
+// UninstallRequestList contains a list of UninstallRequests
+// +kubebuilder:object:root=true
+type UninstallRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []UninstallRequest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&UninstallRequest{}, &UninstallRequestList{})
+}
@@ -7,12 +7,27 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObservedParameter) DeepCopyInto(out *ObservedParameter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObservedParameter.
+func (in *ObservedParameter) DeepCopy() *ObservedParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(ObservedParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReferenceAddon) DeepCopyInto(out *ReferenceAddon) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -69,6 +84,41 @@ func (in *ReferenceAddonList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReferenceAddonSpec) DeepCopyInto(out *ReferenceAddonSpec) {
 	*out = *in
+	if in.ApplyNetworkPolicies != nil {
+		in, out := &in.ApplyNetworkPolicies, &out.ApplyNetworkPolicies
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableSmokeTest != nil {
+		in, out := &in.EnableSmokeTest, &out.EnableSmokeTest
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		*out = new(string)
+		**out = **in
+	}
+	if in.CustomSize != nil {
+		in, out := &in.CustomSize, &out.CustomSize
+		*out = new(WorkloadSize)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SampleURLs != nil {
+		in, out := &in.SampleURLs, &out.SampleURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NetworkPolicies != nil {
+		in, out := &in.NetworkPolicies, &out.NetworkPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedCIDRs != nil {
+		in, out := &in.AllowedCIDRs, &out.AllowedCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferenceAddonSpec.
@@ -91,6 +141,20 @@ func (in *ReferenceAddonStatus) DeepCopyInto(out *ReferenceAddonStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.UninstallScheduledAt != nil {
+		in, out := &in.UninstallScheduledAt, &out.UninstallScheduledAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Uninstall != nil {
+		in, out := &in.Uninstall, &out.Uninstall
+		*out = new(UninstallReport)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]ObservedParameter, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferenceAddonStatus.
@@ -102,3 +166,139 @@ func (in *ReferenceAddonStatus) DeepCopy() *ReferenceAddonStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UninstallReport) DeepCopyInto(out *UninstallReport) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]UninstallStepReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UninstallReport.
+func (in *UninstallReport) DeepCopy() *UninstallReport {
+	if in == nil {
+		return nil
+	}
+	out := new(UninstallReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UninstallRequest) DeepCopyInto(out *UninstallRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UninstallRequest.
+func (in *UninstallRequest) DeepCopy() *UninstallRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(UninstallRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *UninstallRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UninstallRequestList) DeepCopyInto(out *UninstallRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]UninstallRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UninstallRequestList.
+func (in *UninstallRequestList) DeepCopy() *UninstallRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(UninstallRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *UninstallRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UninstallRequestSpec) DeepCopyInto(out *UninstallRequestSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UninstallRequestSpec.
+func (in *UninstallRequestSpec) DeepCopy() *UninstallRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UninstallRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UninstallStepReport) DeepCopyInto(out *UninstallStepReport) {
+	*out = *in
+	if in.Found != nil {
+		in, out := &in.Found, &out.Found
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Removed != nil {
+		in, out := &in.Removed, &out.Removed
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UninstallStepReport.
+func (in *UninstallStepReport) DeepCopy() *UninstallStepReport {
+	if in == nil {
+		return nil
+	}
+	out := new(UninstallStepReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadSize) DeepCopyInto(out *WorkloadSize) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadSize.
+func (in *WorkloadSize) DeepCopy() *WorkloadSize {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadSize)
+	in.DeepCopyInto(out)
+	return out
+}
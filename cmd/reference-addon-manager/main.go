@@ -17,6 +17,7 @@ import (
 	"github.com/go-logr/logr"
 	av1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
 	refapis "github.com/openshift/reference-addon/apis"
+	"github.com/openshift/reference-addon/internal/controllers"
 	ractrl "github.com/openshift/reference-addon/internal/controllers/referenceaddon"
 	"github.com/openshift/reference-addon/internal/controllers/status"
 	"github.com/openshift/reference-addon/internal/metrics"
@@ -25,15 +26,40 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == schemaCommand {
+		if err := runSchema(os.Stdout, os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Unexpected error occurred while exporting parameter schema: %v\n", err)
+
+			os.Exit(1)
+		}
+
+		return
+	}
+
 	opts := options{
-		DeleteLabel:           "api.openshift.com/addon-reference-addon-delete",
-		EnableMetricsRecorder: true,
-		MetricsAddr:           ":8080",
-		OperatorName:          "reference-addon",
-		ParameterSecretname:   "addon-reference-addon-parameters",
-		ProbeAddr:             ":8081",
-		AddonInstanceName:     "addon-instance",
-		HeartbeatInterval:     10 * time.Second,
+		DeleteLabel:             "api.openshift.com/addon-reference-addon-delete",
+		EnableMetricsRecorder:   true,
+		MetricsAddr:             ":8080",
+		OperatorName:            "reference-addon",
+		InstallMode:             string(controllers.InstallModeOwnNamespace),
+		ReferenceAddonMode:      string(ractrl.ReferenceAddonModeAdopt),
+		ParameterSecretname:     "addon-reference-addon-parameters",
+		ProbeAddr:               ":8081",
+		AddonInstanceName:       "addon-instance",
+		HeartbeatInterval:       10 * time.Second,
+		HeartbeatMode:           string(status.HeartbeatModeEnforce),
+		HeartbeatJitter:         0.1,
+		HeartbeatMinInterval:    time.Second,
+		HeartbeatMaxInterval:    5 * time.Minute,
+		HeartbeatBackoffBase:    time.Second,
+		HeartbeatBackoffMax:     time.Minute,
+		HeartbeatStaleIntervals: 3,
+		UninstallSignalers:      string(ractrl.UninstallSignalerKindConfigMap),
+		UninstallSignalMode:     string(ractrl.UninstallSignalModeAny),
+		ParameterSources:        string(ractrl.ParameterSourceKindSecret),
+		ParameterDirectory:      ractrl.DefaultParameterDirectory,
+		ParameterEnvPrefix:      ractrl.DefaultParameterEnvPrefix,
+		SampleWorkloadImage:     ractrl.DefaultSampleWorkloadImage,
 		Zap: zap.Options{
 			Development: true,
 		},
@@ -84,11 +110,7 @@ func setupManager(log logr.Logger, opts options) (ctrl.Manager, error) {
 	}
 
 	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
-		Cache: cache.Options{
-			DefaultNamespaces: map[string]cache.Config{
-				opts.Namespace: {},
-			},
-		},
+		Cache:                      getCacheOpts(opts),
 		HealthProbeBindAddress:     opts.ProbeAddr,
 		LeaderElectionResourceLock: "leases",
 		LeaderElection:             opts.EnableLeaderElection,
@@ -119,18 +141,47 @@ func setupManager(log logr.Logger, opts options) (ctrl.Manager, error) {
 
 	client := mgr.GetClient()
 
+	newGetter := func(namespace string) (ractrl.ParameterGetter, error) {
+		return ractrl.NewParameterGetter(
+			client,
+			opts.parameterSources(),
+			ractrl.WithNamespace(namespace),
+			ractrl.WithAddonParameterSecretName(opts.ParameterSecretname),
+			ractrl.WithParameterConfigMapName(opts.ParameterConfigMapName),
+			ractrl.WithParameterDirectory(opts.ParameterDirectory),
+			ractrl.WithParameterEnvPrefix(opts.ParameterEnvPrefix),
+		)
+	}
+
+	getter, err := newGetter(opts.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("initializing parameter getter: %w", err)
+	}
+
 	r, err := ractrl.NewReferenceAddonReconciler(
 		client,
-		ractrl.NewSecretParameterGetter(
-			client,
-			ractrl.WithNamespace(opts.Namespace),
-			ractrl.WithName(opts.ParameterSecretname),
-		),
+		getter,
 		ractrl.WithLog{Log: ctrl.Log.WithName("controller").WithName("referenceaddon")},
+		ractrl.WithEventRecorder{Recorder: mgr.GetEventRecorderFor("reference-addon")},
+		ractrl.WithInstallMode(opts.InstallMode),
+		ractrl.WithReferenceAddonMode(opts.ReferenceAddonMode),
+		ractrl.WithParameterGetterFactory{Factory: newGetter},
 		ractrl.WithAddonNamespace(opts.Namespace),
 		ractrl.WithAddonParameterSecretName(opts.ParameterSecretname),
+		ractrl.WithParameterConfigMapName(opts.ParameterConfigMapName),
 		ractrl.WithOperatorName(opts.OperatorName),
 		ractrl.WithDeleteLabel(opts.DeleteLabel),
+		ractrl.WithAddonInstanceNamespace(opts.AddonInstanceNamespace),
+		ractrl.WithAddonInstanceName(opts.AddonInstanceName),
+		ractrl.WithUninstallSignalers(opts.uninstallSignalers()),
+		ractrl.WithUninstallSignalMode(opts.UninstallSignalMode),
+		ractrl.WithUninstallGracePeriod(opts.UninstallGracePeriod),
+		ractrl.WithUninstallDryRun(opts.UninstallDryRun),
+		ractrl.WithCSVLabelSelector(opts.CSVSelector),
+		ractrl.WithCSVVersionRange(opts.CSVVersionRange),
+		ractrl.WithSampleWorkloadImage(opts.SampleWorkloadImage),
+		ractrl.WithNetworkPolicyPruneDryRun(opts.NetworkPolicyPruneDryRun),
+		ractrl.WithNetworkPolicyDriftReportOnly(opts.NetworkPolicyDriftReportOnly),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("initializing reference addon controller: %w", err)
@@ -143,16 +194,30 @@ func setupManager(log logr.Logger, opts options) (ctrl.Manager, error) {
 	statusctlr, err := status.NewStatusControllerReconciler(
 		client,
 		status.WithLog{Log: ctrl.Log.WithName("controller").WithName("status")},
+		status.WithInstallMode(opts.InstallMode),
 		status.WithAddonInstanceNamespace(opts.AddonInstanceNamespace),
 		status.WithAddonInstanceName(opts.AddonInstanceName),
 		status.WithReferenceAddonNamespace(opts.Namespace),
 		status.WithReferenceAddonName(opts.OperatorName),
 		status.WithHeartbeatInterval(opts.HeartbeatInterval),
+		status.WithHeartbeatMode(opts.HeartbeatMode),
+		status.WithHeartbeatJitter(opts.HeartbeatJitter),
+		status.WithHeartbeatMinInterval(opts.HeartbeatMinInterval),
+		status.WithHeartbeatMaxInterval(opts.HeartbeatMaxInterval),
+		status.WithHeartbeatBackoffBase(opts.HeartbeatBackoffBase),
+		status.WithHeartbeatBackoffMax(opts.HeartbeatBackoffMax),
+		status.WithHeartbeatStaleIntervals(opts.HeartbeatStaleIntervals),
+		status.WithHeartbeatRecorder{Recorder: metrics.NewHeartbeatRecorder()},
+		status.WithElected{Elected: mgr.Elected()},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("initializing status controller: %w", err)
 	}
 
+	if err := mgr.AddReadyzCheck("heartbeat", statusctlr.HeartbeatCheck); err != nil {
+		return nil, fmt.Errorf("adding heartbeat readyz check to manager: %w", err)
+	}
+
 	if err := statusctlr.SetupWithManager(mgr); err != nil {
 		return nil, fmt.Errorf("setting up status controller: %w", err)
 	}
@@ -186,6 +251,30 @@ func initializeScheme() (*runtime.Scheme, error) {
 	return scheme, nil
 }
 
+func getCacheOpts(opts options) cache.Options {
+	var namespaces []string
+
+	switch controllers.InstallMode(opts.InstallMode) {
+	case controllers.InstallModeAllNamespaces:
+		// Leaving the default namespaces unset caches all namespaces.
+		return cache.Options{}
+	case controllers.InstallModeMultiNamespace:
+		namespaces = opts.watchNamespaces()
+	default:
+		namespaces = []string{opts.Namespace}
+	}
+
+	defaultNamespaces := make(map[string]cache.Config, len(namespaces))
+
+	for _, ns := range namespaces {
+		defaultNamespaces[ns] = cache.Config{}
+	}
+
+	return cache.Options{
+		DefaultNamespaces: defaultNamespaces,
+	}
+}
+
 func getMetricsOpts(opts options) server.Options {
 	metricsOpts := server.Options{
 		BindAddress: opts.MetricsAddr,
@@ -8,24 +8,49 @@ import (
 	"strings"
 	"time"
 
+	"github.com/openshift/reference-addon/internal/controllers"
+	ractrl "github.com/openshift/reference-addon/internal/controllers/referenceaddon"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 )
 
 type options struct {
-	DeleteLabel            string
-	EnableLeaderElection   bool
-	EnableMetricsRecorder  bool
-	MetricsAddr            string
-	MetricsCertDir         string
-	Namespace              string
-	OperatorName           string
-	ParameterSecretname    string
-	PprofAddr              string
-	ProbeAddr              string
-	AddonInstanceName      string
-	AddonInstanceNamespace string
-	HeartbeatInterval      time.Duration
-	Zap                    zap.Options
+	DeleteLabel                  string
+	EnableLeaderElection         bool
+	EnableMetricsRecorder        bool
+	MetricsAddr                  string
+	MetricsCertDir               string
+	Namespace                    string
+	InstallMode                  string
+	WatchNamespaces              string
+	ReferenceAddonMode           string
+	OperatorName                 string
+	ParameterSecretname          string
+	PprofAddr                    string
+	ProbeAddr                    string
+	AddonInstanceName            string
+	AddonInstanceNamespace       string
+	HeartbeatInterval            time.Duration
+	HeartbeatMode                string
+	HeartbeatJitter              float64
+	HeartbeatMinInterval         time.Duration
+	HeartbeatMaxInterval         time.Duration
+	HeartbeatBackoffBase         time.Duration
+	HeartbeatBackoffMax          time.Duration
+	HeartbeatStaleIntervals      int
+	UninstallSignalers           string
+	UninstallSignalMode          string
+	UninstallGracePeriod         time.Duration
+	UninstallDryRun              bool
+	CSVSelector                  string
+	CSVVersionRange              string
+	ParameterSources             string
+	ParameterConfigMapName       string
+	ParameterDirectory           string
+	ParameterEnvPrefix           string
+	SampleWorkloadImage          string
+	NetworkPolicyPruneDryRun     bool
+	NetworkPolicyDriftReportOnly bool
+	Zap                          zap.Options
 }
 
 func (o *options) Process() error {
@@ -85,6 +110,39 @@ func (o *options) processFlags() {
 		"The namepsace in which the operator will run.",
 	)
 
+	flags.StringVar(
+		&o.InstallMode,
+		"install-mode",
+		o.InstallMode,
+		strings.Join([]string{
+			"Which ReferenceAddons are reconciled.",
+			"'OwnNamespace' creates and reconciles the ReferenceAddon in the operator's namespace,",
+			"'MultiNamespace' reconciles the ReferenceAddons in the namespaces given by -watch-namespaces",
+			"and 'AllNamespaces' reconciles the ReferenceAddons in every namespace.",
+			"Only the ReferenceAddon named after -operator-name is reconciled in each namespace.",
+			"'AllNamespaces' requires the cluster wide RBAC of the config/components/all-namespaces kustomize component.",
+		}, " "),
+	)
+
+	flags.StringVar(
+		&o.WatchNamespaces,
+		"watch-namespaces",
+		o.WatchNamespaces,
+		"Comma separated list of namespaces watched in the 'MultiNamespace' install mode.",
+	)
+
+	flags.StringVar(
+		&o.ReferenceAddonMode,
+		"reference-addon-mode",
+		o.ReferenceAddonMode,
+		strings.Join([]string{
+			"Who owns the lifecycle of the ReferenceAddon.",
+			"'adopt' only reconciles ReferenceAddons created by users",
+			"while 'bootstrap' also creates the ReferenceAddon in the operator's namespace whenever it does not exist.",
+			"The shipped manifests use 'adopt'; include the config/components/bootstrap kustomize component to bootstrap instead.",
+		}, " "),
+	)
+
 	flags.StringVar(
 		&o.OperatorName,
 		"operator-name",
@@ -131,7 +189,155 @@ func (o *options) processFlags() {
 		&o.HeartbeatInterval,
 		"heartbeat-interval",
 		o.HeartbeatInterval,
-		"Time between heartbeats sent to addon instance",
+		"Time between heartbeats sent to addon instance. "+
+			"With heartbeat mode 'adopt' only used while the addon instance does not configure a heartbeat period.",
+	)
+
+	flags.StringVar(
+		&o.HeartbeatMode,
+		"heartbeat-mode",
+		o.HeartbeatMode,
+		"How the heartbeat period is determined: 'enforce' overwrites the addon instance's period with the heartbeat interval, "+
+			"'adopt' pulses at the addon instance's period.",
+	)
+
+	flags.Float64Var(
+		&o.HeartbeatJitter,
+		"heartbeat-jitter",
+		o.HeartbeatJitter,
+		"Maximum fraction of the heartbeat period by which heartbeats are randomly sent early.",
+	)
+
+	flags.DurationVar(
+		&o.HeartbeatMinInterval,
+		"heartbeat-min-interval",
+		o.HeartbeatMinInterval,
+		"Lower bound of the heartbeat period adopted from the addon instance.",
+	)
+
+	flags.DurationVar(
+		&o.HeartbeatMaxInterval,
+		"heartbeat-max-interval",
+		o.HeartbeatMaxInterval,
+		"Upper bound of the heartbeat period adopted from the addon instance.",
+	)
+
+	flags.DurationVar(
+		&o.HeartbeatBackoffBase,
+		"heartbeat-backoff-base",
+		o.HeartbeatBackoffBase,
+		"Delay before retrying a failed heartbeat which doubles with every consecutive failure.",
+	)
+
+	flags.DurationVar(
+		&o.HeartbeatBackoffMax,
+		"heartbeat-backoff-max",
+		o.HeartbeatBackoffMax,
+		"Maximum delay before retrying a failed heartbeat.",
+	)
+
+	flags.IntVar(
+		&o.HeartbeatStaleIntervals,
+		"heartbeat-stale-intervals",
+		o.HeartbeatStaleIntervals,
+		"Number of heartbeat periods without a successful heartbeat after which the readiness check fails.",
+	)
+
+	flags.StringVar(
+		&o.UninstallSignalers,
+		"uninstall-signalers",
+		o.UninstallSignalers,
+		strings.Join([]string{
+			"Comma separated list of mechanisms which signal an uninstall.",
+			"Valid values are 'configmap', 'annotation', 'addoninstance', 'uninstallrequest' and 'parameter'.",
+		}, " "),
+	)
+
+	flags.StringVar(
+		&o.UninstallSignalMode,
+		"uninstall-signal-mode",
+		o.UninstallSignalMode,
+		"Whether 'any' or 'all' of the uninstall signalers must signal an uninstall.",
+	)
+
+	flags.DurationVar(
+		&o.UninstallGracePeriod,
+		"uninstall-grace-period",
+		o.UninstallGracePeriod,
+		"Time to wait after an uninstall is signaled before uninstalling. "+
+			"Withdrawing the signal during this period aborts the uninstall.",
+	)
+
+	flags.BoolVar(
+		&o.UninstallDryRun,
+		"uninstall-dry-run",
+		o.UninstallDryRun,
+		"Only report the ClusterServiceVersions an uninstall would delete.",
+	)
+
+	flags.StringVar(
+		&o.CSVSelector,
+		"csv-selector",
+		o.CSVSelector,
+		"Label selector further restricting the ClusterServiceVersions owned by the addon.",
+	)
+
+	flags.StringVar(
+		&o.CSVVersionRange,
+		"csv-version-range",
+		o.CSVVersionRange,
+		"Semver range (e.g. '>=1.0.0 <2.0.0') restricting the ClusterServiceVersions owned by the addon.",
+	)
+
+	flags.StringVar(
+		&o.ParameterSources,
+		"parameter-sources",
+		o.ParameterSources,
+		"Comma separated list of sources addon parameters are read from in order of priority. "+
+			"Valid values are 'secret', 'configmap', 'file' and 'env'. "+
+			"The 'file' and 'env' sources are shared by all namespaces and may not be used in multi-namespace install modes.",
+	)
+
+	flags.StringVar(
+		&o.ParameterConfigMapName,
+		"parameter-configmap-name",
+		o.ParameterConfigMapName,
+		"Name of the ConfigMap read by the 'configmap' parameter source. Defaults to the parameter Secret name.",
+	)
+
+	flags.StringVar(
+		&o.ParameterDirectory,
+		"parameter-dir",
+		o.ParameterDirectory,
+		"Directory of parameter files read by the 'file' parameter source.",
+	)
+
+	flags.StringVar(
+		&o.ParameterEnvPrefix,
+		"parameter-env-prefix",
+		o.ParameterEnvPrefix,
+		"Prefix of the environment variables read by the 'env' parameter source.",
+	)
+
+	flags.StringVar(
+		&o.SampleWorkloadImage,
+		"sample-workload-image",
+		o.SampleWorkloadImage,
+		"Image run by the sample workload which is sized by the 'size' parameter.",
+	)
+
+	flags.BoolVar(
+		&o.NetworkPolicyPruneDryRun,
+		"network-policy-prune-dry-run",
+		o.NetworkPolicyPruneDryRun,
+		"Only report the stale NetworkPolicies a prune would delete.",
+	)
+
+	flags.BoolVar(
+		&o.NetworkPolicyDriftReportOnly,
+		"network-policy-drift-report-only",
+		o.NetworkPolicyDriftReportOnly,
+		"Only report manual changes to applied NetworkPolicies instead of reverting them.",
 	)
 
 	o.Zap.BindFlags(flags)
@@ -161,14 +367,94 @@ func (o *options) applyValuesFromOptions() {
 	if o.AddonInstanceNamespace == "" {
 		o.AddonInstanceNamespace = o.Namespace
 	}
+
+	if o.ParameterConfigMapName == "" {
+		o.ParameterConfigMapName = o.ParameterSecretname
+	}
 }
 
-var ErrEmptyValue = errors.New("empty value")
+var (
+	ErrEmptyValue = errors.New("empty value")
+	// ErrNamespaceUnawareParameterSource is returned when a parameter
+	// source which reads the same parameters for every namespace is
+	// used to reconcile ReferenceAddons in multiple namespaces.
+	ErrNamespaceUnawareParameterSource = errors.New("parameter source is not namespace aware")
+)
 
 func (o *options) validate() error {
 	if o.Namespace == "" {
 		return fmt.Errorf("validating namespace: %w", ErrEmptyValue)
 	}
 
+	if err := controllers.InstallMode(o.InstallMode).Validate(); err != nil {
+		return fmt.Errorf("validating install mode: %w", err)
+	}
+
+	if o.InstallMode == string(controllers.InstallModeMultiNamespace) && len(o.watchNamespaces()) == 0 {
+		return fmt.Errorf("validating watch namespaces: %w", ErrEmptyValue)
+	}
+
+	if len(o.uninstallSignalers()) == 0 {
+		return fmt.Errorf("validating uninstall signalers: %w", ErrEmptyValue)
+	}
+
+	if len(o.parameterSources()) == 0 {
+		return fmt.Errorf("validating parameter sources: %w", ErrEmptyValue)
+	}
+
+	if controllers.InstallMode(o.InstallMode).IsMultiNamespace() {
+		for _, kind := range o.parameterSources() {
+			switch kind {
+			case ractrl.ParameterSourceKindFile, ractrl.ParameterSourceKindEnv:
+				return fmt.Errorf(
+					"validating parameter sources: %w: %q in install mode %q",
+					ErrNamespaceUnawareParameterSource, kind, o.InstallMode,
+				)
+			}
+		}
+	}
+
 	return nil
 }
+
+func (o *options) watchNamespaces() []string {
+	var namespaces []string
+
+	for _, ns := range strings.Split(o.WatchNamespaces, ",") {
+		if ns = strings.TrimSpace(ns); ns == "" {
+			continue
+		}
+
+		namespaces = append(namespaces, ns)
+	}
+
+	return namespaces
+}
+
+func (o *options) parameterSources() []ractrl.ParameterSourceKind {
+	var kinds []ractrl.ParameterSourceKind
+
+	for _, kind := range strings.Split(o.ParameterSources, ",") {
+		if kind = strings.TrimSpace(kind); kind == "" {
+			continue
+		}
+
+		kinds = append(kinds, ractrl.ParameterSourceKind(strings.ToLower(kind)))
+	}
+
+	return kinds
+}
+
+func (o *options) uninstallSignalers() []ractrl.UninstallSignalerKind {
+	var kinds []ractrl.UninstallSignalerKind
+
+	for _, kind := range strings.Split(o.UninstallSignalers, ",") {
+		if kind = strings.TrimSpace(kind); kind == "" {
+			continue
+		}
+
+		kinds = append(kinds, ractrl.UninstallSignalerKind(strings.ToLower(kind)))
+	}
+
+	return kinds
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	ractrl "github.com/openshift/reference-addon/internal/controllers/referenceaddon"
+)
+
+const schemaCommand = "schema"
+
+const (
+	schemaFormatJSONSchema = "jsonschema"
+	schemaFormatOCM        = "ocm"
+)
+
+// runSchema writes the addon parameter schema to out in the
+// format selected by the given arguments.
+func runSchema(out io.Writer, args []string) error {
+	flags := flag.NewFlagSet(schemaCommand, flag.ContinueOnError)
+
+	format := flags.String(
+		"format",
+		schemaFormatJSONSchema,
+		fmt.Sprintf("Output format of the parameter schema: %q or %q.", schemaFormatJSONSchema, schemaFormatOCM),
+	)
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	reg := ractrl.DefaultParameterRegistry()
+
+	var doc any
+
+	switch *format {
+	case schemaFormatJSONSchema:
+		doc = reg.JSONSchema()
+	case schemaFormatOCM:
+		doc = reg.OCMAddonParameters()
+	default:
+		return fmt.Errorf("unknown schema format %q", *format)
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encoding parameter schema: %w", err)
+	}
+
+	return nil
+}
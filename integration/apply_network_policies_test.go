@@ -43,6 +43,7 @@ var _ = Describe("Apply Network Policies Phase", func() {
 			"-namespace", namespace,
 			"-delete-label", deleteLabel,
 			"-operator-name", operatorName,
+			"-reference-addon-mode", "bootstrap",
 			"-parameter-secret-name", parameterSecretName,
 			"-kubeconfig", _kubeConfigPath,
 			"-health-probe-bind-address", "0",
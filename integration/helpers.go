@@ -15,6 +15,7 @@ import (
 	internaltesting "github.com/openshift/reference-addon/internal/testing"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -64,6 +65,53 @@ func getRBAC(namespace, group string) ([]client.Object, error) {
 	}, nil
 }
 
+// getDeploymentArgs returns the manager container args shipped in
+// config/deploy/deployment.yaml. Leader election is dropped since the
+// lease namespace cannot be determined when running outside of a cluster.
+func getDeploymentArgs() ([]string, error) {
+	root, err := projectRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := internaltesting.LoadUnstructuredFromFile(filepath.Join(root, "config", "deploy", "deployment.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("loading deployment: %w", err)
+	}
+
+	deployment, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected deployment type %T: %w", obj, errSetup)
+	}
+
+	containers, _, err := unstructured.NestedSlice(deployment.Object, "spec", "template", "spec", "containers")
+	if err != nil || len(containers) == 0 {
+		return nil, fmt.Errorf("finding manager container: %w", errSetup)
+	}
+
+	container, ok := containers[0].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("decoding manager container: %w", errSetup)
+	}
+
+	args, _, err := unstructured.NestedStringSlice(container, "args")
+	if err != nil {
+		return nil, fmt.Errorf("decoding manager args: %w", err)
+	}
+
+	res := make([]string, 0, len(args))
+
+	for _, arg := range args {
+		if arg == "--enable-leader-election" {
+			continue
+		}
+
+		res = append(res, arg)
+	}
+
+	return res, nil
+}
+
 func projectRoot() (string, error) {
 	var buf bytes.Buffer
 
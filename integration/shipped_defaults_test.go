@@ -0,0 +1,122 @@
+package integration
+
+import (
+	"context"
+	"os/exec"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gexec"
+	av1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	addoninstance "github.com/openshift/addon-operator/pkg/client"
+	"github.com/openshift/reference-addon/internal/controllers/status"
+	internaltesting "github.com/openshift/reference-addon/internal/testing"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Shipped Deployment defaults", func() {
+	var (
+		ctx                  context.Context
+		cancel               context.CancelFunc
+		addonInstanceName    string
+		addonInstanceNameGen = nameGenerator("ai-defaults-name")
+		namespace            string
+		namespaceGen         = nameGenerator("ref-defaults-namespace")
+		operatorName         string
+		operatorNameGen      = nameGenerator("ref-defaults-name")
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		addonInstanceName = addonInstanceNameGen()
+		namespace = namespaceGen()
+		operatorName = operatorNameGen()
+
+		By("Starting manager with the shipped Deployment args")
+
+		args, err := getDeploymentArgs()
+		Expect(err).ToNot(HaveOccurred())
+
+		args = append(args,
+			"-addon-instance-name", addonInstanceName,
+			"-addon-instance-namespace", namespace,
+			"-namespace", namespace,
+			"-operator-name", operatorName,
+			"-kubeconfig", _kubeConfigPath,
+			"-heartbeat-interval", "1s",
+			"-health-probe-bind-address", "0",
+			"-metrics-addr", "0",
+		)
+
+		manager := exec.Command(_binPath, args...)
+
+		session, err := Start(manager, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		By("Creating the addon namespace")
+
+		ns := addonNamespace(namespace)
+		addonInstance := addonInstanceObject(addonInstanceName, namespace)
+
+		_client.Create(ctx, &ns)
+		_client.Create(ctx, &addonInstance)
+
+		rbac, err := getRBAC(namespace, managerGroup)
+		Expect(err).ToNot(HaveOccurred())
+
+		for _, obj := range rbac {
+			_client.Create(ctx, obj)
+		}
+
+		DeferCleanup(func() {
+			cancel()
+
+			By("Stopping the managers")
+
+			session.Interrupt()
+
+			if usingExistingCluster() {
+				By("Deleting test namspace")
+
+				_client.Delete(ctx, &ns)
+			}
+		})
+	})
+
+	It("should not create a ReferenceAddon without a user supplied CR", func() {
+		addon := referenceAddon(operatorName, namespace)
+		_client.ConsistentlyObjectDoesNotExist(ctx, &addon, internaltesting.WithTimeout(5*time.Second))
+	})
+
+	It("should report the AddonInstance as Installed and Available once a ReferenceAddon is created", func() {
+		By("Creating the ReferenceAddon")
+
+		addon := referenceAddon(operatorName, namespace)
+		_client.Create(ctx, &addon)
+
+		addonInstance := addonInstanceObject(addonInstanceName, namespace)
+		_client.EventuallyObjectExists(ctx, &addonInstance, internaltesting.WithTimeout(10*time.Second))
+
+		expectedConditions := []metav1.Condition{
+			addoninstance.NewAddonInstanceConditionInstalled(
+				"True",
+				av1alpha1.AddonInstanceInstalledReasonSetupComplete,
+				"All Components Available",
+			),
+			{
+				Type:    status.AddonInstanceConditionAvailable.String(),
+				Status:  "True",
+				Reason:  "Ready",
+				Message: "all reconcile phases completed successfully",
+			},
+		}
+
+		Eventually(func() []metav1.Condition {
+			_client.Get(ctx, &addonInstance)
+
+			return addonInstance.Status.Conditions
+		}, 30*time.Second).Should(ContainElements(equalConditions(expectedConditions)...))
+	})
+})
@@ -12,6 +12,7 @@ import (
 	"github.com/onsi/gomega/types"
 	av1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
 	addoninstance "github.com/openshift/addon-operator/pkg/client"
+	"github.com/openshift/reference-addon/internal/controllers/status"
 	internaltesting "github.com/openshift/reference-addon/internal/testing"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -47,6 +48,7 @@ var _ = Describe("Status Controller", func() {
 			"-namespace", namespace,
 			"-delete-label", deleteLabel,
 			"-operator-name", operatorName,
+			"-reference-addon-mode", "bootstrap",
 			"-kubeconfig", _kubeConfigPath,
 			"-heartbeat-interval", heartbeatInterval.String(),
 			"-health-probe-bind-address", "0",
@@ -92,21 +94,85 @@ var _ = Describe("Status Controller", func() {
 				addonInstance := addonInstanceObject(addonInstanceName, namespace)
 				_client.EventuallyObjectExists(ctx, &addonInstance, internaltesting.WithTimeout(10*time.Second))
 
-				expectedCondition := addoninstance.NewAddonInstanceConditionInstalled(
-					"True",
-					av1alpha1.AddonInstanceInstalledReasonSetupComplete,
-					"All Components Available",
-				)
+				expectedConditions := []metav1.Condition{
+					addoninstance.NewAddonInstanceConditionInstalled(
+						"True",
+						av1alpha1.AddonInstanceInstalledReasonSetupComplete,
+						"All Components Available",
+					),
+					{
+						Type:    status.AddonInstanceConditionAvailable.String(),
+						Status:  "True",
+						Reason:  "Ready",
+						Message: "all reconcile phases completed successfully",
+					},
+					addoninstance.NewAddonInstanceConditionDegraded(
+						"False",
+						"AsExpected",
+						"all reconcile phases completed successfully",
+					),
+				}
 
 				Eventually(func() []metav1.Condition {
 					_client.Get(ctx, &addonInstance)
 
 					return addonInstance.Status.Conditions
-				}, 30*time.Second).Should(ContainElements(EqualCondition(expectedCondition)))
+				}, 30*time.Second).Should(ContainElements(equalConditions(expectedConditions)...))
 
 				fmt.Printf("%+v\n", addonInstance)
 
 				Expect(addonInstance.Spec.HeartbeatUpdatePeriod.Duration).To(Equal(heartbeatInterval))
+
+				Expect(addonInstance.Status.Conditions).ToNot(ContainElement(
+					HaveField("Type", av1alpha1.AddonInstanceConditionReadyToBeDeleted.String()),
+				), "ReadyToBeDeleted is only reported once an uninstall is requested")
+			})
+		})
+
+		Context("Uninstall Requested", func() {
+			BeforeEach(func() {
+				By("Ensuring the addon CSV exists")
+
+				csv := addonCSV(operatorName, namespace)
+				_client.Create(ctx, &csv)
+
+				By("Creating the uninstall ConfigMap")
+
+				cm := deleteConfigMapWithLabel(operatorName, namespace, deleteLabel)
+				_client.Create(ctx, &cm)
+
+				DeferCleanup(func() {
+					_client.Delete(ctx, &cm)
+				})
+			})
+
+			It("Addon Instance should report teardown completion", func() {
+				addonInstance := addonInstanceObject(addonInstanceName, namespace)
+				_client.EventuallyObjectExists(ctx, &addonInstance, internaltesting.WithTimeout(10*time.Second))
+
+				expectedConditions := []metav1.Condition{
+					addoninstance.NewAddonInstanceConditionInstalled(
+						"False",
+						av1alpha1.AddonInstanceInstalledReasonTeardownComplete,
+						"All Components Removed",
+					),
+					addoninstance.NewAddonInstanceConditionReadyToBeDeleted(
+						"True",
+						av1alpha1.AddonInstanceReasonReadyToBeDeleted,
+						"All teardown steps completed",
+					),
+					addoninstance.NewAddonInstanceConditionDegraded(
+						"False",
+						"AsExpected",
+						"Teardown completed",
+					),
+				}
+
+				Eventually(func() []metav1.Condition {
+					_client.Get(ctx, &addonInstance)
+
+					return addonInstance.Status.Conditions
+				}, 30*time.Second).Should(ContainElements(equalConditions(expectedConditions)...))
 			})
 		})
 	})
@@ -121,3 +187,13 @@ func EqualCondition(expected metav1.Condition) types.GomegaMatcher {
 		HaveField("Message", expected.Message),
 	)
 }
+
+func equalConditions(expected []metav1.Condition) []interface{} {
+	matchers := make([]interface{}, 0, len(expected))
+
+	for _, cond := range expected {
+		matchers = append(matchers, EqualCondition(cond))
+	}
+
+	return matchers
+}
@@ -2,12 +2,14 @@ package integration
 
 import (
 	"context"
+	"fmt"
 	"os/exec"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gexec"
+	refv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
 	internaltesting "github.com/openshift/reference-addon/internal/testing"
 	opsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
@@ -42,6 +44,7 @@ var _ = Describe("Uninstall Phase", func() {
 			"-namespace", namespace,
 			"-delete-label", deleteLabel,
 			"-operator-name", operatorName,
+			"-reference-addon-mode", "bootstrap",
 			"-parameter-secret-name", parameterSecretName,
 			"-kubeconfig", _kubeConfigPath,
 			"-health-probe-bind-address", "0",
@@ -122,7 +125,18 @@ var _ = Describe("Uninstall Phase", func() {
 				_client.Update(ctx, &updatedCM)
 
 				csv := addonCSV(operatorName, namespace)
-				_client.EventuallyObjectDoesNotExist(ctx, &csv, internaltesting.WithTimeout(2*time.Second))
+				_client.EventuallyObjectDoesNotExist(ctx, &csv, internaltesting.WithTimeout(10*time.Second))
+			})
+
+			It("should tear down the ReferenceAddon and its NetworkPolicies", func() {
+				updatedCM := deleteConfigMapWithLabel(operatorName, namespace, deleteLabel)
+				_client.Update(ctx, &updatedCM)
+
+				addon := referenceAddon(operatorName, namespace)
+				_client.EventuallyObjectDoesNotExist(ctx, &addon, internaltesting.WithTimeout(10*time.Second))
+
+				np := addonNetworkPolicy(fmt.Sprintf("%s-ingress", operatorName), namespace)
+				_client.EventuallyObjectDoesNotExist(ctx, &np, internaltesting.WithTimeout(10*time.Second))
 			})
 		})
 	})
@@ -136,6 +150,15 @@ func addonNamespace(name string) corev1.Namespace {
 	}
 }
 
+func referenceAddon(name, ns string) refv1alpha1.ReferenceAddon {
+	return refv1alpha1.ReferenceAddon{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+		},
+	}
+}
+
 func addonCSV(name, ns string) opsv1alpha1.ClusterServiceVersion {
 	return opsv1alpha1.ClusterServiceVersion{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,251 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/csaupgrade"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const (
+	// DefaultFieldManager is the field manager owning the
+	// fields of objects applied by the reference-addon.
+	DefaultFieldManager = "reference-addon"
+	// LegacyFieldManager is the field manager the API server inferred
+	// from the manager's user agent for objects written before
+	// server-side apply was used.
+	LegacyFieldManager = "reference-addon-manager"
+)
+
+// NewApplier returns an Applier writing objects through the given client.
+func NewApplier(c client.Client, opts ...ApplierOption) *Applier {
+	var cfg ApplierConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	return &Applier{
+		cfg:    cfg,
+		client: c,
+	}
+}
+
+// Applier writes objects using server-side apply so that only the
+// fields set on the desired object are owned by the reference-addon
+// and fields set by other controllers or admins are left untouched.
+type Applier struct {
+	cfg ApplierConfig
+
+	client client.Client
+}
+
+// Apply server-side applies the given object and updates it with the
+// state returned by the API server. If a field of the object is owned
+// by another field manager and ownership is not forced an
+// *ApplyConflictError is returned.
+func (a *Applier) Apply(ctx context.Context, obj client.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, a.client.Scheme())
+	if err != nil {
+		return fmt.Errorf("getting GroupVersionKind: %w", err)
+	}
+
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+
+	if err := a.upgradeManagedFields(ctx, obj); err != nil {
+		return fmt.Errorf("upgrading managed fields of %s %q: %w", gvk.Kind, obj.GetName(), err)
+	}
+
+	obj.SetResourceVersion("")
+	obj.SetManagedFields(nil)
+
+	opts := []client.PatchOption{client.FieldOwner(a.cfg.FieldManager)}
+
+	if a.cfg.ForceOwnership {
+		opts = append(opts, client.ForceOwnership)
+	}
+
+	if err := a.client.Patch(ctx, obj, client.Apply, opts...); err != nil {
+		if apierrors.IsConflict(err) {
+			return newApplyConflictError(gvk.Kind, obj.GetName(), err)
+		}
+
+		return fmt.Errorf("applying %s %q: %w", gvk.Kind, obj.GetName(), err)
+	}
+
+	return nil
+}
+
+// upgradeManagedFields transfers the fields owned by the legacy
+// field managers of an existing object to the configured field
+// manager so that they are not reported as conflicts.
+func (a *Applier) upgradeManagedFields(ctx context.Context, obj client.Object) error {
+	if len(a.cfg.LegacyFieldManagers) == 0 {
+		return nil
+	}
+
+	actual, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("unexpected object type %T", obj)
+	}
+
+	if err := a.client.Get(ctx, client.ObjectKeyFromObject(obj), actual); apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("getting object: %w", err)
+	}
+
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(
+		actual, sets.New(a.cfg.LegacyFieldManagers...), a.cfg.FieldManager,
+	)
+	if err != nil {
+		return fmt.Errorf("computing managed fields patch: %w", err)
+	}
+
+	if patch == nil {
+		return nil
+	}
+
+	return a.client.Patch(ctx, actual, client.RawPatch(types.JSONPatchType, patch))
+}
+
+type ApplierConfig struct {
+	// FieldManager owns the fields of applied objects.
+	FieldManager string
+	// ForceOwnership takes ownership of fields which are
+	// owned by other field managers instead of conflicting.
+	ForceOwnership bool
+	// LegacyFieldManagers previously wrote the applied objects
+	// using updates and are upgraded to FieldManager.
+	LegacyFieldManagers []string
+}
+
+func (c *ApplierConfig) Option(opts ...ApplierOption) {
+	for _, opt := range opts {
+		opt.ConfigureApplier(c)
+	}
+}
+
+func (c *ApplierConfig) Default() {
+	if c.FieldManager == "" {
+		c.FieldManager = DefaultFieldManager
+	}
+
+	if c.LegacyFieldManagers == nil {
+		c.LegacyFieldManagers = []string{LegacyFieldManager}
+	}
+}
+
+type ApplierOption interface {
+	ConfigureApplier(*ApplierConfig)
+}
+
+type WithFieldManager string
+
+func (w WithFieldManager) ConfigureApplier(c *ApplierConfig) {
+	c.FieldManager = string(w)
+}
+
+type WithForceOwnership bool
+
+func (w WithForceOwnership) ConfigureApplier(c *ApplierConfig) {
+	c.ForceOwnership = bool(w)
+}
+
+type WithLegacyFieldManagers []string
+
+func (w WithLegacyFieldManagers) ConfigureApplier(c *ApplierConfig) {
+	c.LegacyFieldManagers = []string(w)
+}
+
+func newApplyConflictError(kind, name string, err error) *ApplyConflictError {
+	conflict := &ApplyConflictError{
+		Kind: kind,
+		Name: name,
+		err:  err,
+	}
+
+	var status apierrors.APIStatus
+
+	if !errors.As(err, &status) || status.Status().Details == nil {
+		return conflict
+	}
+
+	managers := sets.New[string]()
+
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+
+		conflict.Fields = append(conflict.Fields, cause.Field)
+
+		// Causes are reported as: conflict with "<manager>"[ using <version>]
+		if _, rest, ok := strings.Cut(cause.Message, `"`); ok {
+			if manager, _, ok := strings.Cut(rest, `"`); ok {
+				managers.Insert(manager)
+			}
+		}
+	}
+
+	conflict.Managers = sets.List(managers)
+	sort.Strings(conflict.Fields)
+
+	return conflict
+}
+
+// ApplyConflictError is returned when applying an object
+// conflicts with fields owned by other field managers.
+type ApplyConflictError struct {
+	Kind string
+	Name string
+	// Managers are the field managers owning the conflicting fields.
+	Managers []string
+	// Fields are the paths of the conflicting fields.
+	Fields []string
+
+	err error
+}
+
+func (e *ApplyConflictError) Error() string {
+	if len(e.Managers) == 0 {
+		return fmt.Sprintf("applying %s %q: %v", e.Kind, e.Name, e.err)
+	}
+
+	quoted := make([]string, 0, len(e.Managers))
+
+	for _, m := range e.Managers {
+		quoted = append(quoted, strconv.Quote(m))
+	}
+
+	noun := "field manager"
+	if len(quoted) > 1 {
+		noun = "field managers"
+	}
+
+	return fmt.Sprintf(
+		"applying %s %q: conflict with %s %s on [%s]",
+		e.Kind, e.Name, noun, strings.Join(quoted, ", "), strings.Join(e.Fields, ", "),
+	)
+}
+
+func (e *ApplyConflictError) Unwrap() error {
+	return e.err
+}
+
+// IsApplyConflict returns true if the given error or
+// any error it wraps is an *ApplyConflictError.
+func IsApplyConflict(err error) bool {
+	var conflict *ApplyConflictError
+
+	return errors.As(err, &conflict)
+}
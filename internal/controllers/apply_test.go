@@ -0,0 +1,152 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	internaltesting "github.com/openshift/reference-addon/internal/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestApplier_Apply(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	var patchOpts []client.PatchOption
+
+	funcs := internaltesting.ServerSideApplyFuncs()
+	emulate := funcs.Patch
+	funcs.Patch = func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+		if patch.Type() == types.ApplyPatchType {
+			patchOpts = opts
+		}
+
+		return emulate(ctx, c, obj, patch, opts...)
+	}
+
+	c := fake.
+		NewClientBuilder().
+		WithInterceptorFuncs(funcs).
+		Build()
+
+	applier := NewApplier(c)
+
+	desired := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-namespace",
+			Labels:    map[string]string{"applied": "true"},
+		},
+		Data: map[string]string{"key": "value"},
+	}
+
+	require.NoError(t, applier.Apply(ctx, desired.DeepCopy()))
+	assert.Equal(t, []client.PatchOption{client.FieldOwner(DefaultFieldManager)}, patchOpts)
+
+	var actual corev1.ConfigMap
+
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(desired), &actual))
+
+	actual.Labels["admin"] = "true"
+	require.NoError(t, c.Update(ctx, &actual))
+
+	desired.Data["key"] = "changed"
+
+	require.NoError(t, applier.Apply(ctx, desired.DeepCopy()))
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(desired), &actual))
+
+	assert.Equal(t, "changed", actual.Data["key"])
+	assert.Equal(t, map[string]string{"applied": "true", "admin": "true"}, actual.Labels,
+		"labels added by others are kept")
+
+	forced := NewApplier(c, WithFieldManager("test"), WithForceOwnership(true))
+
+	require.NoError(t, forced.Apply(ctx, desired.DeepCopy()))
+	assert.Equal(t, []client.PatchOption{client.FieldOwner("test"), client.ForceOwnership}, patchOpts)
+}
+
+func TestApplier_ApplyConflict(t *testing.T) {
+	t.Parallel()
+
+	c := fake.
+		NewClientBuilder().
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(context.Context, client.WithWatch, client.Object, client.Patch, ...client.PatchOption) error {
+				return apierrors.NewApplyConflict([]metav1.StatusCause{
+					{
+						Type:    metav1.CauseTypeFieldManagerConflict,
+						Message: `conflict with "kubectl-edit" using v1`,
+						Field:   ".data.key",
+					},
+					{
+						Type:    metav1.CauseTypeFieldManagerConflict,
+						Message: `conflict with "other-controller"`,
+						Field:   ".data.other",
+					},
+				}, "Apply failed with 2 conflicts")
+			},
+		}).
+		Build()
+
+	err := NewApplier(c).Apply(context.Background(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-namespace",
+		},
+	})
+	require.Error(t, err)
+	require.True(t, IsApplyConflict(err))
+	assert.True(t, apierrors.IsConflict(err), "API error is wrapped")
+
+	assert.EqualError(t, err,
+		`applying ConfigMap "test": conflict with field managers "kubectl-edit", "other-controller" on [.data.key, .data.other]`,
+	)
+}
+
+func TestApplier_UpgradeManagedFields(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-namespace",
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{
+					Manager:    LegacyFieldManager,
+					Operation:  metav1.ManagedFieldsOperationUpdate,
+					APIVersion: "v1",
+					FieldsType: "FieldsV1",
+					FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:key":{}}}`)},
+				},
+			},
+		},
+		Data: map[string]string{"key": "value"},
+	}
+
+	c := fake.
+		NewClientBuilder().
+		WithObjects(existing).
+		WithInterceptorFuncs(internaltesting.ServerSideApplyFuncs()).
+		Build()
+
+	require.NoError(t, NewApplier(c).Apply(ctx, existing.DeepCopy()))
+
+	var actual corev1.ConfigMap
+
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(existing), &actual))
+	require.Len(t, actual.ManagedFields, 1)
+
+	assert.Equal(t, DefaultFieldManager, actual.ManagedFields[0].Manager)
+	assert.Equal(t, metav1.ManagedFieldsOperationApply, actual.ManagedFields[0].Operation)
+}
@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	opsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// OLMPackageLabelPrefix prefixes the label OLM applies to the
+// resources of an operator in the form
+// "operators.coreos.com/<package>.<namespace>".
+const OLMPackageLabelPrefix = "operators.coreos.com/"
+
+// OLMCopiedFromLabel is applied by OLM to the copies of a CSV which
+// it creates in every target namespace of an operator installed in
+// AllNamespaces or MultiNamespace mode. The value is the namespace
+// of the original CSV.
+const OLMCopiedFromLabel = "olm.copiedFrom"
+
+// OLMPackageLabel returns the label OLM applies to the resources
+// of the given package installed in the given namespace.
+func OLMPackageLabel(pkg, namespace string) string {
+	return OLMPackageLabelPrefix + pkg + "." + namespace
+}
+
+// NewCSVMatcher returns a CSVMatcher for the given package. The optional
+// selector and version range are parsed from their string forms and may
+// be left empty.
+func NewCSVMatcher(pkg, selector, versionRange string) (CSVMatcher, error) {
+	m := CSVMatcher{
+		Package: pkg,
+	}
+
+	if selector != "" {
+		sel, err := labels.Parse(selector)
+		if err != nil {
+			return m, fmt.Errorf("parsing label selector %q: %w", selector, err)
+		}
+
+		m.Selector = sel
+	}
+
+	if versionRange != "" {
+		rng, err := semver.ParseRange(versionRange)
+		if err != nil {
+			return m, fmt.Errorf("parsing version range %q: %w", versionRange, err)
+		}
+
+		m.VersionRange = rng
+	}
+
+	return m, nil
+}
+
+// CSVMatcher selects the ClusterServiceVersions which belong to an operator.
+type CSVMatcher struct {
+	// Package is the operator's package name. CSVs carrying OLM's package
+	// label for their namespace match. CSVs not yet labeled by OLM match
+	// only if named after the package with an optional ".v<version>" suffix.
+	Package string
+	// Selector additionally restricts CSVs by their labels.
+	Selector labels.Selector
+	// VersionRange additionally restricts CSVs by their spec.version.
+	VersionRange semver.Range
+}
+
+// Matches returns true if the given CSV belongs to the operator.
+// Copied CSVs never match since they are managed by OLM and are
+// removed along with the original CSV.
+func (m CSVMatcher) Matches(csv *opsv1alpha1.ClusterServiceVersion) bool {
+	if _, ok := csv.Labels[OLMCopiedFromLabel]; ok {
+		return false
+	}
+
+	if m.Package != "" && !m.matchesPackage(csv) {
+		return false
+	}
+
+	if m.Selector != nil && !m.Selector.Matches(labels.Set(csv.Labels)) {
+		return false
+	}
+
+	if m.VersionRange != nil && !m.VersionRange(csv.Spec.Version.Version) {
+		return false
+	}
+
+	return true
+}
+
+func (m CSVMatcher) matchesPackage(csv *opsv1alpha1.ClusterServiceVersion) bool {
+	if _, ok := csv.Labels[OLMPackageLabel(m.Package, csv.Namespace)]; ok {
+		return true
+	}
+
+	return csv.Name == m.Package || strings.HasPrefix(csv.Name, m.Package+".v")
+}
+
+// MatchesCSV filters events to ClusterServiceVersions selected
+// by the given matcher.
+func MatchesCSV(m CSVMatcher) predicate.Funcs {
+	return predicate.NewPredicateFuncs(
+		func(obj client.Object) bool {
+			csv, ok := obj.(*opsv1alpha1.ClusterServiceVersion)
+			if !ok {
+				return false
+			}
+
+			return m.Matches(csv)
+		},
+	)
+}
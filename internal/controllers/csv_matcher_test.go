@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/operator-framework/api/pkg/lib/version"
+	opsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewCSVMatcher(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Selector     string
+		VersionRange string
+		AssertError  require.ErrorAssertionFunc
+	}{
+		"package only": {
+			AssertError: require.NoError,
+		},
+		"valid selector and range": {
+			Selector:     "channel=stable",
+			VersionRange: ">=1.0.0 <2.0.0",
+			AssertError:  require.NoError,
+		},
+		"invalid selector": {
+			Selector:    "channel in (",
+			AssertError: require.Error,
+		},
+		"invalid range": {
+			VersionRange: "not-a-range",
+			AssertError:  require.Error,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewCSVMatcher("test-operator", tc.Selector, tc.VersionRange)
+			tc.AssertError(t, err)
+		})
+	}
+}
+
+func TestCSVMatcher_Matches(t *testing.T) {
+	t.Parallel()
+
+	csv := func(name, ver string, lbls map[string]string) *opsv1alpha1.ClusterServiceVersion {
+		return &opsv1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test-namespace",
+				Labels:    lbls,
+			},
+			Spec: opsv1alpha1.ClusterServiceVersionSpec{
+				Version: version.OperatorVersion{Version: semver.MustParse(ver)},
+			},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		Selector     string
+		VersionRange string
+		CSV          *opsv1alpha1.ClusterServiceVersion
+		AssertResult assert.BoolAssertionFunc
+	}{
+		"exact name": {
+			CSV:          csv("test-operator", "1.0.0", nil),
+			AssertResult: assert.True,
+		},
+		"versioned name": {
+			CSV:          csv("test-operator.v1.0.0", "1.0.0", nil),
+			AssertResult: assert.True,
+		},
+		"name sharing prefix": {
+			CSV:          csv("test-operator-foo.v1.0.0", "1.0.0", nil),
+			AssertResult: assert.False,
+		},
+		"package label": {
+			CSV: csv("renamed.v1.0.0", "1.0.0", map[string]string{
+				OLMPackageLabel("test-operator", "test-namespace"): "",
+			}),
+			AssertResult: assert.True,
+		},
+		"package label for other namespace": {
+			CSV: csv("renamed.v1.0.0", "1.0.0", map[string]string{
+				OLMPackageLabel("test-operator", "other-namespace"): "",
+			}),
+			AssertResult: assert.False,
+		},
+		"copied": {
+			CSV: csv("test-operator.v1.0.0", "1.0.0", map[string]string{
+				OLMCopiedFromLabel: "operator-namespace",
+			}),
+			AssertResult: assert.False,
+		},
+		"selector matches": {
+			Selector:     "channel=stable",
+			CSV:          csv("test-operator.v1.0.0", "1.0.0", map[string]string{"channel": "stable"}),
+			AssertResult: assert.True,
+		},
+		"selector does not match": {
+			Selector:     "channel=stable",
+			CSV:          csv("test-operator.v1.0.0", "1.0.0", map[string]string{"channel": "beta"}),
+			AssertResult: assert.False,
+		},
+		"version in range": {
+			VersionRange: ">=1.0.0 <2.0.0",
+			CSV:          csv("test-operator.v1.2.0", "1.2.0", nil),
+			AssertResult: assert.True,
+		},
+		"version out of range": {
+			VersionRange: ">=1.0.0 <2.0.0",
+			CSV:          csv("test-operator.v2.0.0", "2.0.0", nil),
+			AssertResult: assert.False,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := NewCSVMatcher("test-operator", tc.Selector, tc.VersionRange)
+			require.NoError(t, err)
+
+			tc.AssertResult(t, m.Matches(tc.CSV))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
@@ -26,6 +27,10 @@ func StringPtr(s string) *string {
 	return &s
 }
 
+func Int32Ptr(i int32) *int32 {
+	return &i
+}
+
 func HasNamePrefix(pfx string) predicate.Funcs {
 	return predicate.NewPredicateFuncs(
 		func(obj client.Object) bool {
@@ -41,3 +46,13 @@ func HasName(name string) predicate.Funcs {
 		},
 	)
 }
+
+// NopEventRecorder discards all recorded events.
+type NopEventRecorder struct{}
+
+func (NopEventRecorder) Event(runtime.Object, string, string, string) {}
+
+func (NopEventRecorder) Eventf(runtime.Object, string, string, string, ...interface{}) {}
+
+func (NopEventRecorder) AnnotatedEventf(runtime.Object, map[string]string, string, string, string, ...interface{}) {
+}
@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+)
+
+// InstallMode mirrors the OLM install modes advertised by the
+// ClusterServiceVersion and determines which ReferenceAddons
+// are reconciled.
+type InstallMode string
+
+const (
+	// InstallModeOwnNamespace reconciles the single ReferenceAddon
+	// named after the operator in the operator's namespace.
+	InstallModeOwnNamespace InstallMode = "OwnNamespace"
+	// InstallModeMultiNamespace reconciles the ReferenceAddon named
+	// after the operator in each of a set of watched namespaces.
+	InstallModeMultiNamespace InstallMode = "MultiNamespace"
+	// InstallModeAllNamespaces reconciles the ReferenceAddon named
+	// after the operator in every namespace of the cluster.
+	InstallModeAllNamespaces InstallMode = "AllNamespaces"
+)
+
+var ErrUnknownInstallMode = errors.New("unknown install mode")
+
+// Validate returns an error if the install mode is not supported.
+func (m InstallMode) Validate() error {
+	switch m {
+	case InstallModeOwnNamespace, InstallModeMultiNamespace, InstallModeAllNamespaces:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownInstallMode, m)
+	}
+}
+
+// IsMultiNamespace returns true if ReferenceAddons outside of
+// the operator's namespace are reconciled.
+func (m InstallMode) IsMultiNamespace() bool {
+	return m == InstallModeMultiNamespace || m == InstallModeAllNamespaces
+}
@@ -0,0 +1,54 @@
+package referenceaddon
+
+import (
+	refv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	EventReasonInvalidParameters         = "InvalidParameters"
+	EventReasonPhaseFailed               = "PhaseFailed"
+	EventReasonPhaseRecovered            = "PhaseRecovered"
+	EventReasonNetworkPoliciesApplied    = "NetworkPoliciesApplied"
+	EventReasonNetworkPoliciesRemoved    = "NetworkPoliciesRemoved"
+	EventReasonNetworkPoliciesFailed     = "NetworkPoliciesFailed"
+	EventReasonNetworkPolicyPruned       = "NetworkPolicyPruned"
+	EventReasonNetworkPolicyPruneDryRun  = "NetworkPolicyPruneDryRun"
+	EventReasonNetworkPolicyDrifted      = "NetworkPolicyDrifted"
+	EventReasonSmokeTestEnabled          = "SmokeTestEnabled"
+	EventReasonSmokeTestDisabled         = "SmokeTestDisabled"
+	EventReasonMetricsSampled            = "MetricsSampled"
+	EventReasonUninstallScheduled        = "UninstallScheduled"
+	EventReasonUninstallAborted          = "UninstallAborted"
+	EventReasonUninstallDryRun           = "UninstallDryRun"
+	EventReasonUninstallStarted          = "UninstallStarted"
+	EventReasonUninstallFailed           = "UninstallFailed"
+	EventReasonCSVsDeleted               = "CSVsDeleted"
+	EventReasonWorkloadProgressing       = "WorkloadProgressing"
+	EventReasonWorkloadAvailable         = "WorkloadAvailable"
+	EventReasonWorkloadFailed            = "WorkloadFailed"
+	EventReasonResourceGuardrailsApplied = "ResourceGuardrailsApplied"
+	EventReasonResourceGuardrailsRemoved = "ResourceGuardrailsRemoved"
+	EventReasonResourceGuardrailsDrifted = "ResourceGuardrailsDrifted"
+	EventReasonResourceGuardrailsFailed  = "ResourceGuardrailsFailed"
+)
+
+// recordConditionEvent emits an event describing the given condition only
+// when the condition differs in status or reason from the one currently
+// recorded on the addon. Emitting on transitions only keeps a steady state
+// from producing a stream of identical events.
+func recordConditionEvent(
+	rec record.EventRecorder,
+	addon *refv1alpha1.ReferenceAddon,
+	cond metav1.Condition,
+	eventType, reason string,
+) {
+	current := meta.FindStatusCondition(addon.Status.Conditions, cond.Type)
+	if current != nil && current.Status == cond.Status && current.Reason == cond.Reason {
+		return
+	}
+
+	rec.Event(addon, eventType, reason, cond.Message)
+}
@@ -7,13 +7,38 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func generateIngressPolicyName(prefix string) string {
-	return fmt.Sprintf("%s-ingress", prefix)
+func generateSampleWorkloadName(prefix string) string {
+	return fmt.Sprintf("%s-sample-workload", prefix)
+}
+
+func generateResourceQuotaName(prefix string) string {
+	return fmt.Sprintf("%s-quota", prefix)
+}
+
+func generateLimitRangeName(prefix string) string {
+	return fmt.Sprintf("%s-limits", prefix)
 }
 
 func newAvailableCondition(reason refv1alpha1.ReferenceAddonAvailableReason, msg string) metav1.Condition {
+	return newCondition(refv1alpha1.ReferenceAddonConditionAvailable, reason, msg)
+}
+
+func newDegradedCondition(reason refv1alpha1.ReferenceAddonDegradedReason, msg string) metav1.Condition {
+	return newCondition(refv1alpha1.ReferenceAddonConditionDegraded, reason, msg)
+}
+
+func newParametersValidCondition(reason refv1alpha1.ParametersValidReason, msg string) metav1.Condition {
+	return newCondition(refv1alpha1.ReferenceAddonConditionParametersValid, reason, msg)
+}
+
+type conditionReason interface {
+	String() string
+	Status() metav1.ConditionStatus
+}
+
+func newCondition(condT refv1alpha1.ReferenceAddonCondition, reason conditionReason, msg string) metav1.Condition {
 	return metav1.Condition{
-		Type:               refv1alpha1.ReferenceAddonConditionAvailable.String(),
+		Type:               condT.String(),
 		Status:             reason.Status(),
 		Reason:             reason.String(),
 		Message:            msg,
@@ -0,0 +1,193 @@
+package referenceaddon
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	netv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/yaml"
+)
+
+//go:embed networkpolicies/*.yaml
+var networkPolicyTemplateFS embed.FS
+
+// DefaultNetworkPolicyTemplateName is the template selected when
+// the 'networkpolicies' parameter is not set.
+const DefaultNetworkPolicyTemplateName = "deny-all-ingress"
+
+// AllowFromCIDRsTemplateName is the template allowing ingress from
+// the CIDRs given by the 'allowedcidrs' parameter.
+const AllowFromCIDRsTemplateName = "allow-from-cidrs"
+
+// ErrNoAllowedCIDRs is returned when the "allow-from-cidrs" template
+// is selected without any CIDRs since the rendered policy would
+// silently deny all ingress.
+var ErrNoAllowedCIDRs = errors.New("no allowed CIDRs")
+
+// NetworkPolicyTemplateData is substituted into
+// NetworkPolicy templates when they are rendered.
+type NetworkPolicyTemplateData struct {
+	Namespace    string
+	OperatorName string
+	AllowedCIDRs []string
+}
+
+// NewNetworkPolicyTemplate parses the given text as the template
+// of a single NetworkPolicy manifest.
+func NewNetworkPolicyTemplate(name, text string) (NetworkPolicyTemplate, error) {
+	tmpl, err := template.
+		New(name).
+		Option("missingkey=error").
+		Funcs(template.FuncMap{"quote": strconv.Quote}).
+		Parse(text)
+	if err != nil {
+		return NetworkPolicyTemplate{}, fmt.Errorf("parsing NetworkPolicy template %q: %w", name, err)
+	}
+
+	return NetworkPolicyTemplate{
+		Name:     name,
+		template: tmpl,
+	}, nil
+}
+
+// NetworkPolicyTemplate renders a NetworkPolicy from
+// NetworkPolicyTemplateData.
+type NetworkPolicyTemplate struct {
+	// Name identifies the template in the 'networkpolicies' parameter.
+	Name string
+
+	template *template.Template
+}
+
+// Render renders the NetworkPolicy described by the template. The
+// namespace of the rendered policy defaults to data.Namespace.
+func (t NetworkPolicyTemplate) Render(data NetworkPolicyTemplateData) (netv1.NetworkPolicy, error) {
+	var buf bytes.Buffer
+
+	if err := t.template.Execute(&buf, data); err != nil {
+		return netv1.NetworkPolicy{}, fmt.Errorf("executing NetworkPolicy template %q: %w", t.Name, err)
+	}
+
+	var policy netv1.NetworkPolicy
+
+	if err := yaml.UnmarshalStrict(buf.Bytes(), &policy); err != nil {
+		return netv1.NetworkPolicy{}, fmt.Errorf("decoding NetworkPolicy template %q: %w", t.Name, err)
+	}
+
+	if policy.Name == "" {
+		return netv1.NetworkPolicy{}, fmt.Errorf("NetworkPolicy template %q renders a policy without a name", t.Name)
+	}
+
+	if policy.Namespace == "" {
+		policy.Namespace = data.Namespace
+	}
+
+	return policy, nil
+}
+
+// RenderNetworkPolicies renders every given template and returns the
+// rendered policies split into those whose template name is selected
+// and those whose template name is not.
+func RenderNetworkPolicies(
+	templates []NetworkPolicyTemplate,
+	selected []string,
+	data NetworkPolicyTemplateData,
+) (desired, unselected []netv1.NetworkPolicy, err error) {
+	for _, name := range selected {
+		if !hasNetworkPolicyTemplate(templates, name) {
+			return nil, nil, fmt.Errorf("unknown NetworkPolicy template %q", name)
+		}
+	}
+
+	if containsString(selected, AllowFromCIDRsTemplateName) && len(data.AllowedCIDRs) == 0 {
+		return nil, nil, fmt.Errorf(
+			"%w: NetworkPolicy template %q requires the 'allowedcidrs' parameter",
+			ErrNoAllowedCIDRs, AllowFromCIDRsTemplateName,
+		)
+	}
+
+	var errs []error
+
+	for _, tmpl := range templates {
+		policy, err := tmpl.Render(data)
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		if containsString(selected, tmpl.Name) {
+			desired = append(desired, policy)
+		} else {
+			unselected = append(unselected, policy)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+
+	return desired, unselected, nil
+}
+
+func hasNetworkPolicyTemplate(templates []NetworkPolicyTemplate, name string) bool {
+	for _, tmpl := range templates {
+		if tmpl.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DefaultNetworkPolicyTemplates returns the NetworkPolicy templates
+// shipped with the reference-addon sorted by name.
+func DefaultNetworkPolicyTemplates() []NetworkPolicyTemplate {
+	entries, err := networkPolicyTemplateFS.ReadDir("networkpolicies")
+	if err != nil {
+		panic(fmt.Sprintf("reading embedded NetworkPolicy templates: %v", err))
+	}
+
+	templates := make([]NetworkPolicyTemplate, 0, len(entries))
+
+	for _, e := range entries {
+		data, err := networkPolicyTemplateFS.ReadFile(path.Join("networkpolicies", e.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("reading embedded NetworkPolicy template %q: %v", e.Name(), err))
+		}
+
+		tmpl, err := NewNetworkPolicyTemplate(strings.TrimSuffix(e.Name(), path.Ext(e.Name())), string(data))
+		if err != nil {
+			panic(fmt.Sprintf("invalid embedded NetworkPolicy template: %v", err))
+		}
+
+		templates = append(templates, tmpl)
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+
+	return templates
+}
+
+// NetworkPolicyTemplateNames returns the names of the
+// DefaultNetworkPolicyTemplates.
+func NetworkPolicyTemplateNames() []string {
+	templates := DefaultNetworkPolicyTemplates()
+
+	names := make([]string, 0, len(templates))
+
+	for _, tmpl := range templates {
+		names = append(names, tmpl.Name)
+	}
+
+	return names
+}
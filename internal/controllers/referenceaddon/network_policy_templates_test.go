@@ -0,0 +1,194 @@
+package referenceaddon
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	netv1 "k8s.io/api/networking/v1"
+)
+
+func TestDefaultNetworkPolicyTemplates(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{
+		"allow-dns",
+		"allow-from-cidrs",
+		"allow-from-monitoring",
+		"allow-from-same-namespace",
+		"deny-all-ingress",
+		"restrict-egress",
+	}, NetworkPolicyTemplateNames())
+
+	data := NetworkPolicyTemplateData{
+		Namespace:    "test-namespace",
+		OperatorName: "test",
+		AllowedCIDRs: []string{"10.0.0.0/8"},
+	}
+
+	for _, tmpl := range DefaultNetworkPolicyTemplates() {
+		tmpl := tmpl
+
+		t.Run(tmpl.Name, func(t *testing.T) {
+			t.Parallel()
+
+			policy, err := tmpl.Render(data)
+			require.NoError(t, err)
+
+			assert.True(t, strings.HasPrefix(policy.Name, "test-"), "policy name %q is prefixed by the operator name", policy.Name)
+			assert.Equal(t, "test-namespace", policy.Namespace)
+			assert.NotEmpty(t, policy.Spec.PolicyTypes)
+		})
+	}
+}
+
+func TestNetworkPolicyTemplate_Render(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Text        string
+		Data        NetworkPolicyTemplateData
+		AssertError require.ErrorAssertionFunc
+	}{
+		"namespace defaulted": {
+			Text: "metadata:\n  name: {{ .OperatorName }}-test\n",
+			Data: NetworkPolicyTemplateData{
+				Namespace:    "test-namespace",
+				OperatorName: "test",
+			},
+			AssertError: require.NoError,
+		},
+		"missing name": {
+			Text:        "spec:\n  podSelector: {}\n",
+			AssertError: require.Error,
+		},
+		"unknown field": {
+			Text:        "metadata:\n  name: test\nunknown: true\n",
+			AssertError: require.Error,
+		},
+		"unknown key": {
+			Text:        "metadata:\n  name: {{ .Unknown }}\n",
+			AssertError: require.Error,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpl, err := NewNetworkPolicyTemplate("test", tc.Text)
+			require.NoError(t, err)
+
+			policy, err := tmpl.Render(tc.Data)
+			tc.AssertError(t, err)
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, "test-test", policy.Name)
+			assert.Equal(t, "test-namespace", policy.Namespace)
+		})
+	}
+}
+
+func TestNetworkPolicyTemplate_RenderCIDRs(t *testing.T) {
+	t.Parallel()
+
+	var allowFromCIDRs NetworkPolicyTemplate
+
+	for _, tmpl := range DefaultNetworkPolicyTemplates() {
+		if tmpl.Name == "allow-from-cidrs" {
+			allowFromCIDRs = tmpl
+		}
+	}
+
+	policy, err := allowFromCIDRs.Render(NetworkPolicyTemplateData{
+		Namespace:    "test-namespace",
+		OperatorName: "test",
+		AllowedCIDRs: []string{"10.0.0.0/8", "192.168.0.0/16"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, policy.Spec.Ingress, 1)
+
+	var cidrs []string
+
+	for _, peer := range policy.Spec.Ingress[0].From {
+		require.NotNil(t, peer.IPBlock)
+
+		cidrs = append(cidrs, peer.IPBlock.CIDR)
+	}
+
+	assert.Equal(t, []string{"10.0.0.0/8", "192.168.0.0/16"}, cidrs)
+
+	policy, err = allowFromCIDRs.Render(NetworkPolicyTemplateData{
+		Namespace:    "test-namespace",
+		OperatorName: "test",
+	})
+	require.NoError(t, err)
+
+	assert.Empty(t, policy.Spec.Ingress, "no CIDRs allow no ingress")
+}
+
+func TestRenderNetworkPolicies(t *testing.T) {
+	t.Parallel()
+
+	data := NetworkPolicyTemplateData{
+		Namespace:    "test-namespace",
+		OperatorName: "test",
+	}
+
+	for name, tc := range map[string]struct {
+		Selected           []string
+		ExpectedDesired    []string
+		ExpectedUnselected []string
+		AssertError        require.ErrorAssertionFunc
+	}{
+		"none selected": {
+			ExpectedUnselected: []string{"test-allow-dns", "test-ingress"},
+			AssertError:        require.NoError,
+		},
+		"some selected": {
+			Selected:           []string{"deny-all-ingress"},
+			ExpectedDesired:    []string{"test-ingress"},
+			ExpectedUnselected: []string{"test-allow-dns"},
+			AssertError:        require.NoError,
+		},
+		"unknown selected": {
+			Selected:    []string{"unknown"},
+			AssertError: require.Error,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var templates []NetworkPolicyTemplate
+
+			for _, tmpl := range DefaultNetworkPolicyTemplates() {
+				if tmpl.Name == "allow-dns" || tmpl.Name == "deny-all-ingress" {
+					templates = append(templates, tmpl)
+				}
+			}
+
+			desired, unselected, err := RenderNetworkPolicies(templates, tc.Selected, data)
+			tc.AssertError(t, err)
+
+			assert.Equal(t, tc.ExpectedDesired, policyNames(desired))
+			assert.Equal(t, tc.ExpectedUnselected, policyNames(unselected))
+		})
+	}
+}
+
+func policyNames(policies []netv1.NetworkPolicy) []string {
+	var names []string
+
+	for _, p := range policies {
+		names = append(names, p.Name)
+	}
+
+	return names
+}
@@ -1,9 +1,17 @@
 package referenceaddon
 
 import (
+	"time"
+
+	"github.com/blang/semver/v4"
 	"github.com/go-logr/logr"
+	refv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
+	"github.com/openshift/reference-addon/internal/controllers"
 	netv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 )
 
 type WithLog struct{ Log logr.Logger }
@@ -16,6 +24,14 @@ func (w WithLog) ConfigurePhaseApplyNetworkPolicies(c *PhaseApplyNetworkPolicies
 	c.Log = w.Log
 }
 
+func (w WithLog) ConfigurePhaseApplySampleWorkload(c *PhaseApplySampleWorkloadConfig) {
+	c.Log = w.Log
+}
+
+func (w WithLog) ConfigurePhaseApplyResourceGuardrails(c *PhaseApplyResourceGuardrailsConfig) {
+	c.Log = w.Log
+}
+
 func (w WithLog) ConfigurePhaseSmokeTestRun(c *PhaseSmokeTestRunConfig) {
 	c.Log = w.Log
 }
@@ -24,6 +40,10 @@ func (w WithLog) ConfigurePhaseUninstall(c *PhaseUninstallConfig) {
 	c.Log = w.Log
 }
 
+func (w WithLog) ConfigurePhaseTeardown(c *PhaseTeardownConfig) {
+	c.Log = w.Log
+}
+
 func (w WithLog) ConfigureUninstallerImpl(c *UninstallerImplConfig) {
 	c.Log = w.Log
 }
@@ -32,6 +52,92 @@ func (w WithLog) ConfigureCSVClientImpl(c *CSVClientImplConfig) {
 	c.Log = w.Log
 }
 
+func (w WithLog) ConfigureUninstallSignaler(c *UninstallSignalerConfig) {
+	c.Log = w.Log
+}
+
+func (w WithLog) ConfigureConfigMapUninstallSignaler(c *ConfigMapUninstallSignalerConfig) {
+	c.Log = w.Log
+}
+
+func (w WithLog) ConfigureAnnotationUninstallSignaler(c *AnnotationUninstallSignalerConfig) {
+	c.Log = w.Log
+}
+
+func (w WithLog) ConfigureAddonInstanceUninstallSignaler(c *AddonInstanceUninstallSignalerConfig) {
+	c.Log = w.Log
+}
+
+func (w WithLog) ConfigureUninstallRequestUninstallSignaler(c *UninstallRequestUninstallSignalerConfig) {
+	c.Log = w.Log
+}
+
+func (w WithLog) ConfigureParameterUninstallSignaler(c *ParameterUninstallSignalerConfig) {
+	c.Log = w.Log
+}
+
+type WithEventRecorder struct{ Recorder record.EventRecorder }
+
+func (w WithEventRecorder) ConfigureReferenceAddonReconciler(c *ReferenceAddonReconcilerConfig) {
+	c.Recorder = w.Recorder
+}
+
+func (w WithEventRecorder) ConfigurePhaseApplyNetworkPolicies(c *PhaseApplyNetworkPoliciesConfig) {
+	c.Recorder = w.Recorder
+}
+
+func (w WithEventRecorder) ConfigurePhaseApplySampleWorkload(c *PhaseApplySampleWorkloadConfig) {
+	c.Recorder = w.Recorder
+}
+
+func (w WithEventRecorder) ConfigurePhaseApplyResourceGuardrails(c *PhaseApplyResourceGuardrailsConfig) {
+	c.Recorder = w.Recorder
+}
+
+func (w WithEventRecorder) ConfigurePhaseSmokeTestRun(c *PhaseSmokeTestRunConfig) {
+	c.Recorder = w.Recorder
+}
+
+func (w WithEventRecorder) ConfigurePhaseSendDummyMetrics(c *PhaseSendDummyMetricsConfig) {
+	c.Recorder = w.Recorder
+}
+
+func (w WithEventRecorder) ConfigurePhaseUninstall(c *PhaseUninstallConfig) {
+	c.Recorder = w.Recorder
+}
+
+func (w WithEventRecorder) ConfigurePhaseTeardown(c *PhaseTeardownConfig) {
+	c.Recorder = w.Recorder
+}
+
+type WithInstallMode controllers.InstallMode
+
+func (w WithInstallMode) ConfigureReferenceAddonReconciler(c *ReferenceAddonReconcilerConfig) {
+	c.InstallMode = controllers.InstallMode(w)
+}
+
+type WithReferenceAddonMode ReferenceAddonMode
+
+func (w WithReferenceAddonMode) ConfigureReferenceAddonReconciler(c *ReferenceAddonReconcilerConfig) {
+	c.ReferenceAddonMode = ReferenceAddonMode(w)
+}
+
+type WithParameterGetterFactory struct{ Factory ParameterGetterFactory }
+
+func (w WithParameterGetterFactory) ConfigureReferenceAddonReconciler(c *ReferenceAddonReconcilerConfig) {
+	c.ParameterGetterFactory = w.Factory
+}
+
+type WithParameterGetter struct{ Getter ParameterGetter }
+
+func (w WithParameterGetter) ConfigureUninstallSignaler(c *UninstallSignalerConfig) {
+	c.ParameterGetter = w.Getter
+}
+
+func (w WithParameterGetter) ConfigureParameterUninstallSignaler(c *ParameterUninstallSignalerConfig) {
+	c.ParameterGetter = w.Getter
+}
+
 type WithAddonNamespace string
 
 func (w WithAddonNamespace) ConfigureConfigMapUninstallSignaler(c *ConfigMapUninstallSignalerConfig) {
@@ -42,30 +148,118 @@ func (w WithAddonNamespace) ConfigureReferenceAddonReconciler(c *ReferenceAddonR
 	c.AddonNamespace = string(w)
 }
 
+func (w WithAddonNamespace) ConfigureUninstallSignaler(c *UninstallSignalerConfig) {
+	c.AddonNamespace = string(w)
+}
+
+func (w WithAddonNamespace) ConfigureAnnotationUninstallSignaler(c *AnnotationUninstallSignalerConfig) {
+	c.AddonNamespace = string(w)
+}
+
+func (w WithAddonNamespace) ConfigureUninstallRequestUninstallSignaler(c *UninstallRequestUninstallSignalerConfig) {
+	c.AddonNamespace = string(w)
+}
+
+func (w WithAddonNamespace) ConfigurePhaseTeardown(c *PhaseTeardownConfig) {
+	c.AddonNamespace = string(w)
+}
+
 func (w WithAddonNamespace) ConfigurePhaseUninstall(c *PhaseUninstallConfig) {
 	c.AddonNamespace = string(w)
 }
 
+func (w WithAddonNamespace) ConfigurePhaseApplySampleWorkload(c *PhaseApplySampleWorkloadConfig) {
+	c.Namespace = string(w)
+}
+
+func (w WithAddonNamespace) ConfigurePhaseApplyNetworkPolicies(c *PhaseApplyNetworkPoliciesConfig) {
+	c.Namespace = string(w)
+}
+
+func (w WithAddonNamespace) ConfigurePhaseApplyResourceGuardrails(c *PhaseApplyResourceGuardrailsConfig) {
+	c.Namespace = string(w)
+}
+
 type WithAddonParameterSecretName string
 
 func (w WithAddonParameterSecretName) ConfigureReferenceAddonReconciler(c *ReferenceAddonReconcilerConfig) {
 	c.AddonParameterSecretname = string(w)
 }
 
+func (w WithAddonParameterSecretName) ConfigureParameterGetter(c *ParameterGetterConfig) {
+	c.SecretName = string(w)
+}
+
+type WithAddonInstanceNamespace string
+
+func (w WithAddonInstanceNamespace) ConfigureReferenceAddonReconciler(c *ReferenceAddonReconcilerConfig) {
+	c.AddonInstanceNamespace = string(w)
+}
+
+func (w WithAddonInstanceNamespace) ConfigureUninstallSignaler(c *UninstallSignalerConfig) {
+	c.AddonInstanceNamespace = string(w)
+}
+
+func (w WithAddonInstanceNamespace) ConfigureAddonInstanceUninstallSignaler(c *AddonInstanceUninstallSignalerConfig) {
+	c.AddonInstanceNamespace = string(w)
+}
+
+type WithAddonInstanceName string
+
+func (w WithAddonInstanceName) ConfigureReferenceAddonReconciler(c *ReferenceAddonReconcilerConfig) {
+	c.AddonInstanceName = string(w)
+}
+
+func (w WithAddonInstanceName) ConfigureUninstallSignaler(c *UninstallSignalerConfig) {
+	c.AddonInstanceName = string(w)
+}
+
+func (w WithAddonInstanceName) ConfigureAddonInstanceUninstallSignaler(c *AddonInstanceUninstallSignalerConfig) {
+	c.AddonInstanceName = string(w)
+}
+
 type WithOperatorName string
 
 func (w WithOperatorName) ConfigureConfigMapUninstallSignaler(c *ConfigMapUninstallSignalerConfig) {
 	c.OperatorName = string(w)
 }
 
+func (w WithOperatorName) ConfigurePhaseTeardown(c *PhaseTeardownConfig) {
+	c.OperatorName = string(w)
+}
+
 func (w WithOperatorName) ConfigurePhaseUninstall(c *PhaseUninstallConfig) {
 	c.OperatorName = string(w)
 }
 
+func (w WithOperatorName) ConfigurePhaseApplySampleWorkload(c *PhaseApplySampleWorkloadConfig) {
+	c.OperatorName = string(w)
+}
+
+func (w WithOperatorName) ConfigurePhaseApplyNetworkPolicies(c *PhaseApplyNetworkPoliciesConfig) {
+	c.OperatorName = string(w)
+}
+
+func (w WithOperatorName) ConfigurePhaseApplyResourceGuardrails(c *PhaseApplyResourceGuardrailsConfig) {
+	c.OperatorName = string(w)
+}
+
 func (w WithOperatorName) ConfigureReferenceAddonReconciler(c *ReferenceAddonReconcilerConfig) {
 	c.OperatorName = string(w)
 }
 
+func (w WithOperatorName) ConfigureUninstallSignaler(c *UninstallSignalerConfig) {
+	c.OperatorName = string(w)
+}
+
+func (w WithOperatorName) ConfigureAnnotationUninstallSignaler(c *AnnotationUninstallSignalerConfig) {
+	c.OperatorName = string(w)
+}
+
+func (w WithOperatorName) ConfigureUninstallRequestUninstallSignaler(c *UninstallRequestUninstallSignalerConfig) {
+	c.OperatorName = string(w)
+}
+
 type WithDeleteLabel string
 
 func (w WithDeleteLabel) ConfigureConfigMapUninstallSignaler(c *ConfigMapUninstallSignalerConfig) {
@@ -76,42 +270,298 @@ func (w WithDeleteLabel) ConfigureReferenceAddonReconciler(c *ReferenceAddonReco
 	c.DeleteLabel = string(w)
 }
 
+func (w WithDeleteLabel) ConfigureUninstallSignaler(c *UninstallSignalerConfig) {
+	c.DeleteLabel = string(w)
+}
+
+type WithUninstallSignalers []UninstallSignalerKind
+
+func (w WithUninstallSignalers) ConfigureReferenceAddonReconciler(c *ReferenceAddonReconcilerConfig) {
+	c.UninstallSignalers = append(c.UninstallSignalers, w...)
+}
+
+type WithUninstallSignalMode UninstallSignalMode
+
+func (w WithUninstallSignalMode) ConfigureReferenceAddonReconciler(c *ReferenceAddonReconcilerConfig) {
+	c.UninstallSignalMode = UninstallSignalMode(w)
+}
+
+type WithUninstallGracePeriod time.Duration
+
+func (w WithUninstallGracePeriod) ConfigureReferenceAddonReconciler(c *ReferenceAddonReconcilerConfig) {
+	c.UninstallGracePeriod = time.Duration(w)
+}
+
+func (w WithUninstallGracePeriod) ConfigurePhaseUninstall(c *PhaseUninstallConfig) {
+	c.GracePeriod = time.Duration(w)
+}
+
+type WithUninstallDryRun bool
+
+func (w WithUninstallDryRun) ConfigureReferenceAddonReconciler(c *ReferenceAddonReconcilerConfig) {
+	c.UninstallDryRun = bool(w)
+}
+
+func (w WithUninstallDryRun) ConfigurePhaseUninstall(c *PhaseUninstallConfig) {
+	c.DryRun = bool(w)
+}
+
+func (w WithUninstallDryRun) ConfigurePhaseTeardown(c *PhaseTeardownConfig) {
+	c.DryRun = bool(w)
+}
+
+type WithClock struct{ Clock clock.PassiveClock }
+
+func (w WithClock) ConfigurePhaseUninstall(c *PhaseUninstallConfig) {
+	c.Clock = w.Clock
+}
+
+type WithUninstallAnnotation string
+
+func (w WithUninstallAnnotation) ConfigureAnnotationUninstallSignaler(c *AnnotationUninstallSignalerConfig) {
+	c.Annotation = string(w)
+}
+
+type WithUninstallParameterID string
+
+func (w WithUninstallParameterID) ConfigureParameterUninstallSignaler(c *ParameterUninstallSignalerConfig) {
+	c.ParameterID = string(w)
+}
+
+type WithDegradedThreshold int
+
+func (w WithDegradedThreshold) ConfigureReferenceAddonReconciler(c *ReferenceAddonReconcilerConfig) {
+	c.DegradedThreshold = int(w)
+}
+
+type WithParameterRegistry struct{ Registry *ParameterRegistry }
+
+func (w WithParameterRegistry) ConfigureReferenceAddonReconciler(c *ReferenceAddonReconcilerConfig) {
+	c.ParameterRegistry = w.Registry
+}
+
+func (w WithParameterRegistry) ConfigureParameterGetter(c *ParameterGetterConfig) {
+	c.Registry = w.Registry
+}
+
+func (w WithParameterRegistry) ConfigureSecretParameterGetter(c *SecretParameterGetterConfig) {
+	c.Registry = w.Registry
+}
+
+func (w WithParameterRegistry) ConfigureConfigMapParameterGetter(c *ConfigMapParameterGetterConfig) {
+	c.Registry = w.Registry
+}
+
+func (w WithParameterRegistry) ConfigureFileParameterGetter(c *FileParameterGetterConfig) {
+	c.Registry = w.Registry
+}
+
+func (w WithParameterRegistry) ConfigureEnvParameterGetter(c *EnvParameterGetterConfig) {
+	c.Registry = w.Registry
+}
+
+func (w WithParameterRegistry) ConfigureLayeredParameterGetter(c *LayeredParameterGetterConfig) {
+	c.Registry = w.Registry
+}
+
+type WithParameterConfigMapName string
+
+func (w WithParameterConfigMapName) ConfigureParameterGetter(c *ParameterGetterConfig) {
+	c.ConfigMapName = string(w)
+}
+
+func (w WithParameterConfigMapName) ConfigureReferenceAddonReconciler(c *ReferenceAddonReconcilerConfig) {
+	c.AddonParameterConfigMapName = string(w)
+}
+
+type WithParameterDirectory string
+
+func (w WithParameterDirectory) ConfigureParameterGetter(c *ParameterGetterConfig) {
+	c.Directory = string(w)
+}
+
+func (w WithParameterDirectory) ConfigureFileParameterGetter(c *FileParameterGetterConfig) {
+	c.Directory = string(w)
+}
+
+type WithParameterEnvPrefix string
+
+func (w WithParameterEnvPrefix) ConfigureParameterGetter(c *ParameterGetterConfig) {
+	c.EnvPrefix = string(w)
+}
+
+func (w WithParameterEnvPrefix) ConfigureEnvParameterGetter(c *EnvParameterGetterConfig) {
+	c.Prefix = string(w)
+}
+
+type WithLookupEnv func(string) (string, bool)
+
+func (w WithLookupEnv) ConfigureEnvParameterGetter(c *EnvParameterGetterConfig) {
+	c.LookupEnv = w
+}
+
 type WithName string
 
 func (w WithName) ConfigureSecretParameterGetter(c *SecretParameterGetterConfig) {
 	c.Name = string(w)
 }
 
+func (w WithName) ConfigureConfigMapParameterGetter(c *ConfigMapParameterGetterConfig) {
+	c.Name = string(w)
+}
+
 type WithNamespace string
 
 func (w WithNamespace) ConfigureSecretParameterGetter(c *SecretParameterGetterConfig) {
 	c.Namespace = string(w)
 }
 
+func (w WithNamespace) ConfigureConfigMapParameterGetter(c *ConfigMapParameterGetterConfig) {
+	c.Namespace = string(w)
+}
+
+func (w WithNamespace) ConfigureParameterGetter(c *ParameterGetterConfig) {
+	c.Namespace = string(w)
+}
+
 func (w WithNamespace) ConfigureListCSVs(c *ListCSVsConfig) {
 	c.Namespace = string(w)
 }
 
+func (w WithNamespace) ConfigurePruneNetworkPolicies(c *PruneNetworkPoliciesConfig) {
+	c.Namespace = string(w)
+}
+
 type WithOwner struct{ Owner metav1.Object }
 
 func (w WithOwner) ConfigureApplyNetworkPolicies(c *ApplyNetorkPoliciesConfig) {
 	c.Owner = w.Owner
 }
 
+func (w WithOwner) ConfigureApplyDeployment(c *ApplyDeploymentConfig) {
+	c.Owner = w.Owner
+}
+
+func (w WithOwner) ConfigureApplyResourceGuardrails(c *ApplyResourceGuardrailsConfig) {
+	c.Owner = w.Owner
+}
+
 type WithPolicies []netv1.NetworkPolicy
 
 func (w WithPolicies) ConfigurePhaseApplyNetworkPolicies(c *PhaseApplyNetworkPoliciesConfig) {
 	c.Policies = append(c.Policies, w...)
 }
 
+func (w WithPolicies) ConfigurePhaseTeardown(c *PhaseTeardownConfig) {
+	c.Policies = append(c.Policies, w...)
+}
+
 func (w WithPolicies) ConfigureApplyNetworkPolicies(c *ApplyNetorkPoliciesConfig) {
 	c.Policies = append(c.Policies, w...)
 }
 
-type WithPrefix string
+func (w WithPolicies) ConfigurePruneNetworkPolicies(c *PruneNetworkPoliciesConfig) {
+	c.Policies = append(c.Policies, w...)
+}
 
-func (w WithPrefix) ConfigureListCSVs(c *ListCSVsConfig) {
-	c.Prefix = string(w)
+type WithNetworkPolicyPruneDryRun bool
+
+func (w WithNetworkPolicyPruneDryRun) ConfigureReferenceAddonReconciler(c *ReferenceAddonReconcilerConfig) {
+	c.NetworkPolicyPruneDryRun = bool(w)
+}
+
+func (w WithNetworkPolicyPruneDryRun) ConfigurePhaseApplyNetworkPolicies(c *PhaseApplyNetworkPoliciesConfig) {
+	c.PruneDryRun = bool(w)
+}
+
+func (w WithNetworkPolicyPruneDryRun) ConfigurePruneNetworkPolicies(c *PruneNetworkPoliciesConfig) {
+	c.DryRun = bool(w)
+}
+
+type WithNetworkPolicyDriftReportOnly bool
+
+func (w WithNetworkPolicyDriftReportOnly) ConfigureReferenceAddonReconciler(c *ReferenceAddonReconcilerConfig) {
+	c.NetworkPolicyDriftReportOnly = bool(w)
+}
+
+func (w WithNetworkPolicyDriftReportOnly) ConfigurePhaseApplyNetworkPolicies(c *PhaseApplyNetworkPoliciesConfig) {
+	c.DriftReportOnly = bool(w)
+}
+
+func (w WithNetworkPolicyDriftReportOnly) ConfigureApplyNetworkPolicies(c *ApplyNetorkPoliciesConfig) {
+	c.DriftReportOnly = bool(w)
+}
+
+type WithNetworkPolicyDriftRecorder struct{ Recorder NetworkPolicyDriftRecorder }
+
+func (w WithNetworkPolicyDriftRecorder) ConfigurePhaseApplyNetworkPolicies(c *PhaseApplyNetworkPoliciesConfig) {
+	c.DriftRecorder = w.Recorder
+}
+
+type WithNetworkPolicyTemplates []NetworkPolicyTemplate
+
+func (w WithNetworkPolicyTemplates) ConfigurePhaseApplyNetworkPolicies(c *PhaseApplyNetworkPoliciesConfig) {
+	c.Templates = append(c.Templates, w...)
+}
+
+type WithNetworkPolicies []string
+
+func (w WithNetworkPolicies) ConfigurePhaseRequestParameters(c *PhaseRequestParametersConfig) {
+	c.Values[networkPoliciesID] = []string(w)
+}
+
+type WithAllowedCIDRs []string
+
+func (w WithAllowedCIDRs) ConfigurePhaseRequestParameters(c *PhaseRequestParametersConfig) {
+	c.Values[allowedCIDRsID] = []string(w)
+}
+
+type WithPackage string
+
+func (w WithPackage) ConfigureListCSVs(c *ListCSVsConfig) {
+	c.Matcher.Package = string(w)
+}
+
+type WithLabelSelector struct{ Selector labels.Selector }
+
+func (w WithLabelSelector) ConfigureListCSVs(c *ListCSVsConfig) {
+	c.Matcher.Selector = w.Selector
+}
+
+func (w WithLabelSelector) ConfigurePruneNetworkPolicies(c *PruneNetworkPoliciesConfig) {
+	c.Selector = w.Selector
+}
+
+type WithVersionRange struct{ Range semver.Range }
+
+func (w WithVersionRange) ConfigureListCSVs(c *ListCSVsConfig) {
+	c.Matcher.VersionRange = w.Range
+}
+
+type WithCSVMatcher struct{ Matcher controllers.CSVMatcher }
+
+func (w WithCSVMatcher) ConfigureListCSVs(c *ListCSVsConfig) {
+	c.Matcher = w.Matcher
+}
+
+func (w WithCSVMatcher) ConfigurePhaseUninstall(c *PhaseUninstallConfig) {
+	c.CSVMatcher = w.Matcher
+}
+
+func (w WithCSVMatcher) ConfigurePhaseTeardown(c *PhaseTeardownConfig) {
+	c.CSVMatcher = w.Matcher
+}
+
+type WithCSVLabelSelector string
+
+func (w WithCSVLabelSelector) ConfigureReferenceAddonReconciler(c *ReferenceAddonReconcilerConfig) {
+	c.CSVLabelSelector = string(w)
+}
+
+type WithCSVVersionRange string
+
+func (w WithCSVVersionRange) ConfigureReferenceAddonReconciler(c *ReferenceAddonReconcilerConfig) {
+	c.CSVVersionRange = string(w)
 }
 
 type WithSampleURLs []string
@@ -120,8 +570,44 @@ func (w WithSampleURLs) ConfigurePhaseSendDummyMetrics(c *PhaseSendDummyMetricsC
 	c.SampleURLs = []string(w)
 }
 
+func (w WithSampleURLs) ConfigurePhaseRequestParameters(c *PhaseRequestParametersConfig) {
+	c.Values[sampleURLsParameterID] = []string(w)
+}
+
 type WithSmokeTester struct{ Tester SmokeTester }
 
 func (w WithSmokeTester) ConfigurePhaseSmokeTestRun(c *PhaseSmokeTestRunConfig) {
 	c.SmokeTester = w.Tester
 }
+
+func (w WithSmokeTester) ConfigurePhaseTeardown(c *PhaseTeardownConfig) {
+	c.SmokeTester = w.Tester
+}
+
+type WithSampleWorkloadImage string
+
+func (w WithSampleWorkloadImage) ConfigureReferenceAddonReconciler(c *ReferenceAddonReconcilerConfig) {
+	c.SampleWorkloadImage = string(w)
+}
+
+func (w WithSampleWorkloadImage) ConfigurePhaseApplySampleWorkload(c *PhaseApplySampleWorkloadConfig) {
+	c.Image = string(w)
+}
+
+type WithWorkloadSizeTiers map[string]refv1alpha1.WorkloadSize
+
+func (w WithWorkloadSizeTiers) ConfigurePhaseApplySampleWorkload(c *PhaseApplySampleWorkloadConfig) {
+	c.Tiers = map[string]refv1alpha1.WorkloadSize(w)
+}
+
+type WithRolloutPollInterval time.Duration
+
+func (w WithRolloutPollInterval) ConfigurePhaseApplySampleWorkload(c *PhaseApplySampleWorkloadConfig) {
+	c.RolloutPollInterval = time.Duration(w)
+}
+
+type WithResourceGuardrailTiers map[string]ResourceGuardrails
+
+func (w WithResourceGuardrailTiers) ConfigurePhaseApplyResourceGuardrails(c *PhaseApplyResourceGuardrailsConfig) {
+	c.Tiers = map[string]ResourceGuardrails(w)
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -14,10 +17,139 @@ type ParameterGetter interface {
 	GetParameters(ctx context.Context) (PhaseRequestParameters, error)
 }
 
+// ParameterGetterFactory returns the ParameterGetter reading the
+// parameters of the ReferenceAddon in the given namespace.
+type ParameterGetterFactory func(namespace string) (ParameterGetter, error)
+
+// RawParameterGetter is a ParameterGetter which can also provide
+// the unparsed parameter values it sources.
+type RawParameterGetter interface {
+	ParameterGetter
+	// GetRawParameters returns the unparsed parameter values
+	// keyed by parameter key.
+	GetRawParameters(ctx context.Context) (map[string]string, error)
+	// Source describes where parameters are retrieved from.
+	Source() string
+}
+
+// ParameterSourceKind identifies a RawParameterGetter implementation.
+type ParameterSourceKind string
+
+const (
+	// ParameterSourceKindSecret reads parameters from the addon
+	// parameters Secret.
+	ParameterSourceKindSecret ParameterSourceKind = "secret"
+	// ParameterSourceKindConfigMap reads parameters from a ConfigMap.
+	ParameterSourceKindConfigMap ParameterSourceKind = "configmap"
+	// ParameterSourceKindFile reads parameters from the files of a
+	// directory such as a mounted Secret or ConfigMap volume.
+	ParameterSourceKindFile ParameterSourceKind = "file"
+	// ParameterSourceKindEnv reads parameters from environment variables.
+	ParameterSourceKindEnv ParameterSourceKind = "env"
+)
+
+var (
+	ErrUnknownParameterSourceKind = errors.New("unknown parameter source kind")
+	// ErrParameterSourceNotFound is wrapped by errors returned when
+	// a parameter source does not exist.
+	ErrParameterSourceNotFound = errors.New("parameter source not found")
+)
+
+const (
+	// DefaultParameterDirectory is the directory read by the file
+	// parameter source unless configured otherwise.
+	DefaultParameterDirectory = "/etc/reference-addon/parameters"
+	// DefaultParameterEnvPrefix prefixes the upper-cased parameter
+	// keys read by the env parameter source.
+	DefaultParameterEnvPrefix = "REFERENCE_ADDON_PARAM_"
+)
+
+// NewParameterGetter returns a ParameterGetter reading parameters from
+// sources of the given kinds. Multiple kinds are layered with earlier
+// kinds taking priority over later ones.
+func NewParameterGetter(client client.Client, kinds []ParameterSourceKind, opts ...ParameterGetterOption) (ParameterGetter, error) {
+	var cfg ParameterGetterConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	getters := make([]RawParameterGetter, 0, len(kinds))
+
+	for _, kind := range kinds {
+		var getter RawParameterGetter
+
+		switch kind {
+		case ParameterSourceKindSecret:
+			getter = NewSecretParameterGetter(
+				client,
+				WithNamespace(cfg.Namespace),
+				WithName(cfg.SecretName),
+				WithParameterRegistry{Registry: cfg.Registry},
+			)
+		case ParameterSourceKindConfigMap:
+			getter = NewConfigMapParameterGetter(
+				client,
+				WithNamespace(cfg.Namespace),
+				WithName(cfg.ConfigMapName),
+				WithParameterRegistry{Registry: cfg.Registry},
+			)
+		case ParameterSourceKindFile:
+			getter = NewFileParameterGetter(
+				WithParameterDirectory(cfg.Directory),
+				WithParameterRegistry{Registry: cfg.Registry},
+			)
+		case ParameterSourceKindEnv:
+			getter = NewEnvParameterGetter(
+				WithParameterEnvPrefix(cfg.EnvPrefix),
+				WithParameterRegistry{Registry: cfg.Registry},
+			)
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownParameterSourceKind, kind)
+		}
+
+		getters = append(getters, getter)
+	}
+
+	switch len(getters) {
+	case 0:
+		return nil, errors.New("no parameter sources configured")
+	case 1:
+		return getters[0], nil
+	default:
+		return NewLayeredParameterGetter(getters, WithParameterRegistry{Registry: cfg.Registry}), nil
+	}
+}
+
+type ParameterGetterConfig struct {
+	Namespace     string
+	SecretName    string
+	ConfigMapName string
+	Directory     string
+	EnvPrefix     string
+	Registry      *ParameterRegistry
+}
+
+func (c *ParameterGetterConfig) Option(opts ...ParameterGetterOption) {
+	for _, opt := range opts {
+		opt.ConfigureParameterGetter(c)
+	}
+}
+
+func (c *ParameterGetterConfig) Default() {
+	if c.Registry == nil {
+		c.Registry = DefaultParameterRegistry()
+	}
+}
+
+type ParameterGetterOption interface {
+	ConfigureParameterGetter(*ParameterGetterConfig)
+}
+
 func NewSecretParameterGetter(client client.Client, opts ...SecretParameteterGetterOption) *SecretParameterGetter {
 	var cfg SecretParameterGetterConfig
 
 	cfg.Option(opts...)
+	cfg.Default()
 
 	return &SecretParameterGetter{
 		cfg: cfg,
@@ -32,13 +164,11 @@ type SecretParameterGetter struct {
 	client client.Client
 }
 
-const (
-	applyNetworkPoliciesID = "applynetworkpolicies"
-	enableSmokeTestID      = "enablesmoketest"
-	sizeParameterID        = "size"
-)
-
 func (s *SecretParameterGetter) GetParameters(ctx context.Context) (PhaseRequestParameters, error) {
+	return parseRawParameters(ctx, s, s.cfg.Registry)
+}
+
+func (s *SecretParameterGetter) GetRawParameters(ctx context.Context) (map[string]string, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -50,51 +180,414 @@ func (s *SecretParameterGetter) GetParameters(ctx context.Context) (PhaseRequest
 	var secret corev1.Secret
 
 	if err := s.client.Get(ctx, key, &secret); err != nil {
-		return NewPhaseRequestParameters(), fmt.Errorf("retrieving addon parameters secret: %w", err)
+		return nil, wrapSourceNotFound(fmt.Errorf("retrieving addon parameters secret: %w", err))
 	}
 
-	var opts []PhaseRequestParametersOption
+	raw := make(map[string]string, len(secret.Data))
 
-	if val, ok := secret.Data[applyNetworkPoliciesID]; ok {
-		b, err := parseBool(string(val))
-		if err != nil {
-			return NewPhaseRequestParameters(), fmt.Errorf("parsing 'ApplyNetworkPolicies' value: %w", err)
+	for k, v := range secret.Data {
+		raw[k] = string(v)
+	}
+
+	return raw, nil
+}
+
+func (s *SecretParameterGetter) Source() string {
+	return fmt.Sprintf("secret/%s/%s", s.cfg.Namespace, s.cfg.Name)
+}
+
+type SecretParameterGetterConfig struct {
+	Namespace string
+	Name      string
+	Registry  *ParameterRegistry
+}
+
+func (c *SecretParameterGetterConfig) Option(opts ...SecretParameteterGetterOption) {
+	for _, opt := range opts {
+		opt.ConfigureSecretParameterGetter(c)
+	}
+}
+
+func (c *SecretParameterGetterConfig) Default() {
+	if c.Registry == nil {
+		c.Registry = DefaultParameterRegistry()
+	}
+}
+
+type SecretParameteterGetterOption interface {
+	ConfigureSecretParameterGetter(*SecretParameterGetterConfig)
+}
+
+func NewConfigMapParameterGetter(client client.Client, opts ...ConfigMapParameterGetterOption) *ConfigMapParameterGetter {
+	var cfg ConfigMapParameterGetterConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	return &ConfigMapParameterGetter{
+		cfg: cfg,
+
+		client: client,
+	}
+}
+
+// ConfigMapParameterGetter reads parameters from the data of a ConfigMap.
+type ConfigMapParameterGetter struct {
+	cfg ConfigMapParameterGetterConfig
+
+	client client.Client
+}
+
+func (g *ConfigMapParameterGetter) GetParameters(ctx context.Context) (PhaseRequestParameters, error) {
+	return parseRawParameters(ctx, g, g.cfg.Registry)
+}
+
+func (g *ConfigMapParameterGetter) GetRawParameters(ctx context.Context) (map[string]string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	key := client.ObjectKey{
+		Namespace: g.cfg.Namespace,
+		Name:      g.cfg.Name,
+	}
+
+	var cm corev1.ConfigMap
+
+	if err := g.client.Get(ctx, key, &cm); err != nil {
+		return nil, wrapSourceNotFound(fmt.Errorf("retrieving addon parameters configmap: %w", err))
+	}
+
+	raw := make(map[string]string, len(cm.Data))
+
+	for k, v := range cm.Data {
+		raw[k] = v
+	}
+
+	return raw, nil
+}
+
+func (g *ConfigMapParameterGetter) Source() string {
+	return fmt.Sprintf("configmap/%s/%s", g.cfg.Namespace, g.cfg.Name)
+}
+
+type ConfigMapParameterGetterConfig struct {
+	Namespace string
+	Name      string
+	Registry  *ParameterRegistry
+}
+
+func (c *ConfigMapParameterGetterConfig) Option(opts ...ConfigMapParameterGetterOption) {
+	for _, opt := range opts {
+		opt.ConfigureConfigMapParameterGetter(c)
+	}
+}
+
+func (c *ConfigMapParameterGetterConfig) Default() {
+	if c.Registry == nil {
+		c.Registry = DefaultParameterRegistry()
+	}
+}
+
+type ConfigMapParameterGetterOption interface {
+	ConfigureConfigMapParameterGetter(*ConfigMapParameterGetterConfig)
+}
+
+func NewFileParameterGetter(opts ...FileParameterGetterOption) *FileParameterGetter {
+	var cfg FileParameterGetterConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	return &FileParameterGetter{
+		cfg: cfg,
+	}
+}
+
+// FileParameterGetter reads parameters from a directory holding one
+// file per parameter such as a projected Secret or ConfigMap volume.
+// Parameters are read on every call so that updates to the mounted
+// volume are picked up without requiring API access.
+type FileParameterGetter struct {
+	cfg FileParameterGetterConfig
+}
+
+func (g *FileParameterGetter) GetParameters(ctx context.Context) (PhaseRequestParameters, error) {
+	return parseRawParameters(ctx, g, g.cfg.Registry)
+}
+
+func (g *FileParameterGetter) GetRawParameters(_ context.Context) (map[string]string, error) {
+	entries, err := os.ReadDir(g.cfg.Directory)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			err = fmt.Errorf("%w: %w", ErrParameterSourceNotFound, err)
 		}
 
-		opts = append(opts, WithApplyNetworkPolicies{Value: &b})
+		return nil, fmt.Errorf("reading addon parameters directory: %w", err)
 	}
 
-	if val, ok := secret.Data[enableSmokeTestID]; ok {
-		b, err := parseBool(string(val))
+	raw := make(map[string]string, len(entries))
+
+	for _, entry := range entries {
+		// Projected volumes store their data in hidden timestamped
+		// directories linked through "..data".
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(g.cfg.Directory, entry.Name())
+
+		info, err := os.Stat(path)
 		if err != nil {
-			return NewPhaseRequestParameters(), fmt.Errorf("parsing 'EnableSmokeTest' value: %w", err)
+			return nil, fmt.Errorf("inspecting addon parameter file %q: %w", path, err)
 		}
 
-		opts = append(opts, WithEnableSmokeTest{Value: &b})
+		if !info.Mode().IsRegular() {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading addon parameter file %q: %w", path, err)
+		}
+
+		raw[entry.Name()] = string(data)
 	}
 
-	if val, ok := secret.Data[sizeParameterID]; ok {
-		s := string(val)
+	return raw, nil
+}
+
+func (g *FileParameterGetter) Source() string {
+	return "file/" + g.cfg.Directory
+}
+
+type FileParameterGetterConfig struct {
+	Directory string
+	Registry  *ParameterRegistry
+}
+
+func (c *FileParameterGetterConfig) Option(opts ...FileParameterGetterOption) {
+	for _, opt := range opts {
+		opt.ConfigureFileParameterGetter(c)
+	}
+}
 
-		opts = append(opts, WithSize{Value: &s})
+func (c *FileParameterGetterConfig) Default() {
+	if c.Directory == "" {
+		c.Directory = DefaultParameterDirectory
 	}
 
-	return NewPhaseRequestParameters(opts...), nil
+	if c.Registry == nil {
+		c.Registry = DefaultParameterRegistry()
+	}
 }
 
-type SecretParameterGetterConfig struct {
-	Namespace string
-	Name      string
+type FileParameterGetterOption interface {
+	ConfigureFileParameterGetter(*FileParameterGetterConfig)
 }
 
-func (c *SecretParameterGetterConfig) Option(opts ...SecretParameteterGetterOption) {
+func NewEnvParameterGetter(opts ...EnvParameterGetterOption) *EnvParameterGetter {
+	var cfg EnvParameterGetterConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	return &EnvParameterGetter{
+		cfg: cfg,
+	}
+}
+
+// EnvParameterGetter reads each registered parameter from the
+// environment variable named after its upper-cased key prefixed
+// with the configured prefix. It is intended for local development.
+type EnvParameterGetter struct {
+	cfg EnvParameterGetterConfig
+}
+
+func (g *EnvParameterGetter) GetParameters(ctx context.Context) (PhaseRequestParameters, error) {
+	return parseRawParameters(ctx, g, g.cfg.Registry)
+}
+
+func (g *EnvParameterGetter) GetRawParameters(_ context.Context) (map[string]string, error) {
+	raw := make(map[string]string)
+
+	for _, def := range g.cfg.Registry.Definitions() {
+		if val, ok := g.cfg.LookupEnv(g.envName(def.Key)); ok {
+			raw[def.Key] = val
+		}
+	}
+
+	return raw, nil
+}
+
+func (g *EnvParameterGetter) envName(key string) string {
+	return g.cfg.Prefix + strings.ToUpper(key)
+}
+
+func (g *EnvParameterGetter) Source() string {
+	return "env/" + g.cfg.Prefix
+}
+
+type EnvParameterGetterConfig struct {
+	Prefix    string
+	LookupEnv func(string) (string, bool)
+	Registry  *ParameterRegistry
+}
+
+func (c *EnvParameterGetterConfig) Option(opts ...EnvParameterGetterOption) {
 	for _, opt := range opts {
-		opt.ConfigureSecretParameterGetter(c)
+		opt.ConfigureEnvParameterGetter(c)
 	}
 }
 
-type SecretParameteterGetterOption interface {
-	ConfigureSecretParameterGetter(*SecretParameterGetterConfig)
+func (c *EnvParameterGetterConfig) Default() {
+	if c.Prefix == "" {
+		c.Prefix = DefaultParameterEnvPrefix
+	}
+
+	if c.LookupEnv == nil {
+		c.LookupEnv = os.LookupEnv
+	}
+
+	if c.Registry == nil {
+		c.Registry = DefaultParameterRegistry()
+	}
+}
+
+type EnvParameterGetterOption interface {
+	ConfigureEnvParameterGetter(*EnvParameterGetterConfig)
+}
+
+func NewLayeredParameterGetter(getters []RawParameterGetter, opts ...LayeredParameterGetterOption) *LayeredParameterGetter {
+	var cfg LayeredParameterGetterConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	return &LayeredParameterGetter{
+		cfg:     cfg,
+		getters: getters,
+	}
+}
+
+// LayeredParameterGetter merges the parameters of multiple sources.
+// A value from an earlier source takes priority over the value of the
+// same key from a later source. Sources which do not exist are skipped.
+// The source of each value is reported through
+// PhaseRequestParameters.Source.
+type LayeredParameterGetter struct {
+	cfg     LayeredParameterGetterConfig
+	getters []RawParameterGetter
+}
+
+func (g *LayeredParameterGetter) GetParameters(ctx context.Context) (PhaseRequestParameters, error) {
+	raw, sources, err := g.getLayeredParameters(ctx)
+	if err != nil {
+		return NewPhaseRequestParameters(), err
+	}
+
+	params, err := g.cfg.Registry.Parse(raw)
+	if err != nil {
+		return params, fmt.Errorf("parsing addon parameters: %w", err)
+	}
+
+	for key := range params.values {
+		if src, ok := sources[key]; ok {
+			params.setSource(key, src)
+		}
+	}
+
+	return params, nil
+}
+
+func (g *LayeredParameterGetter) GetRawParameters(ctx context.Context) (map[string]string, error) {
+	raw, _, err := g.getLayeredParameters(ctx)
+
+	return raw, err
+}
+
+func (g *LayeredParameterGetter) getLayeredParameters(ctx context.Context) (map[string]string, map[string]string, error) {
+	var (
+		raw     = make(map[string]string)
+		sources = make(map[string]string)
+		found   bool
+	)
+
+	// apply in reverse so that earlier getters take priority
+	for i := len(g.getters) - 1; i >= 0; i-- {
+		getter := g.getters[i]
+
+		layer, err := getter.GetRawParameters(ctx)
+		if errors.Is(err, ErrParameterSourceNotFound) {
+			continue
+		} else if err != nil {
+			return nil, nil, fmt.Errorf("retrieving parameters from %s: %w", getter.Source(), err)
+		}
+
+		found = true
+
+		for k, v := range layer {
+			raw[k] = v
+			sources[k] = getter.Source()
+		}
+	}
+
+	if !found && len(g.getters) > 0 {
+		return nil, nil, fmt.Errorf("%w: none of %s exist", ErrParameterSourceNotFound, g.Source())
+	}
+
+	return raw, sources, nil
+}
+
+func (g *LayeredParameterGetter) Source() string {
+	srcs := make([]string, 0, len(g.getters))
+
+	for _, getter := range g.getters {
+		srcs = append(srcs, getter.Source())
+	}
+
+	return strings.Join(srcs, ",")
+}
+
+type LayeredParameterGetterConfig struct {
+	Registry *ParameterRegistry
+}
+
+func (c *LayeredParameterGetterConfig) Option(opts ...LayeredParameterGetterOption) {
+	for _, opt := range opts {
+		opt.ConfigureLayeredParameterGetter(c)
+	}
+}
+
+func (c *LayeredParameterGetterConfig) Default() {
+	if c.Registry == nil {
+		c.Registry = DefaultParameterRegistry()
+	}
+}
+
+type LayeredParameterGetterOption interface {
+	ConfigureLayeredParameterGetter(*LayeredParameterGetterConfig)
+}
+
+func parseRawParameters(ctx context.Context, getter RawParameterGetter, reg *ParameterRegistry) (PhaseRequestParameters, error) {
+	raw, err := getter.GetRawParameters(ctx)
+	if err != nil {
+		return NewPhaseRequestParameters(), err
+	}
+
+	params, err := reg.Parse(raw)
+	if err != nil {
+		return params, fmt.Errorf("parsing addon parameters: %w", err)
+	}
+
+	return params, nil
+}
+
+func wrapSourceNotFound(err error) error {
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", ErrParameterSourceNotFound, err)
 }
 
 var ErrInvalidBoolValue = errors.New("invalid bool value")
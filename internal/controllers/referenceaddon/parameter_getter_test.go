@@ -2,6 +2,8 @@ package referenceaddon
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/openshift/reference-addon/internal/controllers"
@@ -26,6 +28,7 @@ func TestSecretParameterGetter(t *testing.T) {
 		Namespace      string
 		Name           string
 		ExpectedParams PhaseRequestParameters
+		AssertError    require.ErrorAssertionFunc
 	}{
 		"happy path": {
 			ActualSecret: &corev1.Secret{
@@ -35,15 +38,36 @@ func TestSecretParameterGetter(t *testing.T) {
 				},
 				Data: map[string][]byte{
 					"applynetworkpolicies": []byte("true"),
-					"size":                 []byte("1"),
+					"size":                 []byte("small"),
+					"sampleurls":           []byte("https://a.io, https://b.io"),
+					"unknown":              []byte("ignored"),
 				},
 			},
 			Namespace: "test-namespace",
 			Name:      "test",
 			ExpectedParams: NewPhaseRequestParameters(
 				WithApplyNetworkPolicies{Value: controllers.BoolPtr(true)},
-				WithSize{Value: controllers.StringPtr("1")},
+				WithSize{Value: controllers.StringPtr("small")},
+				WithSampleURLs{"https://a.io", "https://b.io"},
+				WithNetworkPolicies{DefaultNetworkPolicyTemplateName},
 			),
+			AssertError: require.NoError,
+		},
+		"invalid size": {
+			ActualSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "test-namespace",
+				},
+				Data: map[string][]byte{
+					"applynetworkpolicies": []byte("true"),
+					"size":                 []byte("1"),
+				},
+			},
+			Namespace:      "test-namespace",
+			Name:           "test",
+			ExpectedParams: NewPhaseRequestParameters(),
+			AssertError:    require.Error,
 		},
 	} {
 		tc := tc
@@ -63,9 +87,221 @@ func TestSecretParameterGetter(t *testing.T) {
 			)
 
 			params, err := getter.GetParameters(context.Background())
-			require.NoError(t, err)
+			tc.AssertError(t, err)
 
 			assert.Equal(t, tc.ExpectedParams, params)
 		})
 	}
 }
+
+func TestParameterGetterInterfaces(t *testing.T) {
+	t.Parallel()
+
+	require.Implements(t, new(RawParameterGetter), new(SecretParameterGetter))
+	require.Implements(t, new(RawParameterGetter), new(ConfigMapParameterGetter))
+	require.Implements(t, new(RawParameterGetter), new(FileParameterGetter))
+	require.Implements(t, new(RawParameterGetter), new(EnvParameterGetter))
+	require.Implements(t, new(RawParameterGetter), new(LayeredParameterGetter))
+}
+
+func TestConfigMapParameterGetter(t *testing.T) {
+	t.Parallel()
+
+	client := fake.
+		NewClientBuilder().
+		WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "test-namespace",
+			},
+			Data: map[string]string{
+				"enablesmoketest": "false",
+			},
+		}).
+		Build()
+
+	getter := NewConfigMapParameterGetter(
+		client,
+		WithNamespace("test-namespace"),
+		WithName("test"),
+	)
+
+	params, err := getter.GetParameters(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, NewPhaseRequestParameters(
+		WithEnableSmokeTest{Value: controllers.BoolPtr(false)},
+		WithNetworkPolicies{DefaultNetworkPolicyTemplateName},
+	), params)
+
+	missing := NewConfigMapParameterGetter(
+		client,
+		WithNamespace("test-namespace"),
+		WithName("missing"),
+	)
+
+	_, err = missing.GetParameters(context.Background())
+	require.ErrorIs(t, err, ErrParameterSourceNotFound)
+}
+
+func TestFileParameterGetter(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	// mimic the layout of a projected volume
+	dataDir := filepath.Join(dir, "..2024_01_01")
+	require.NoError(t, os.Mkdir(dataDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "size"), []byte("medium\n"), 0o600))
+	require.NoError(t, os.Symlink(dataDir, filepath.Join(dir, "..data")))
+	require.NoError(t, os.Symlink(filepath.Join("..data", "size"), filepath.Join(dir, "size")))
+
+	getter := NewFileParameterGetter(WithParameterDirectory(dir))
+
+	params, err := getter.GetParameters(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, NewPhaseRequestParameters(
+		WithSize{Value: controllers.StringPtr("medium")},
+		WithNetworkPolicies{DefaultNetworkPolicyTemplateName},
+	), params)
+
+	missing := NewFileParameterGetter(WithParameterDirectory(filepath.Join(dir, "missing")))
+
+	_, err = missing.GetParameters(context.Background())
+	require.ErrorIs(t, err, ErrParameterSourceNotFound)
+}
+
+func TestEnvParameterGetter(t *testing.T) {
+	t.Parallel()
+
+	env := map[string]string{
+		"TEST_APPLYNETWORKPOLICIES": "true",
+		"TEST_SIZE":                 "large",
+		"OTHER_ENABLESMOKETEST":     "true",
+	}
+
+	getter := NewEnvParameterGetter(
+		WithParameterEnvPrefix("TEST_"),
+		WithLookupEnv(func(name string) (string, bool) {
+			val, ok := env[name]
+
+			return val, ok
+		}),
+	)
+
+	params, err := getter.GetParameters(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, NewPhaseRequestParameters(
+		WithApplyNetworkPolicies{Value: controllers.BoolPtr(true)},
+		WithSize{Value: controllers.StringPtr("large")},
+		WithNetworkPolicies{DefaultNetworkPolicyTemplateName},
+	), params)
+}
+
+func TestLayeredParameterGetter(t *testing.T) {
+	t.Parallel()
+
+	client := fake.
+		NewClientBuilder().
+		WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "test-namespace",
+			},
+			Data: map[string][]byte{
+				"applynetworkpolicies": []byte("true"),
+				"size":                 []byte("small"),
+			},
+		}).
+		Build()
+
+	env := NewEnvParameterGetter(
+		WithParameterEnvPrefix("TEST_"),
+		WithLookupEnv(func(name string) (string, bool) {
+			if name == "TEST_SIZE" {
+				return "large", true
+			}
+
+			return "", false
+		}),
+	)
+	configMap := NewConfigMapParameterGetter(
+		client,
+		WithNamespace("test-namespace"),
+		WithName("missing"),
+	)
+	secret := NewSecretParameterGetter(
+		client,
+		WithNamespace("test-namespace"),
+		WithName("test"),
+	)
+
+	getter := NewLayeredParameterGetter([]RawParameterGetter{env, configMap, secret})
+
+	params, err := getter.GetParameters(context.Background())
+	require.NoError(t, err)
+
+	size, _ := params.GetSize()
+	assert.Equal(t, "large", size)
+
+	apply, _ := params.GetApplyNetworkPolicies()
+	assert.True(t, apply)
+
+	src, _ := params.Source(sizeParameterID)
+	assert.Equal(t, "env/TEST_", src)
+
+	src, _ = params.Source(applyNetworkPoliciesID)
+	assert.Equal(t, "secret/test-namespace/test", src)
+
+	onlyMissing := NewLayeredParameterGetter([]RawParameterGetter{configMap})
+
+	_, err = onlyMissing.GetParameters(context.Background())
+	require.ErrorIs(t, err, ErrParameterSourceNotFound)
+}
+
+func TestNewParameterGetter(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Kinds       []ParameterSourceKind
+		AssertError require.ErrorAssertionFunc
+	}{
+		"single kind": {
+			Kinds:       []ParameterSourceKind{ParameterSourceKindSecret},
+			AssertError: require.NoError,
+		},
+		"all kinds": {
+			Kinds: []ParameterSourceKind{
+				ParameterSourceKindEnv,
+				ParameterSourceKindFile,
+				ParameterSourceKindConfigMap,
+				ParameterSourceKindSecret,
+			},
+			AssertError: require.NoError,
+		},
+		"unknown kind": {
+			Kinds:       []ParameterSourceKind{"unknown"},
+			AssertError: require.Error,
+		},
+		"no kinds": {
+			AssertError: require.Error,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewParameterGetter(
+				fake.NewClientBuilder().Build(),
+				tc.Kinds,
+				WithNamespace("test-namespace"),
+				WithAddonParameterSecretName("test-secret"),
+				WithParameterConfigMapName("test-configmap"),
+			)
+			tc.AssertError(t, err)
+		})
+	}
+}
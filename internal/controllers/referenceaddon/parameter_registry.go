@@ -0,0 +1,498 @@
+package referenceaddon
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	refv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
+)
+
+// ParameterType determines how the raw value of an addon
+// parameter is parsed.
+type ParameterType string
+
+func (t ParameterType) String() string {
+	return string(t)
+}
+
+const (
+	// ParameterTypeBool accepts "true" or "false" in any case.
+	ParameterTypeBool ParameterType = "bool"
+	// ParameterTypeInt accepts a base 10 integer.
+	ParameterTypeInt ParameterType = "int"
+	// ParameterTypeEnum accepts one of the definition's Enum values.
+	ParameterTypeEnum ParameterType = "enum"
+	// ParameterTypeDuration accepts a Go duration string such as "90s".
+	ParameterTypeDuration ParameterType = "duration"
+	// ParameterTypeURLList accepts a comma or newline separated list
+	// of absolute http(s) URLs.
+	ParameterTypeURLList ParameterType = "urllist"
+	// ParameterTypeJSON accepts any valid JSON document.
+	ParameterTypeJSON ParameterType = "json"
+	// ParameterTypeStringList accepts a comma or newline separated
+	// list of strings. If the definition declares Enum values every
+	// element must be one of them.
+	ParameterTypeStringList ParameterType = "stringlist"
+)
+
+var (
+	ErrInvalidIntValue      = errors.New("invalid int value")
+	ErrInvalidEnumValue     = errors.New("invalid enum value")
+	ErrInvalidDurationValue = errors.New("invalid duration value")
+	ErrInvalidURLValue      = errors.New("invalid URL value")
+	ErrInvalidJSONValue     = errors.New("invalid JSON value")
+	ErrInvalidCIDRValue     = errors.New("invalid CIDR value")
+)
+
+// ParameterDefinition declares a single addon parameter.
+type ParameterDefinition struct {
+	// Key is the key of the parameter in the addon parameters Secret.
+	Key string
+	// Type determines how the raw value is parsed.
+	Type ParameterType
+	// Title is a short human readable name of the parameter.
+	Title string
+	// Description is a human readable description of the parameter.
+	Description string
+	// Default is the parsed value used when the parameter is absent.
+	// A nil Default leaves the parameter unset.
+	Default any
+	// Enum lists the values accepted by an enum parameter
+	// or by the elements of a string list parameter.
+	Enum []string
+	// Sensitive parameters have their values redacted
+	// when reported in status.
+	Sensitive bool
+	// Validate optionally performs additional validation on
+	// the parsed value.
+	Validate func(any) error
+}
+
+// Parse parses and validates the given raw value.
+func (d ParameterDefinition) Parse(raw string) (any, error) {
+	val, err := d.parse(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	if d.Validate != nil {
+		if err := d.Validate(val); err != nil {
+			return nil, err
+		}
+	}
+
+	return val, nil
+}
+
+func (d ParameterDefinition) parse(raw string) (any, error) {
+	switch d.Type {
+	case ParameterTypeBool:
+		return parseBool(raw)
+	case ParameterTypeInt:
+		i, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q", ErrInvalidIntValue, raw)
+		}
+
+		return i, nil
+	case ParameterTypeEnum:
+		for _, allowed := range d.Enum {
+			if raw == allowed {
+				return raw, nil
+			}
+		}
+
+		return nil, fmt.Errorf("%w %q: must be one of [%s]", ErrInvalidEnumValue, raw, strings.Join(d.Enum, ", "))
+	case ParameterTypeDuration:
+		dur, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q", ErrInvalidDurationValue, raw)
+		}
+
+		return dur, nil
+	case ParameterTypeURLList:
+		return parseURLList(raw)
+	case ParameterTypeStringList:
+		return d.parseStringList(raw)
+	case ParameterTypeJSON:
+		if !json.Valid([]byte(raw)) {
+			return nil, ErrInvalidJSONValue
+		}
+
+		return json.RawMessage(raw), nil
+	default:
+		return nil, fmt.Errorf("unknown parameter type %q", d.Type)
+	}
+}
+
+func splitList(raw string) []string {
+	fields := strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == '\n'
+	})
+
+	res := make([]string, 0, len(fields))
+
+	for _, f := range fields {
+		if f = strings.TrimSpace(f); f != "" {
+			res = append(res, f)
+		}
+	}
+
+	return res
+}
+
+func (d ParameterDefinition) parseStringList(raw string) ([]string, error) {
+	elems := splitList(raw)
+
+	if len(d.Enum) == 0 {
+		return elems, nil
+	}
+
+	for _, e := range elems {
+		if !containsString(d.Enum, e) {
+			return nil, fmt.Errorf("%w %q: must be one of [%s]", ErrInvalidEnumValue, e, strings.Join(d.Enum, ", "))
+		}
+	}
+
+	return elems, nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseURLList(raw string) ([]string, error) {
+	fields := splitList(raw)
+
+	urls := make([]string, 0, len(fields))
+
+	for _, f := range fields {
+		u, err := url.ParseRequestURI(f)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("%w %q", ErrInvalidURLValue, f)
+		}
+
+		urls = append(urls, f)
+	}
+
+	return urls, nil
+}
+
+func (d ParameterDefinition) validateDefinition() error {
+	if d.Key == "" {
+		return errors.New("empty key")
+	}
+
+	switch d.Type {
+	case ParameterTypeBool, ParameterTypeInt, ParameterTypeDuration, ParameterTypeURLList, ParameterTypeJSON, ParameterTypeStringList:
+	case ParameterTypeEnum:
+		if len(d.Enum) == 0 {
+			return errors.New("enum parameter without allowed values")
+		}
+	default:
+		return fmt.Errorf("unknown type %q", d.Type)
+	}
+
+	if d.Default == nil {
+		return nil
+	}
+
+	raw, err := formatParameterValue(d.Default)
+	if err != nil {
+		return fmt.Errorf("invalid default: %w", err)
+	}
+
+	parsed, err := d.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid default: %w", err)
+	}
+
+	if reflect.TypeOf(parsed) != reflect.TypeOf(d.Default) {
+		return fmt.Errorf("default of type %T does not match parameter type %q", d.Default, d.Type)
+	}
+
+	return nil
+}
+
+// ParameterError reports an addon parameter which failed to parse
+// or validate.
+type ParameterError struct {
+	Key string
+	Err error
+}
+
+func (e *ParameterError) Error() string {
+	return fmt.Sprintf("parameter %q: %v", e.Key, e.Err)
+}
+
+func (e *ParameterError) Unwrap() error {
+	return e.Err
+}
+
+// NewParameterRegistry returns a ParameterRegistry holding the given
+// definitions. An error is returned if a definition is incomplete or
+// a key is declared more than once.
+func NewParameterRegistry(defs ...ParameterDefinition) (*ParameterRegistry, error) {
+	reg := &ParameterRegistry{
+		byKey: make(map[string]ParameterDefinition, len(defs)),
+	}
+
+	for _, def := range defs {
+		if err := def.validateDefinition(); err != nil {
+			return nil, fmt.Errorf("invalid definition for parameter %q: %w", def.Key, err)
+		}
+
+		if _, ok := reg.byKey[def.Key]; ok {
+			return nil, fmt.Errorf("parameter %q declared more than once", def.Key)
+		}
+
+		reg.byKey[def.Key] = def
+		reg.defs = append(reg.defs, def)
+	}
+
+	return reg, nil
+}
+
+// ParameterRegistry declares the addon parameters which are
+// understood by the reference-addon.
+type ParameterRegistry struct {
+	defs  []ParameterDefinition
+	byKey map[string]ParameterDefinition
+}
+
+// Definitions returns the registered definitions in declaration order.
+func (r *ParameterRegistry) Definitions() []ParameterDefinition {
+	return append([]ParameterDefinition{}, r.defs...)
+}
+
+// Lookup returns the definition registered for the given key.
+func (r *ParameterRegistry) Lookup(key string) (ParameterDefinition, bool) {
+	def, ok := r.byKey[key]
+
+	return def, ok
+}
+
+// Validate validates an already parsed value of the parameter with
+// the given key by formatting it and parsing it with the registered
+// definition. A failure is reported as a *ParameterError. Values of
+// keys which are not registered are not validated.
+func (r *ParameterRegistry) Validate(key string, val any) error {
+	def, ok := r.byKey[key]
+	if !ok {
+		return nil
+	}
+
+	raw, err := formatParameterValue(val)
+	if err == nil {
+		_, err = def.Parse(raw)
+	}
+
+	if err != nil {
+		return &ParameterError{Key: key, Err: err}
+	}
+
+	return nil
+}
+
+// Parse parses and validates the registered parameters found in the
+// given raw values. Keys which are not registered are ignored. Every
+// parameter failing validation is reported as a *ParameterError and
+// no parameters are returned in that case.
+func (r *ParameterRegistry) Parse(raw map[string]string) (PhaseRequestParameters, error) {
+	var (
+		opts []PhaseRequestParametersOption
+		errs []error
+	)
+
+	for _, def := range r.defs {
+		val, ok := raw[def.Key]
+		if !ok {
+			if def.Default != nil {
+				opts = append(opts, WithParameter{Key: def.Key, Value: def.Default})
+			}
+
+			continue
+		}
+
+		parsed, err := def.Parse(val)
+		if err != nil {
+			errs = append(errs, &ParameterError{Key: def.Key, Err: err})
+
+			continue
+		}
+
+		opts = append(opts, WithParameter{Key: def.Key, Value: parsed})
+	}
+
+	if len(errs) > 0 {
+		return NewPhaseRequestParameters(), errors.Join(errs...)
+	}
+
+	return NewPhaseRequestParameters(opts...), nil
+}
+
+// InvalidParameterKeys returns the sorted keys of every *ParameterError
+// wrapped by the given error.
+func InvalidParameterKeys(err error) []string {
+	var keys []string
+
+	collectParameterErrorKeys(err, &keys)
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func collectParameterErrorKeys(err error, keys *[]string) {
+	if err == nil {
+		return
+	}
+
+	if pErr, ok := err.(*ParameterError); ok {
+		*keys = append(*keys, pErr.Key)
+
+		return
+	}
+
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			collectParameterErrorKeys(inner, keys)
+		}
+	case interface{ Unwrap() error }:
+		collectParameterErrorKeys(e.Unwrap(), keys)
+	}
+}
+
+const (
+	applyNetworkPoliciesID = "applynetworkpolicies"
+	enableSmokeTestID      = "enablesmoketest"
+	sizeParameterID        = "size"
+	sampleURLsParameterID  = "sampleurls"
+	customSizeParameterID  = "customsize"
+	networkPoliciesID      = "networkpolicies"
+	allowedCIDRsID         = "allowedcidrs"
+)
+
+// AddonSizeCustom selects the sample workload sizing given by
+// the 'customsize' parameter rather than a predefined tier.
+const AddonSizeCustom = "custom"
+
+// AddonSizes are the values accepted by the 'size' parameter.
+var AddonSizes = []string{"small", "medium", "large", AddonSizeCustom}
+
+// DefaultParameterDefinitions returns the definitions of the
+// parameters consumed by the reference-addon's phases.
+func DefaultParameterDefinitions() []ParameterDefinition {
+	return []ParameterDefinition{
+		{
+			Key:         applyNetworkPoliciesID,
+			Title:       "Apply NetworkPolicies",
+			Type:        ParameterTypeBool,
+			Description: "Apply (true) or remove (false) the addon's NetworkPolicies.",
+		},
+		{
+			Key:         enableSmokeTestID,
+			Title:       "Enable Smoke Test",
+			Type:        ParameterTypeBool,
+			Description: "Enable (true) or disable (false) the smoke test metric.",
+		},
+		{
+			Key:         sizeParameterID,
+			Title:       "Size",
+			Type:        ParameterTypeEnum,
+			Description: "Requested size of the addon.",
+			Enum:        AddonSizes,
+		},
+		{
+			Key:         sampleURLsParameterID,
+			Title:       "Sample URLs",
+			Type:        ParameterTypeURLList,
+			Description: "Comma separated URLs probed to produce the sample availability and response time metrics.",
+		},
+		{
+			Key:         customSizeParameterID,
+			Title:       "Custom Size",
+			Type:        ParameterTypeJSON,
+			Description: `Replicas and resources of the sample workload used when 'size' is "custom", e.g. {"replicas": 2, "resources": {"requests": {"cpu": "100m"}}}.`,
+			Validate: func(val any) error {
+				_, err := decodeWorkloadSize(val.(json.RawMessage))
+
+				return err
+			},
+		},
+		{
+			Key:         networkPoliciesID,
+			Title:       "NetworkPolicies",
+			Type:        ParameterTypeStringList,
+			Description: "Comma separated names of the NetworkPolicy templates applied when 'applynetworkpolicies' is true.",
+			Enum:        NetworkPolicyTemplateNames(),
+			Default:     []string{DefaultNetworkPolicyTemplateName},
+		},
+		{
+			Key:         allowedCIDRsID,
+			Title:       "Allowed CIDRs",
+			Type:        ParameterTypeStringList,
+			Description: "Comma separated CIDRs substituted into the 'allow-from-cidrs' and 'restrict-egress' NetworkPolicy templates.",
+			Validate: func(val any) error {
+				for _, cidr := range val.([]string) {
+					if _, _, err := net.ParseCIDR(cidr); err != nil {
+						return fmt.Errorf("%w %q", ErrInvalidCIDRValue, cidr)
+					}
+				}
+
+				return nil
+			},
+		},
+		{
+			Key:         DefaultUninstallParameterID,
+			Title:       "Uninstall",
+			Type:        ParameterTypeBool,
+			Description: "Request (true) an uninstall of the addon when the 'parameter' uninstall signaler is enabled.",
+		},
+	}
+}
+
+// decodeWorkloadSize strictly decodes a 'customsize' parameter value.
+func decodeWorkloadSize(raw json.RawMessage) (refv1alpha1.WorkloadSize, error) {
+	var size refv1alpha1.WorkloadSize
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&size); err != nil {
+		return refv1alpha1.WorkloadSize{}, fmt.Errorf("%w: %v", ErrInvalidJSONValue, err)
+	}
+
+	if size.Replicas < 0 {
+		return refv1alpha1.WorkloadSize{}, fmt.Errorf("%w: replicas must not be negative", ErrInvalidJSONValue)
+	}
+
+	return size, nil
+}
+
+// DefaultParameterRegistry returns a ParameterRegistry holding
+// the DefaultParameterDefinitions.
+func DefaultParameterRegistry() *ParameterRegistry {
+	reg, err := NewParameterRegistry(DefaultParameterDefinitions()...)
+	if err != nil {
+		panic(fmt.Sprintf("invalid default parameter definitions: %v", err))
+	}
+
+	return reg
+}
@@ -0,0 +1,284 @@
+package referenceaddon
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewParameterRegistry(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Definitions []ParameterDefinition
+		AssertError require.ErrorAssertionFunc
+	}{
+		"defaults": {
+			Definitions: DefaultParameterDefinitions(),
+			AssertError: require.NoError,
+		},
+		"empty key": {
+			Definitions: []ParameterDefinition{
+				{Type: ParameterTypeBool},
+			},
+			AssertError: require.Error,
+		},
+		"unknown type": {
+			Definitions: []ParameterDefinition{
+				{Key: "test", Type: "unknown"},
+			},
+			AssertError: require.Error,
+		},
+		"enum without values": {
+			Definitions: []ParameterDefinition{
+				{Key: "test", Type: ParameterTypeEnum},
+			},
+			AssertError: require.Error,
+		},
+		"duplicate key": {
+			Definitions: []ParameterDefinition{
+				{Key: "test", Type: ParameterTypeBool},
+				{Key: "test", Type: ParameterTypeInt},
+			},
+			AssertError: require.Error,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewParameterRegistry(tc.Definitions...)
+			tc.AssertError(t, err)
+		})
+	}
+}
+
+func TestParameterDefinition_Parse(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Definition    ParameterDefinition
+		Raw           string
+		ExpectedValue any
+		ExpectedError error
+	}{
+		"bool": {
+			Definition:    ParameterDefinition{Type: ParameterTypeBool},
+			Raw:           "True",
+			ExpectedValue: true,
+		},
+		"bool/invalid": {
+			Definition:    ParameterDefinition{Type: ParameterTypeBool},
+			Raw:           "yes",
+			ExpectedError: ErrInvalidBoolValue,
+		},
+		"int": {
+			Definition:    ParameterDefinition{Type: ParameterTypeInt},
+			Raw:           " 42 ",
+			ExpectedValue: 42,
+		},
+		"int/invalid": {
+			Definition:    ParameterDefinition{Type: ParameterTypeInt},
+			Raw:           "4.2",
+			ExpectedError: ErrInvalidIntValue,
+		},
+		"enum": {
+			Definition:    ParameterDefinition{Type: ParameterTypeEnum, Enum: AddonSizes},
+			Raw:           "medium",
+			ExpectedValue: "medium",
+		},
+		"enum/invalid": {
+			Definition:    ParameterDefinition{Type: ParameterTypeEnum, Enum: AddonSizes},
+			Raw:           "huge",
+			ExpectedError: ErrInvalidEnumValue,
+		},
+		"duration": {
+			Definition:    ParameterDefinition{Type: ParameterTypeDuration},
+			Raw:           "90s",
+			ExpectedValue: 90 * time.Second,
+		},
+		"duration/invalid": {
+			Definition:    ParameterDefinition{Type: ParameterTypeDuration},
+			Raw:           "90",
+			ExpectedError: ErrInvalidDurationValue,
+		},
+		"url list": {
+			Definition:    ParameterDefinition{Type: ParameterTypeURLList},
+			Raw:           "https://a.io,\nhttp://b.io/path",
+			ExpectedValue: []string{"https://a.io", "http://b.io/path"},
+		},
+		"url list/invalid": {
+			Definition:    ParameterDefinition{Type: ParameterTypeURLList},
+			Raw:           "https://a.io,ftp://b.io",
+			ExpectedError: ErrInvalidURLValue,
+		},
+		"string list": {
+			Definition:    ParameterDefinition{Type: ParameterTypeStringList, Enum: []string{"a", "b"}},
+			Raw:           "a,\n b",
+			ExpectedValue: []string{"a", "b"},
+		},
+		"string list/invalid": {
+			Definition:    ParameterDefinition{Type: ParameterTypeStringList, Enum: []string{"a", "b"}},
+			Raw:           "a,c",
+			ExpectedError: ErrInvalidEnumValue,
+		},
+		"json": {
+			Definition:    ParameterDefinition{Type: ParameterTypeJSON},
+			Raw:           `{"a": 1}`,
+			ExpectedValue: json.RawMessage(`{"a": 1}`),
+		},
+		"json/invalid": {
+			Definition:    ParameterDefinition{Type: ParameterTypeJSON},
+			Raw:           `{"a": }`,
+			ExpectedError: ErrInvalidJSONValue,
+		},
+		"custom validation": {
+			Definition: ParameterDefinition{
+				Type: ParameterTypeInt,
+				Validate: func(v any) error {
+					if v.(int) < 0 {
+						return errNegative
+					}
+
+					return nil
+				},
+			},
+			Raw:           "-1",
+			ExpectedError: errNegative,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			val, err := tc.Definition.Parse(tc.Raw)
+			if tc.ExpectedError != nil {
+				require.ErrorIs(t, err, tc.ExpectedError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedValue, val)
+		})
+	}
+}
+
+var errNegative = errors.New("negative")
+
+func TestParameterRegistry_Parse(t *testing.T) {
+	t.Parallel()
+
+	reg, err := NewParameterRegistry(
+		ParameterDefinition{Key: "enabled", Type: ParameterTypeBool},
+		ParameterDefinition{Key: "size", Type: ParameterTypeEnum, Enum: AddonSizes},
+		ParameterDefinition{Key: "timeout", Type: ParameterTypeDuration, Default: time.Minute},
+	)
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		Raw            map[string]string
+		ExpectedParams PhaseRequestParameters
+		ExpectedKeys   []string
+	}{
+		"empty": {
+			ExpectedParams: NewPhaseRequestParameters(
+				WithParameter{Key: "timeout", Value: time.Minute},
+			),
+		},
+		"all set": {
+			Raw: map[string]string{
+				"enabled": "true",
+				"size":    "large",
+				"timeout": "5s",
+				"unknown": "ignored",
+			},
+			ExpectedParams: NewPhaseRequestParameters(
+				WithParameter{Key: "enabled", Value: true},
+				WithParameter{Key: "size", Value: "large"},
+				WithParameter{Key: "timeout", Value: 5 * time.Second},
+			),
+		},
+		"invalid values": {
+			Raw: map[string]string{
+				"enabled": "maybe",
+				"size":    "huge",
+				"timeout": "5s",
+			},
+			ExpectedParams: NewPhaseRequestParameters(),
+			ExpectedKeys:   []string{"enabled", "size"},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			params, err := reg.Parse(tc.Raw)
+			if len(tc.ExpectedKeys) > 0 {
+				require.Error(t, err)
+				assert.Equal(t, tc.ExpectedKeys, InvalidParameterKeys(err))
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.ExpectedParams, params)
+		})
+	}
+}
+
+func TestDefaultParameterRegistry_CustomSize(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Raw              string
+		ExpectedReplicas int32
+		ExpectError      bool
+	}{
+		"replicas only": {
+			Raw:              `{"replicas": 2}`,
+			ExpectedReplicas: 2,
+		},
+		"with resources": {
+			Raw:              `{"replicas": 1, "resources": {"requests": {"cpu": "100m"}}}`,
+			ExpectedReplicas: 1,
+		},
+		"negative replicas": {
+			Raw:         `{"replicas": -1}`,
+			ExpectError: true,
+		},
+		"unknown field": {
+			Raw:         `{"replica": 2}`,
+			ExpectError: true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			params, err := DefaultParameterRegistry().Parse(map[string]string{
+				customSizeParameterID: tc.Raw,
+			})
+			if tc.ExpectError {
+				require.Error(t, err)
+				assert.Equal(t, []string{customSizeParameterID}, InvalidParameterKeys(err))
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			size, ok := params.GetCustomSize()
+			require.True(t, ok)
+
+			assert.Equal(t, tc.ExpectedReplicas, size.Replicas)
+		})
+	}
+}
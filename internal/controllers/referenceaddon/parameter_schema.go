@@ -0,0 +1,228 @@
+package referenceaddon
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+const (
+	durationPattern = `^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+	urlListPattern  = `^\s*https?://[^\s,]+(\s*[,\n]\s*https?://[^\s,]+)*\s*$`
+)
+
+// ParameterJSONSchema is a JSON Schema document describing
+// the parsed addon parameters.
+type ParameterJSONSchema struct {
+	Schema               string                                 `json:"$schema"`
+	Title                string                                 `json:"title,omitempty"`
+	Type                 string                                 `json:"type"`
+	Properties           map[string]ParameterJSONSchemaProperty `json:"properties"`
+	AdditionalProperties bool                                   `json:"additionalProperties"`
+}
+
+// ParameterJSONSchemaProperty describes a single addon parameter
+// within a ParameterJSONSchema.
+type ParameterJSONSchemaProperty struct {
+	Title       string                       `json:"title,omitempty"`
+	Description string                       `json:"description,omitempty"`
+	Type        string                       `json:"type,omitempty"`
+	Format      string                       `json:"format,omitempty"`
+	Pattern     string                       `json:"pattern,omitempty"`
+	Enum        []string                     `json:"enum,omitempty"`
+	Items       *ParameterJSONSchemaProperty `json:"items,omitempty"`
+	Default     any                          `json:"default,omitempty"`
+}
+
+// JSONSchema returns a JSON Schema document describing the
+// registered parameters.
+func (r *ParameterRegistry) JSONSchema() ParameterJSONSchema {
+	schema := ParameterJSONSchema{
+		Schema:               jsonSchemaDraft,
+		Title:                "reference-addon parameters",
+		Type:                 "object",
+		Properties:           make(map[string]ParameterJSONSchemaProperty, len(r.defs)),
+		AdditionalProperties: true,
+	}
+
+	for _, def := range r.defs {
+		prop := ParameterJSONSchemaProperty{
+			Title:       def.Title,
+			Description: def.Description,
+			Default:     jsonDefault(def.Default),
+		}
+
+		switch def.Type {
+		case ParameterTypeBool:
+			prop.Type = "boolean"
+		case ParameterTypeInt:
+			prop.Type = "integer"
+		case ParameterTypeEnum:
+			prop.Type = "string"
+			prop.Enum = def.Enum
+		case ParameterTypeDuration:
+			prop.Type = "string"
+			prop.Pattern = durationPattern
+		case ParameterTypeURLList:
+			prop.Type = "array"
+			prop.Items = &ParameterJSONSchemaProperty{
+				Type:   "string",
+				Format: "uri",
+			}
+		case ParameterTypeStringList:
+			prop.Type = "array"
+			prop.Items = &ParameterJSONSchemaProperty{
+				Type: "string",
+				Enum: def.Enum,
+			}
+		case ParameterTypeJSON:
+			// any JSON document is accepted
+		}
+
+		schema.Properties[def.Key] = prop
+	}
+
+	return schema
+}
+
+func jsonDefault(val any) any {
+	switch v := val.(type) {
+	case time.Duration:
+		return v.String()
+	default:
+		return v
+	}
+}
+
+// OCMAddonParameters is the addon-parameter section of
+// OCM addon metadata.
+type OCMAddonParameters struct {
+	AddonParameters []OCMAddonParameter `json:"addOnParameters"`
+}
+
+// OCMAddonParameter describes a single parameter in the
+// format expected by OCM addon metadata.
+type OCMAddonParameter struct {
+	ID           string                    `json:"id"`
+	Name         string                    `json:"name"`
+	Description  string                    `json:"description"`
+	ValueType    string                    `json:"value_type"`
+	Validation   string                    `json:"validation,omitempty"`
+	Required     bool                      `json:"required"`
+	Editable     bool                      `json:"editable"`
+	Enabled      bool                      `json:"enabled"`
+	DefaultValue string                    `json:"default_value,omitempty"`
+	Options      []OCMAddonParameterOption `json:"options,omitempty"`
+}
+
+// OCMAddonParameterOption is one selectable value of an
+// OCMAddonParameter.
+type OCMAddonParameterOption struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// OCMAddonParameters returns the registered parameters in the
+// OCM addon-parameter format.
+func (r *ParameterRegistry) OCMAddonParameters() OCMAddonParameters {
+	res := OCMAddonParameters{
+		AddonParameters: make([]OCMAddonParameter, 0, len(r.defs)),
+	}
+
+	for _, def := range r.defs {
+		param := OCMAddonParameter{
+			ID:          def.Key,
+			Name:        def.Title,
+			Description: def.Description,
+			ValueType:   "string",
+			Editable:    true,
+			Enabled:     true,
+		}
+
+		if param.Name == "" {
+			param.Name = def.Key
+		}
+
+		if def.Default != nil {
+			param.DefaultValue, _ = formatParameterValue(def.Default)
+		}
+
+		switch def.Type {
+		case ParameterTypeBool:
+			param.ValueType = "boolean"
+		case ParameterTypeInt:
+			param.ValueType = "number"
+		case ParameterTypeEnum:
+			for _, val := range def.Enum {
+				param.Options = append(param.Options, OCMAddonParameterOption{
+					Name:  enumOptionName(val),
+					Value: val,
+				})
+			}
+		case ParameterTypeDuration:
+			param.Validation = durationPattern
+		case ParameterTypeURLList:
+			param.Validation = urlListPattern
+		case ParameterTypeStringList:
+			param.Validation = stringListPattern(def.Enum)
+		case ParameterTypeJSON:
+		}
+
+		res.AddonParameters = append(res.AddonParameters, param)
+	}
+
+	return res
+}
+
+// stringListPattern returns a pattern matching a comma or newline
+// separated list of the given values. No pattern is returned if
+// any value is accepted.
+func stringListPattern(enum []string) string {
+	if len(enum) == 0 {
+		return ""
+	}
+
+	quoted := make([]string, 0, len(enum))
+
+	for _, val := range enum {
+		quoted = append(quoted, regexp.QuoteMeta(val))
+	}
+
+	elem := "(" + strings.Join(quoted, "|") + ")"
+
+	return `^\s*` + elem + `(\s*[,\n]\s*` + elem + `)*\s*$`
+}
+
+func enumOptionName(val string) string {
+	if val == "" {
+		return val
+	}
+
+	return strings.ToUpper(val[:1]) + val[1:]
+}
+
+// formatParameterValue returns the raw string form of a parsed
+// parameter value as it would appear in the addon parameters Secret.
+func formatParameterValue(val any) (string, error) {
+	switch v := val.(type) {
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case string:
+		return v, nil
+	case time.Duration:
+		return v.String(), nil
+	case []string:
+		return strings.Join(v, ","), nil
+	case json.RawMessage:
+		return string(v), nil
+	default:
+		return "", fmt.Errorf("unsupported parameter value type %T", val)
+	}
+}
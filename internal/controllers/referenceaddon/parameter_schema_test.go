@@ -0,0 +1,90 @@
+package referenceaddon
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParameterRegistry_JSONSchema(t *testing.T) {
+	t.Parallel()
+
+	reg, err := NewParameterRegistry(
+		ParameterDefinition{Key: "enabled", Type: ParameterTypeBool, Default: true},
+		ParameterDefinition{Key: "replicas", Type: ParameterTypeInt},
+		ParameterDefinition{Key: "size", Type: ParameterTypeEnum, Enum: AddonSizes},
+		ParameterDefinition{Key: "timeout", Type: ParameterTypeDuration, Default: time.Minute},
+		ParameterDefinition{Key: "urls", Type: ParameterTypeURLList},
+		ParameterDefinition{Key: "extra", Type: ParameterTypeJSON},
+	)
+	require.NoError(t, err)
+
+	schema := reg.JSONSchema()
+
+	assert.Equal(t, "object", schema.Type)
+	assert.Equal(t, ParameterJSONSchemaProperty{Type: "boolean", Default: true}, schema.Properties["enabled"])
+	assert.Equal(t, ParameterJSONSchemaProperty{Type: "integer"}, schema.Properties["replicas"])
+	assert.Equal(t, ParameterJSONSchemaProperty{Type: "string", Enum: AddonSizes}, schema.Properties["size"])
+	assert.Equal(t, ParameterJSONSchemaProperty{Type: "string", Pattern: durationPattern, Default: "1m0s"}, schema.Properties["timeout"])
+	assert.Equal(t, "array", schema.Properties["urls"].Type)
+	assert.Equal(t, ParameterJSONSchemaProperty{}, schema.Properties["extra"])
+
+	_, err = json.Marshal(schema)
+	require.NoError(t, err)
+}
+
+func TestParameterRegistry_OCMAddonParameters(t *testing.T) {
+	t.Parallel()
+
+	params := DefaultParameterRegistry().OCMAddonParameters()
+
+	ids := make([]string, 0, len(params.AddonParameters))
+
+	for _, param := range params.AddonParameters {
+		ids = append(ids, param.ID)
+
+		assert.NotEmpty(t, param.Name)
+		assert.NotEmpty(t, param.Description)
+	}
+
+	assert.Equal(t, []string{
+		applyNetworkPoliciesID,
+		enableSmokeTestID,
+		sizeParameterID,
+		sampleURLsParameterID,
+		customSizeParameterID,
+		networkPoliciesID,
+		allowedCIDRsID,
+		DefaultUninstallParameterID,
+	}, ids)
+
+	size := params.AddonParameters[2]
+
+	assert.Equal(t, "string", size.ValueType)
+	assert.Equal(t, []OCMAddonParameterOption{
+		{Name: "Small", Value: "small"},
+		{Name: "Medium", Value: "medium"},
+		{Name: "Large", Value: "large"},
+		{Name: "Custom", Value: "custom"},
+	}, size.Options)
+
+	assert.Equal(t, "boolean", params.AddonParameters[0].ValueType)
+	assert.Equal(t, urlListPattern, params.AddonParameters[3].Validation)
+}
+
+func TestNewParameterRegistry_InvalidDefault(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewParameterRegistry(
+		ParameterDefinition{Key: "size", Type: ParameterTypeEnum, Enum: AddonSizes, Default: "huge"},
+	)
+	require.Error(t, err)
+
+	_, err = NewParameterRegistry(
+		ParameterDefinition{Key: "enabled", Type: ParameterTypeBool, Default: "true"},
+	)
+	require.Error(t, err)
+}
@@ -0,0 +1,104 @@
+package referenceaddon
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	refv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
+)
+
+const redactedParameterValue = "<redacted>"
+
+// Hash returns a stable hash of the parameter values. Sources are
+// not included so that moving a value between sources does not
+// change the hash.
+func (p *PhaseRequestParameters) Hash() string {
+	h := sha256.New()
+
+	for _, key := range p.keys() {
+		fmt.Fprintf(h, "%s=%s\n", key, stringifyParameterValue(p.values[key]))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+func (p *PhaseRequestParameters) keys() []string {
+	keys := make([]string, 0, len(p.values))
+
+	for key := range p.values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Summarize returns the given parameters sorted by key with the values
+// of Sensitive parameters redacted. Credentials embedded in URLs are
+// always redacted.
+func (r *ParameterRegistry) Summarize(params PhaseRequestParameters) []refv1alpha1.ObservedParameter {
+	keys := params.keys()
+
+	res := make([]refv1alpha1.ObservedParameter, 0, len(keys))
+
+	for _, key := range keys {
+		val := params.values[key]
+
+		summary := refv1alpha1.ObservedParameter{
+			Key:   key,
+			Value: stringifyParameterValue(val),
+		}
+
+		if def, ok := r.Lookup(key); ok && def.Sensitive {
+			summary.Value = redactedParameterValue
+		} else if urls, ok := val.([]string); ok && def.Type == ParameterTypeURLList {
+			summary.Value = strings.Join(redactURLs(urls), ",")
+		}
+
+		if src, ok := params.Source(key); ok {
+			summary.Source = src
+		}
+
+		res = append(res, summary)
+	}
+
+	return res
+}
+
+func stringifyParameterValue(val any) string {
+	if s, err := formatParameterValue(val); err == nil {
+		return s
+	}
+
+	return fmt.Sprintf("%v", val)
+}
+
+func redactURLs(urls []string) []string {
+	res := make([]string, 0, len(urls))
+
+	for _, raw := range urls {
+		u, err := url.Parse(raw)
+		if err != nil {
+			res = append(res, redactedParameterValue)
+
+			continue
+		}
+
+		if u.User != nil {
+			u.User = url.User("redacted")
+		}
+
+		if u.RawQuery != "" {
+			u.RawQuery = "redacted"
+		}
+
+		res = append(res, u.String())
+	}
+
+	return res
+}
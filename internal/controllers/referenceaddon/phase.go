@@ -2,66 +2,200 @@ package referenceaddon
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
 
 	refv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type Phase interface {
+	Name() string
 	Execute(ctx context.Context, req PhaseRequest) PhaseResult
 }
 
 type PhaseRequest struct {
 	Addon  refv1alpha1.ReferenceAddon
 	Params PhaseRequestParameters
+	// Unchanged is true when neither the addon's generation nor its
+	// effective parameters changed since they were last fully
+	// reconciled. Phases may use it to skip expensive work.
+	Unchanged bool
 }
 
 func NewPhaseRequestParameters(opts ...PhaseRequestParametersOption) PhaseRequestParameters {
-	var cfg PhaseRequestParametersConfig
+	cfg := PhaseRequestParametersConfig{
+		Values: make(map[string]any),
+	}
 
 	cfg.Option(opts...)
 
 	return PhaseRequestParameters{
-		applyNetworkPolicies: cfg.ApplyNetworkPolicies,
-		enableSmokeTest:      cfg.EnableSmokeTest,
-		size:                 cfg.Size,
+		values:  cfg.Values,
+		sources: cfg.Sources,
 	}
 }
 
+// PhaseRequestParameters holds the parsed addon parameters keyed
+// by their ParameterDefinition key.
 type PhaseRequestParameters struct {
-	applyNetworkPolicies *bool
-	enableSmokeTest      *bool
-	size                 *string
+	values  map[string]any
+	sources map[string]string
 }
 
-func (p *PhaseRequestParameters) GetSize() (string, bool) {
-	if p.size == nil {
-		return "", false
+// ParameterSourceSpec is reported as the source of parameters
+// overridden by the ReferenceAddon spec.
+const ParameterSourceSpec = "spec"
+
+// OverrideWithSpec returns a copy of the parameters where every field
+// set on the given spec replaces the value sourced from the addon
+// parameters Secret. Spec fields are validated by the given registry;
+// invalid fields do not replace the sourced value and are reported as
+// *ParameterErrors.
+func (p *PhaseRequestParameters) OverrideWithSpec(
+	spec refv1alpha1.ReferenceAddonSpec,
+	reg *ParameterRegistry,
+) (PhaseRequestParameters, error) {
+	res := NewPhaseRequestParameters()
+
+	for key, val := range p.values {
+		res.values[key] = val
+	}
+
+	for key, src := range p.sources {
+		res.setSource(key, src)
+	}
+
+	var errs []error
+
+	override := func(key string, val any) {
+		if err := reg.Validate(key, val); err != nil {
+			errs = append(errs, err)
+
+			return
+		}
+
+		res.values[key] = val
+		res.setSource(key, ParameterSourceSpec)
+	}
+
+	if spec.ApplyNetworkPolicies != nil {
+		override(applyNetworkPoliciesID, *spec.ApplyNetworkPolicies)
+	}
+
+	if spec.EnableSmokeTest != nil {
+		override(enableSmokeTestID, *spec.EnableSmokeTest)
+	}
+
+	if spec.Size != nil {
+		override(sizeParameterID, *spec.Size)
+	}
+
+	if spec.CustomSize != nil {
+		if raw, err := json.Marshal(spec.CustomSize); err != nil {
+			errs = append(errs, &ParameterError{
+				Key: customSizeParameterID,
+				Err: fmt.Errorf("encoding value: %w", err),
+			})
+		} else {
+			override(customSizeParameterID, json.RawMessage(raw))
+		}
+	}
+
+	if spec.SampleURLs != nil {
+		override(sampleURLsParameterID, append([]string{}, spec.SampleURLs...))
+	}
+
+	if spec.NetworkPolicies != nil {
+		override(networkPoliciesID, append([]string{}, spec.NetworkPolicies...))
 	}
 
-	return *p.size, true
+	if spec.AllowedCIDRs != nil {
+		override(allowedCIDRsID, append([]string{}, spec.AllowedCIDRs...))
+	}
+
+	return res, errors.Join(errs...)
 }
 
-func (p *PhaseRequestParameters) GetEnableSmokeTest() (bool, bool) {
-	if p.enableSmokeTest == nil {
-		return false, false
+// Source returns the source the parameter with the given key was
+// retrieved from if it is known.
+func (p *PhaseRequestParameters) Source(key string) (string, bool) {
+	src, ok := p.sources[key]
+
+	return src, ok
+}
+
+func (p *PhaseRequestParameters) setSource(key, src string) {
+	if p.sources == nil {
+		p.sources = make(map[string]string)
 	}
 
-	return *p.enableSmokeTest, true
+	p.sources[key] = src
 }
 
-func (p *PhaseRequestParameters) GetApplyNetworkPolicies() (bool, bool) {
-	if p.applyNetworkPolicies == nil {
-		return false, false
+// Get returns the parsed value of the parameter with the given key
+// and whether it is set.
+func (p *PhaseRequestParameters) Get(key string) (any, bool) {
+	val, ok := p.values[key]
+
+	return val, ok
+}
+
+func getParameter[T any](p *PhaseRequestParameters, key string) (T, bool) {
+	val, ok := p.values[key].(T)
+
+	return val, ok
+}
+
+func (p *PhaseRequestParameters) GetSampleURLs() ([]string, bool) {
+	return getParameter[[]string](p, sampleURLsParameterID)
+}
+
+func (p *PhaseRequestParameters) GetSize() (string, bool) {
+	return getParameter[string](p, sizeParameterID)
+}
+
+// GetCustomSize returns the sample workload sizing requested
+// through the 'customsize' parameter.
+func (p *PhaseRequestParameters) GetCustomSize() (refv1alpha1.WorkloadSize, bool) {
+	raw, ok := getParameter[json.RawMessage](p, customSizeParameterID)
+	if !ok {
+		return refv1alpha1.WorkloadSize{}, false
+	}
+
+	size, err := decodeWorkloadSize(raw)
+	if err != nil {
+		return refv1alpha1.WorkloadSize{}, false
 	}
 
-	return *p.applyNetworkPolicies, true
+	return size, true
+}
+
+func (p *PhaseRequestParameters) GetEnableSmokeTest() (bool, bool) {
+	return getParameter[bool](p, enableSmokeTestID)
+}
+
+func (p *PhaseRequestParameters) GetApplyNetworkPolicies() (bool, bool) {
+	return getParameter[bool](p, applyNetworkPoliciesID)
+}
+
+// GetNetworkPolicies returns the names of the selected
+// NetworkPolicy templates.
+func (p *PhaseRequestParameters) GetNetworkPolicies() ([]string, bool) {
+	return getParameter[[]string](p, networkPoliciesID)
+}
+
+// GetAllowedCIDRs returns the CIDRs substituted
+// into NetworkPolicy templates.
+func (p *PhaseRequestParameters) GetAllowedCIDRs() ([]string, bool) {
+	return getParameter[[]string](p, allowedCIDRsID)
 }
 
 type PhaseRequestParametersConfig struct {
-	ApplyNetworkPolicies *bool
-	EnableSmokeTest      *bool
-	Size                 *string
+	Values  map[string]any
+	Sources map[string]string
 }
 
 func (c *PhaseRequestParametersConfig) Option(opts ...PhaseRequestParametersOption) {
@@ -70,22 +204,52 @@ func (c *PhaseRequestParametersConfig) Option(opts ...PhaseRequestParametersOpti
 	}
 }
 
+// WithParameter sets the parsed value of the parameter with the given key.
+type WithParameter struct {
+	Key   string
+	Value any
+}
+
+func (w WithParameter) ConfigurePhaseRequestParameters(c *PhaseRequestParametersConfig) {
+	c.Values[w.Key] = w.Value
+}
+
+// WithParameterSource records the source of the parameter with the given key.
+type WithParameterSource struct {
+	Key    string
+	Source string
+}
+
+func (w WithParameterSource) ConfigurePhaseRequestParameters(c *PhaseRequestParametersConfig) {
+	if c.Sources == nil {
+		c.Sources = make(map[string]string)
+	}
+
+	c.Sources[w.Key] = w.Source
+}
+
 type WithApplyNetworkPolicies struct{ Value *bool }
 
 func (w WithApplyNetworkPolicies) ConfigurePhaseRequestParameters(c *PhaseRequestParametersConfig) {
-	c.ApplyNetworkPolicies = w.Value
+	if w.Value != nil {
+		c.Values[applyNetworkPoliciesID] = *w.Value
+	}
 }
 
 type WithEnableSmokeTest struct{ Value *bool }
 
 func (w WithEnableSmokeTest) ConfigurePhaseRequestParameters(c *PhaseRequestParametersConfig) {
-	c.EnableSmokeTest = w.Value
+	if w.Value != nil {
+		c.Values[enableSmokeTestID] = *w.Value
+	}
 }
 
 type WithSize struct{ Value *string }
 
 func (w WithSize) ConfigurePhaseRequestParameters(c *PhaseRequestParametersConfig) {
-	c.Size = w.Value
+	if w.Value != nil {
+		c.Values[sizeParameterID] = *w.Value
+	}
 }
 
 type PhaseRequestParametersOption interface {
@@ -161,6 +325,29 @@ func (r PhaseResult) Conditions() []metav1.Condition {
 	return r.cfg.Conditions
 }
 
+// UninstallReport returns the teardown report attached to the
+// result or nil if the phase does not produce one.
+func (r PhaseResult) UninstallReport() *refv1alpha1.UninstallReport {
+	return r.cfg.UninstallReport
+}
+
+// UninstallSchedule returns the time a signaled uninstall is scheduled
+// to proceed. The second return value is false if the phase does not
+// manage the uninstall schedule. A nil time clears the schedule.
+func (r PhaseResult) UninstallSchedule() (*metav1.Time, bool) {
+	if r.cfg.UninstallSchedule == nil {
+		return nil, false
+	}
+
+	return r.cfg.UninstallSchedule.At, true
+}
+
+// RequeueAfter returns the duration after which the phase
+// should be executed again or zero if no requeue is needed.
+func (r PhaseResult) RequeueAfter() time.Duration {
+	return r.cfg.RequeueAfter
+}
+
 type PhaseStatus string
 
 func (s PhaseStatus) String() string {
@@ -175,7 +362,10 @@ const (
 )
 
 type PhaseResultConfig struct {
-	Conditions []metav1.Condition
+	Conditions        []metav1.Condition
+	UninstallReport   *refv1alpha1.UninstallReport
+	UninstallSchedule *WithUninstallSchedule
+	RequeueAfter      time.Duration
 }
 
 func (c *PhaseResultConfig) Option(opts ...PhaseResultOption) {
@@ -193,3 +383,21 @@ type WithConditions []metav1.Condition
 func (w WithConditions) ConfigurePhaseResult(c *PhaseResultConfig) {
 	c.Conditions = append(c.Conditions, w...)
 }
+
+type WithUninstallReport struct{ Report *refv1alpha1.UninstallReport }
+
+func (w WithUninstallReport) ConfigurePhaseResult(c *PhaseResultConfig) {
+	c.UninstallReport = w.Report
+}
+
+type WithUninstallSchedule struct{ At *metav1.Time }
+
+func (w WithUninstallSchedule) ConfigurePhaseResult(c *PhaseResultConfig) {
+	c.UninstallSchedule = &w
+}
+
+type WithRequeueAfter time.Duration
+
+func (w WithRequeueAfter) ConfigurePhaseResult(c *PhaseResultConfig) {
+	c.RequeueAfter = time.Duration(w)
+}
@@ -3,17 +3,34 @@ package referenceaddon
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
+	refv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
+	"github.com/openshift/reference-addon/internal/controllers"
 	"go.uber.org/multierr"
+	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// ManagedByLabel is set on every NetworkPolicy applied by the
+	// reference-addon to the name of the operator.
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	// OwnerLabel is set on every NetworkPolicy applied by the
+	// reference-addon to the name of the owning ReferenceAddon.
+	OwnerLabel = "reference.addons.managed.openshift.io/owner"
+)
+
 func NewPhaseApplyNetworkPolicies(client NetworkPolicyClient, opts ...PhaseApplyNetworkPoliciesOption) *PhaseApplyNetworkPolicies {
 	var cfg PhaseApplyNetworkPoliciesConfig
 
@@ -33,50 +50,307 @@ type PhaseApplyNetworkPolicies struct {
 	client NetworkPolicyClient
 }
 
+func (p *PhaseApplyNetworkPolicies) Name() string {
+	return "applyNetworkPolicies"
+}
+
 func (p *PhaseApplyNetworkPolicies) Execute(ctx context.Context, req PhaseRequest) PhaseResult {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	applyNetworkPolicies, ok := req.Params.GetApplyNetworkPolicies()
 	if !ok {
-		return PhaseResultSuccess()
+		return PhaseResultSuccess(
+			WithConditions{
+				newNetworkPoliciesAppliedCondition(
+					refv1alpha1.NetworkPoliciesAppliedReasonNotConfigured,
+					"'ApplyNetworkPolicies' parameter not set",
+				),
+			},
+		)
 	}
 
 	if !applyNetworkPolicies {
-		return p.ensureNetworkPoliciesRemoved(ctx)
+		return p.ensureNetworkPoliciesRemoved(ctx, req)
 	}
 
 	return p.ensureNetworkPoliciesApplied(ctx, req)
 }
 
-func (p *PhaseApplyNetworkPolicies) ensureNetworkPoliciesRemoved(ctx context.Context) PhaseResult {
-	p.cfg.Log.Info("removing NetworkPolicies", "count", len(p.cfg.Policies))
+func (p *PhaseApplyNetworkPolicies) ensureNetworkPoliciesRemoved(ctx context.Context, req PhaseRequest) PhaseResult {
+	// With no template selected every template is rendered as unselected
+	// so that policies applied for any previous selection are removed.
+	_, templated, err := RenderNetworkPolicies(p.cfg.Templates, nil, p.templateData(req.Params))
+	if err != nil {
+		return p.invalidTemplates(req, err)
+	}
+
+	policies := append(append([]netv1.NetworkPolicy{}, p.cfg.Policies...), templated...)
+
+	p.cfg.Log.Info("removing NetworkPolicies", "count", len(policies))
+
+	if err := p.client.RemoveNetworkPolicies(ctx, policies...); err != nil {
+		cond := newNetworkPoliciesAppliedCondition(
+			refv1alpha1.NetworkPoliciesAppliedReasonRemoveFailed,
+			err.Error(),
+		)
+
+		recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeWarning, EventReasonNetworkPoliciesFailed)
+
+		return PhaseResultError(
+			fmt.Errorf("deleting NetworkPolicies: %w", err),
+			WithConditions{cond},
+		)
+	}
 
-	if err := p.client.RemoveNetworkPolicies(ctx, p.cfg.Policies...); err != nil {
-		return PhaseResultError(fmt.Errorf("deleting NetworkPolicies: %w", err))
+	// Labeled policies which are no longer rendered, e.g. after
+	// being renamed, are not known by name and must be pruned.
+	if err := p.prune(ctx, req, nil); err != nil {
+		return p.pruneFailed(req, err)
 	}
 
-	p.cfg.Log.Info("successfully removed NetworkPolicies", "count", len(p.cfg.Policies))
+	p.cfg.Log.Info("successfully removed NetworkPolicies", "count", len(policies))
 
-	return PhaseResultSuccess()
+	cond := newNetworkPoliciesAppliedCondition(
+		refv1alpha1.NetworkPoliciesAppliedReasonRemoved,
+		fmt.Sprintf("removed %d NetworkPolicies", len(policies)),
+	)
+
+	recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeNormal, EventReasonNetworkPoliciesRemoved)
+
+	return PhaseResultSuccess(WithConditions{cond})
 }
 
 func (p *PhaseApplyNetworkPolicies) ensureNetworkPoliciesApplied(ctx context.Context, req PhaseRequest) PhaseResult {
-	p.cfg.Log.Info("applying NetworkPolicies", "count", len(p.cfg.Policies))
+	selected, _ := req.Params.GetNetworkPolicies()
 
-	if err := p.client.ApplyNetworkPolicies(ctx, WithOwner{Owner: &req.Addon}, WithPolicies(p.cfg.Policies)); err != nil {
-		return PhaseResultError(fmt.Errorf("applying NetworkPolicies: %w", err))
+	templated, _, err := RenderNetworkPolicies(p.cfg.Templates, selected, p.templateData(req.Params))
+	if err != nil {
+		return p.invalidTemplates(req, err)
 	}
 
-	p.cfg.Log.Info("successfully applied NetworkPolicies", "count", len(p.cfg.Policies))
+	policies := append(append([]netv1.NetworkPolicy{}, p.cfg.Policies...), templated...)
+	policies = p.labelPolicies(policies, req.Addon)
+
+	p.cfg.Log.Info("applying NetworkPolicies", "count", len(policies))
+
+	drifted, err := p.client.ApplyNetworkPolicies(
+		ctx,
+		WithOwner{Owner: &req.Addon},
+		WithPolicies(policies),
+		WithNetworkPolicyDriftReportOnly(p.cfg.DriftReportOnly),
+	)
+
+	// Drift is reported even if applying other policies failed
+	// since every occurrence is evidence of a manual change.
+	p.reportDrift(req, drifted)
+
+	if controllers.IsApplyConflict(err) {
+		cond := newNetworkPoliciesAppliedCondition(
+			refv1alpha1.NetworkPoliciesAppliedReasonConflict,
+			err.Error(),
+		)
+
+		recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeWarning, EventReasonNetworkPoliciesFailed)
+
+		return PhaseResultFailure(cond.Message, WithConditions{cond})
+	} else if err != nil {
+		cond := newNetworkPoliciesAppliedCondition(
+			refv1alpha1.NetworkPoliciesAppliedReasonApplyFailed,
+			err.Error(),
+		)
+
+		recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeWarning, EventReasonNetworkPoliciesFailed)
+
+		return PhaseResultError(
+			fmt.Errorf("applying NetworkPolicies: %w", err),
+			WithConditions{cond},
+		)
+	}
 
-	return PhaseResultSuccess()
+	// Any labeled policy which is not desired anymore, e.g. because its
+	// template was deselected or renamed, is pruned.
+	if err := p.prune(ctx, req, policies); err != nil {
+		return p.pruneFailed(req, err)
+	}
+
+	p.cfg.Log.Info("successfully applied NetworkPolicies", "count", len(policies))
+
+	if len(drifted) > 0 {
+		return PhaseResultSuccess(WithConditions{p.driftCondition(len(policies), drifted)})
+	}
+
+	cond := newNetworkPoliciesAppliedCondition(
+		refv1alpha1.NetworkPoliciesAppliedReasonApplied,
+		fmt.Sprintf("applied %d NetworkPolicies", len(policies)),
+	)
+
+	recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeNormal, EventReasonNetworkPoliciesApplied)
+
+	return PhaseResultSuccess(WithConditions{cond})
+}
+
+// reportDrift records every drifted NetworkPolicy in the drift
+// metric and emits an event naming the fields which were changed.
+func (p *PhaseApplyNetworkPolicies) reportDrift(req PhaseRequest, drifted []NetworkPolicyDrift) {
+	for _, drift := range drifted {
+		p.cfg.Log.Info("NetworkPolicy drifted from desired spec",
+			"name", drift.Name,
+			"namespace", drift.Namespace,
+			"fields", drift.Fields,
+			"reverted", !p.cfg.DriftReportOnly,
+		)
+
+		p.cfg.DriftRecorder.RecordNetworkPolicyDrift(drift.Namespace, drift.Name)
+
+		action := "reverted"
+		if p.cfg.DriftReportOnly {
+			action = "not reverted in report-only mode"
+		}
+
+		// Drift is reported on every occurrence rather than only on
+		// transitions since each one is a separate manual edit.
+		p.cfg.Recorder.Eventf(
+			&req.Addon, corev1.EventTypeWarning, EventReasonNetworkPolicyDrifted,
+			"NetworkPolicy %q drifted from the desired spec in [%s]: %s",
+			drift.Name, strings.Join(drift.Fields, ", "), action,
+		)
+	}
+}
+
+func (p *PhaseApplyNetworkPolicies) driftCondition(applied int, drifted []NetworkPolicyDrift) metav1.Condition {
+	names := make([]string, 0, len(drifted))
+
+	for _, drift := range drifted {
+		names = append(names, strconv.Quote(drift.Name))
+	}
+
+	if p.cfg.DriftReportOnly {
+		return newNetworkPoliciesAppliedCondition(
+			refv1alpha1.NetworkPoliciesAppliedReasonDriftDetected,
+			fmt.Sprintf("report-only: manual changes to NetworkPolicies %s were not reverted", strings.Join(names, ", ")),
+		)
+	}
+
+	return newNetworkPoliciesAppliedCondition(
+		refv1alpha1.NetworkPoliciesAppliedReasonDriftCorrected,
+		fmt.Sprintf("applied %d NetworkPolicies: reverted manual changes to %s", applied, strings.Join(names, ", ")),
+	)
+}
+
+// prune deletes every NetworkPolicy labeled as managed for the addon
+// which is not part of the desired policies and emits an event for each.
+func (p *PhaseApplyNetworkPolicies) prune(ctx context.Context, req PhaseRequest, desired []netv1.NetworkPolicy) error {
+	pruned, err := p.client.PruneNetworkPolicies(
+		ctx,
+		WithNamespace(p.cfg.Namespace),
+		WithLabelSelector{Selector: labels.SelectorFromSet(p.managedLabels(req.Addon))},
+		WithPolicies(desired),
+		WithNetworkPolicyPruneDryRun(p.cfg.PruneDryRun),
+	)
+
+	for _, policy := range pruned {
+		if p.cfg.PruneDryRun {
+			p.cfg.Log.Info("would prune NetworkPolicy", "name", policy.Name, "namespace", policy.Namespace)
+
+			p.cfg.Recorder.Eventf(
+				&req.Addon, corev1.EventTypeNormal, EventReasonNetworkPolicyPruneDryRun,
+				"dry-run: would prune NetworkPolicy %q", policy.Name,
+			)
+
+			continue
+		}
+
+		p.cfg.Log.Info("pruned NetworkPolicy", "name", policy.Name, "namespace", policy.Namespace)
+
+		p.cfg.Recorder.Eventf(
+			&req.Addon, corev1.EventTypeNormal, EventReasonNetworkPolicyPruned,
+			"pruned NetworkPolicy %q", policy.Name,
+		)
+	}
+
+	return err
+}
+
+func (p *PhaseApplyNetworkPolicies) pruneFailed(req PhaseRequest, err error) PhaseResult {
+	cond := newNetworkPoliciesAppliedCondition(
+		refv1alpha1.NetworkPoliciesAppliedReasonPruneFailed,
+		err.Error(),
+	)
+
+	recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeWarning, EventReasonNetworkPoliciesFailed)
+
+	return PhaseResultError(
+		fmt.Errorf("pruning NetworkPolicies: %w", err),
+		WithConditions{cond},
+	)
+}
+
+// managedLabels returns the labels identifying the NetworkPolicies
+// applied for the given addon.
+func (p *PhaseApplyNetworkPolicies) managedLabels(addon refv1alpha1.ReferenceAddon) labels.Set {
+	return labels.Set{
+		ManagedByLabel: p.cfg.OperatorName,
+		OwnerLabel:     addon.Name,
+	}
+}
+
+func (p *PhaseApplyNetworkPolicies) labelPolicies(policies []netv1.NetworkPolicy, addon refv1alpha1.ReferenceAddon) []netv1.NetworkPolicy {
+	labeled := make([]netv1.NetworkPolicy, 0, len(policies))
+
+	for _, policy := range policies {
+		policy := *policy.DeepCopy()
+		policy.Labels = labels.Merge(policy.Labels, p.managedLabels(addon))
+
+		labeled = append(labeled, policy)
+	}
+
+	return labeled
+}
+
+func (p *PhaseApplyNetworkPolicies) invalidTemplates(req PhaseRequest, err error) PhaseResult {
+	cond := newNetworkPoliciesAppliedCondition(
+		refv1alpha1.NetworkPoliciesAppliedReasonInvalidTemplate,
+		err.Error(),
+	)
+
+	recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeWarning, EventReasonNetworkPoliciesFailed)
+
+	return PhaseResultFailure(cond.Message, WithConditions{cond})
+}
+
+func (p *PhaseApplyNetworkPolicies) templateData(params PhaseRequestParameters) NetworkPolicyTemplateData {
+	cidrs, _ := params.GetAllowedCIDRs()
+
+	return NetworkPolicyTemplateData{
+		Namespace:    p.cfg.Namespace,
+		OperatorName: p.cfg.OperatorName,
+		AllowedCIDRs: cidrs,
+	}
+}
+
+func newNetworkPoliciesAppliedCondition(reason refv1alpha1.NetworkPoliciesAppliedReason, msg string) metav1.Condition {
+	return newCondition(refv1alpha1.ReferenceAddonConditionNetworkPoliciesApplied, reason, msg)
 }
 
 type PhaseApplyNetworkPoliciesConfig struct {
-	Log logr.Logger
+	Log      logr.Logger
+	Recorder record.EventRecorder
 
+	// Policies are applied whenever NetworkPolicies are enabled.
 	Policies []netv1.NetworkPolicy
+	// Templates are rendered into the policies which may be
+	// selected through the 'networkpolicies' parameter.
+	Templates    []NetworkPolicyTemplate
+	Namespace    string
+	OperatorName string
+	// PruneDryRun only reports the NetworkPolicies
+	// which would be pruned without deleting them.
+	PruneDryRun bool
+	// DriftReportOnly only reports NetworkPolicies whose spec
+	// drifted from the desired spec without reverting them.
+	DriftReportOnly bool
+	DriftRecorder   NetworkPolicyDriftRecorder
 }
 
 func (c *PhaseApplyNetworkPoliciesConfig) Option(opts ...PhaseApplyNetworkPoliciesOption) {
@@ -89,28 +363,61 @@ func (c *PhaseApplyNetworkPoliciesConfig) Default() {
 	if c.Log.GetSink() == nil {
 		c.Log = logr.Discard()
 	}
+
+	if c.Recorder == nil {
+		c.Recorder = controllers.NopEventRecorder{}
+	}
+
+	if c.DriftRecorder == nil {
+		c.DriftRecorder = nopNetworkPolicyDriftRecorder{}
+	}
 }
 
 type PhaseApplyNetworkPoliciesOption interface {
 	ConfigurePhaseApplyNetworkPolicies(*PhaseApplyNetworkPoliciesConfig)
 }
 
+type NetworkPolicyDriftRecorder interface {
+	RecordNetworkPolicyDrift(namespace, name string)
+}
+
+type nopNetworkPolicyDriftRecorder struct{}
+
+func (nopNetworkPolicyDriftRecorder) RecordNetworkPolicyDrift(string, string) {}
+
 type NetworkPolicyClient interface {
-	ApplyNetworkPolicies(ctx context.Context, opts ...ApplyNetorkPoliciesOption) error
+	// ApplyNetworkPolicies applies the configured policies and returns
+	// those whose existing spec drifted from the desired spec.
+	ApplyNetworkPolicies(ctx context.Context, opts ...ApplyNetorkPoliciesOption) ([]NetworkPolicyDrift, error)
 	RemoveNetworkPolicies(ctx context.Context, policies ...netv1.NetworkPolicy) error
+	// PruneNetworkPolicies deletes the NetworkPolicies matching the
+	// configured namespace and label selector which are not part of the
+	// configured policies and returns the pruned policies.
+	PruneNetworkPolicies(ctx context.Context, opts ...PruneNetworkPoliciesOption) ([]netv1.NetworkPolicy, error)
+}
+
+// NetworkPolicyDrift describes an existing NetworkPolicy
+// whose spec differs from the desired spec.
+type NetworkPolicyDrift struct {
+	Name      string
+	Namespace string
+	// Fields are the paths of the spec fields which differ.
+	Fields []string
 }
 
 func NewNetworkPolicyClientImpl(client client.Client) *NetworkPolicyClientImpl {
 	return &NetworkPolicyClientImpl{
-		client: client,
+		client:  client,
+		applier: controllers.NewApplier(client),
 	}
 }
 
 type NetworkPolicyClientImpl struct {
-	client client.Client
+	client  client.Client
+	applier *controllers.Applier
 }
 
-func (c *NetworkPolicyClientImpl) ApplyNetworkPolicies(ctx context.Context, opts ...ApplyNetorkPoliciesOption) error {
+func (c *NetworkPolicyClientImpl) ApplyNetworkPolicies(ctx context.Context, opts ...ApplyNetorkPoliciesOption) ([]NetworkPolicyDrift, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -118,26 +425,37 @@ func (c *NetworkPolicyClientImpl) ApplyNetworkPolicies(ctx context.Context, opts
 
 	cfg.Option(opts...)
 
-	var finalErr error
+	var (
+		drifted  []NetworkPolicyDrift
+		finalErr error
+	)
 
 	for _, policy := range cfg.Policies {
 		if cfg.Owner != nil {
 			if err := ctrl.SetControllerReference(cfg.Owner, &policy, c.client.Scheme()); err != nil {
-				return fmt.Errorf("setting controller reference: %w", err)
+				return nil, fmt.Errorf("setting controller reference: %w", err)
 			}
 		}
 
-		if err := c.createOrUpdatePolicy(ctx, policy); err != nil {
-			multierr.AppendInto(&finalErr, fmt.Errorf("creating/updating NetworkPolicy %q: %w", policy.Name, err))
+		drift, err := c.applyPolicy(ctx, policy, cfg.DriftReportOnly)
+		if drift != nil {
+			drifted = append(drifted, *drift)
+		}
+
+		if err != nil {
+			multierr.AppendInto(&finalErr, err)
 		}
 	}
 
-	return finalErr
+	return drifted, finalErr
 }
 
 type ApplyNetorkPoliciesConfig struct {
 	Owner    metav1.Object
 	Policies []netv1.NetworkPolicy
+	// DriftReportOnly skips applying existing policies
+	// whose spec drifted from the desired spec.
+	DriftReportOnly bool
 }
 
 func (c *ApplyNetorkPoliciesConfig) Option(opts ...ApplyNetorkPoliciesOption) {
@@ -150,23 +468,128 @@ type ApplyNetorkPoliciesOption interface {
 	ConfigureApplyNetworkPolicies(c *ApplyNetorkPoliciesConfig)
 }
 
-func (c *NetworkPolicyClientImpl) createOrUpdatePolicy(ctx context.Context, policy netv1.NetworkPolicy) error {
-	actualPolicy := &netv1.NetworkPolicy{
+// applyPolicy applies the given policy and returns a drift if an
+// existing policy of the same name has a semantically different spec.
+func (c *NetworkPolicyClientImpl) applyPolicy(ctx context.Context, policy netv1.NetworkPolicy, reportOnly bool) (*NetworkPolicyDrift, error) {
+	desired := &netv1.NetworkPolicy{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      policy.Name,
-			Namespace: policy.Namespace,
+			Name:            policy.Name,
+			Namespace:       policy.Namespace,
+			Labels:          policy.Labels,
+			Annotations:     policy.Annotations,
+			OwnerReferences: policy.OwnerReferences,
 		},
+		Spec: policy.Spec,
 	}
 
-	_, err := ctrl.CreateOrUpdate(ctx, c.client, actualPolicy, func() error {
-		actualPolicy.Labels = labels.Merge(actualPolicy.Labels, policy.Labels)
-		actualPolicy.OwnerReferences = policy.OwnerReferences
-		actualPolicy.Spec = policy.Spec
+	var actual netv1.NetworkPolicy
 
-		return nil
-	})
+	if err := c.client.Get(ctx, client.ObjectKeyFromObject(desired), &actual); errors.IsNotFound(err) {
+		return nil, c.applier.Apply(ctx, desired)
+	} else if err != nil {
+		return nil, fmt.Errorf("getting NetworkPolicy %q: %w", policy.Name, err)
+	}
 
-	return err
+	fields := diffNetworkPolicySpec(policy.Spec, actual.Spec)
+	if len(fields) == 0 {
+		return nil, c.applier.Apply(ctx, desired)
+	}
+
+	drift := &NetworkPolicyDrift{
+		Name:      policy.Name,
+		Namespace: policy.Namespace,
+		Fields:    fields,
+	}
+
+	if reportOnly {
+		return drift, nil
+	}
+
+	// Manual edits make the editing field manager the owner of the changed
+	// fields which server-side apply can neither remove nor reset without
+	// conflicting, so the drifted spec is replaced before applying.
+	actual.Spec = policy.Spec
+
+	if err := c.client.Update(ctx, &actual, client.FieldOwner(controllers.DefaultFieldManager)); err != nil {
+		return drift, fmt.Errorf("reverting NetworkPolicy %q: %w", policy.Name, err)
+	}
+
+	return drift, c.applier.Apply(ctx, desired)
+}
+
+// diffNetworkPolicySpec returns the paths of the fields which semantically
+// differ between the desired and actual spec. Values defaulted by the API
+// server are defaulted on both specs so that they do not count as drift.
+func diffNetworkPolicySpec(desired, actual netv1.NetworkPolicySpec) []string {
+	desired = defaultNetworkPolicySpec(desired)
+	actual = defaultNetworkPolicySpec(actual)
+
+	var fields []string
+
+	if !equality.Semantic.DeepEqual(desired.PodSelector, actual.PodSelector) {
+		fields = append(fields, "spec.podSelector")
+	}
+
+	fields = append(fields, diffNetworkPolicyRules("spec.ingress", desired.Ingress, actual.Ingress)...)
+	fields = append(fields, diffNetworkPolicyRules("spec.egress", desired.Egress, actual.Egress)...)
+
+	if !sets.New(desired.PolicyTypes...).Equal(sets.New(actual.PolicyTypes...)) {
+		fields = append(fields, "spec.policyTypes")
+	}
+
+	return fields
+}
+
+// diffNetworkPolicyRules compares ingress or egress rules pairwise and
+// returns the path of each differing rule. Rules which were added or
+// removed shift all following rules so the whole list is reported.
+func diffNetworkPolicyRules[T any](path string, desired, actual []T) []string {
+	if len(desired) != len(actual) {
+		return []string{path}
+	}
+
+	var fields []string
+
+	for i := range desired {
+		if !equality.Semantic.DeepEqual(desired[i], actual[i]) {
+			fields = append(fields, fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+
+	return fields
+}
+
+// defaultNetworkPolicySpec returns a copy of the given spec with the
+// defaults the API server sets on NetworkPolicies.
+func defaultNetworkPolicySpec(spec netv1.NetworkPolicySpec) netv1.NetworkPolicySpec {
+	spec = *spec.DeepCopy()
+
+	if len(spec.PolicyTypes) == 0 {
+		spec.PolicyTypes = []netv1.PolicyType{netv1.PolicyTypeIngress}
+
+		if len(spec.Egress) > 0 {
+			spec.PolicyTypes = append(spec.PolicyTypes, netv1.PolicyTypeEgress)
+		}
+	}
+
+	defaultPorts := func(ports []netv1.NetworkPolicyPort) {
+		for i := range ports {
+			if ports[i].Protocol == nil {
+				protocol := corev1.ProtocolTCP
+				ports[i].Protocol = &protocol
+			}
+		}
+	}
+
+	for _, rule := range spec.Ingress {
+		defaultPorts(rule.Ports)
+	}
+
+	for _, rule := range spec.Egress {
+		defaultPorts(rule.Ports)
+	}
+
+	return spec
 }
 
 func (c *NetworkPolicyClientImpl) RemoveNetworkPolicies(ctx context.Context, policies ...netv1.NetworkPolicy) error {
@@ -183,3 +606,76 @@ func (c *NetworkPolicyClientImpl) RemoveNetworkPolicies(ctx context.Context, pol
 
 	return finalErr
 }
+
+func (c *NetworkPolicyClientImpl) PruneNetworkPolicies(ctx context.Context, opts ...PruneNetworkPoliciesOption) ([]netv1.NetworkPolicy, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var cfg PruneNetworkPoliciesConfig
+
+	cfg.Option(opts...)
+
+	if cfg.Selector == nil || cfg.Selector.Empty() {
+		return nil, fmt.Errorf("refusing to prune NetworkPolicies without a label selector")
+	}
+
+	var list netv1.NetworkPolicyList
+
+	if err := c.client.List(ctx, &list,
+		client.InNamespace(cfg.Namespace),
+		client.MatchingLabelsSelector{Selector: cfg.Selector},
+	); err != nil {
+		return nil, fmt.Errorf("listing NetworkPolicies: %w", err)
+	}
+
+	desired := make(map[client.ObjectKey]struct{}, len(cfg.Policies))
+
+	for _, policy := range cfg.Policies {
+		desired[client.ObjectKeyFromObject(&policy)] = struct{}{}
+	}
+
+	var (
+		pruned   []netv1.NetworkPolicy
+		finalErr error
+	)
+
+	for _, policy := range list.Items {
+		if _, ok := desired[client.ObjectKeyFromObject(&policy)]; ok {
+			continue
+		}
+
+		if cfg.DryRun {
+			pruned = append(pruned, policy)
+
+			continue
+		}
+
+		if err := c.client.Delete(ctx, &policy); err != nil && !errors.IsNotFound(err) {
+			multierr.AppendInto(&finalErr, fmt.Errorf("deleting NetworkPolicy %q: %w", policy.Name, err))
+
+			continue
+		}
+
+		pruned = append(pruned, policy)
+	}
+
+	return pruned, finalErr
+}
+
+type PruneNetworkPoliciesConfig struct {
+	Namespace string
+	Selector  labels.Selector
+	// Policies are the desired policies which are never pruned.
+	Policies []netv1.NetworkPolicy
+	DryRun   bool
+}
+
+func (c *PruneNetworkPoliciesConfig) Option(opts ...PruneNetworkPoliciesOption) {
+	for _, opt := range opts {
+		opt.ConfigurePruneNetworkPolicies(c)
+	}
+}
+
+type PruneNetworkPoliciesOption interface {
+	ConfigurePruneNetworkPolicies(c *PruneNetworkPoliciesConfig)
+}
@@ -2,14 +2,22 @@ package referenceaddon
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	refv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
 	"github.com/openshift/reference-addon/internal/controllers"
+	internaltesting "github.com/openshift/reference-addon/internal/testing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -26,12 +34,15 @@ func TestPhaseApplyNetworkPolicies(t *testing.T) {
 	for name, tc := range map[string]struct {
 		ApplyNetworkPolicy *bool
 		Policies           []netv1.NetworkPolicy
+		ExpectedReason     refv1alpha1.NetworkPoliciesAppliedReason
 	}{
 		"applyNetworkPolicies unset": {
 			ApplyNetworkPolicy: nil,
+			ExpectedReason:     refv1alpha1.NetworkPoliciesAppliedReasonNotConfigured,
 		},
 		"applyNetworkPolicies false/no NetworkPolicies": {
 			ApplyNetworkPolicy: controllers.BoolPtr(false),
+			ExpectedReason:     refv1alpha1.NetworkPoliciesAppliedReasonRemoved,
 		},
 		"applyNetworkPolicies false/with NetworkPolicies": {
 			ApplyNetworkPolicy: controllers.BoolPtr(false),
@@ -43,9 +54,11 @@ func TestPhaseApplyNetworkPolicies(t *testing.T) {
 					},
 				},
 			},
+			ExpectedReason: refv1alpha1.NetworkPoliciesAppliedReasonRemoved,
 		},
 		"applyNetworkPolicies true/no NetworkPolicies": {
 			ApplyNetworkPolicy: controllers.BoolPtr(false),
+			ExpectedReason:     refv1alpha1.NetworkPoliciesAppliedReasonRemoved,
 		},
 		"applyNetworkPolicies true/with NetworkPolicies": {
 			ApplyNetworkPolicy: controllers.BoolPtr(true),
@@ -57,6 +70,7 @@ func TestPhaseApplyNetworkPolicies(t *testing.T) {
 					},
 				},
 			},
+			ExpectedReason: refv1alpha1.NetworkPoliciesAppliedReasonApplied,
 		},
 	} {
 		tc := tc
@@ -66,14 +80,27 @@ func TestPhaseApplyNetworkPolicies(t *testing.T) {
 
 			var m NetworkPolicyClientMock
 
+			p := NewPhaseApplyNetworkPolicies(
+				&m,
+				WithPolicies(tc.Policies),
+			)
+
 			switch val := tc.ApplyNetworkPolicy; {
 			case val == nil:
 			case *val == true:
-				argList := []interface{}{mock.Anything, mock.Anything, WithPolicies(tc.Policies)}
+				argList := []interface{}{
+					mock.Anything,
+					mock.Anything,
+					WithPolicies(p.labelPolicies(tc.Policies, refv1alpha1.ReferenceAddon{})),
+					mock.Anything,
+				}
 
 				m.
 					On("ApplyNetworkPolicies", argList...).
-					Return(nil)
+					Return(nil, nil)
+				m.
+					On("PruneNetworkPolicies", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, nil)
 			case *val == false:
 				argList := make([]interface{}, 0, 1+len(tc.Policies))
 
@@ -86,32 +113,474 @@ func TestPhaseApplyNetworkPolicies(t *testing.T) {
 				m.
 					On("RemoveNetworkPolicies", argList...).
 					Return(nil)
+				m.
+					On("PruneNetworkPolicies", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, nil)
 			}
 
+			res := p.Execute(context.Background(), PhaseRequest{
+				Params: NewPhaseRequestParameters(
+					WithApplyNetworkPolicies{Value: tc.ApplyNetworkPolicy},
+				),
+			})
+			require.NoError(t, res.Error())
+
+			assert.Equal(t, PhaseStatusSuccess, res.Status())
+			assertCondition(t, res.Conditions(), refv1alpha1.ReferenceAddonConditionNetworkPoliciesApplied, tc.ExpectedReason)
+
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPhaseApplyNetworkPolicies_Events(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Conditions     []metav1.Condition
+		ExpectedEvents []string
+	}{
+		"first apply": {
+			ExpectedEvents: []string{
+				"Normal NetworkPoliciesApplied applied 0 NetworkPolicies",
+			},
+		},
+		"steady state": {
+			Conditions: []metav1.Condition{
+				newNetworkPoliciesAppliedCondition(
+					refv1alpha1.NetworkPoliciesAppliedReasonApplied,
+					"applied 0 NetworkPolicies",
+				),
+			},
+		},
+		"previously removed": {
+			Conditions: []metav1.Condition{
+				newNetworkPoliciesAppliedCondition(
+					refv1alpha1.NetworkPoliciesAppliedReasonRemoved,
+					"removed 0 NetworkPolicies",
+				),
+			},
+			ExpectedEvents: []string{
+				"Normal NetworkPoliciesApplied applied 0 NetworkPolicies",
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var m NetworkPolicyClientMock
+			m.
+				On("ApplyNetworkPolicies", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(nil, nil)
+			m.
+				On("PruneNetworkPolicies", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(nil, nil)
+
+			recorder := record.NewFakeRecorder(10)
+
 			p := NewPhaseApplyNetworkPolicies(
 				&m,
-				WithPolicies(tc.Policies),
+				WithEventRecorder{Recorder: recorder},
 			)
 
+			var addon refv1alpha1.ReferenceAddon
+			addon.Status.Conditions = tc.Conditions
+
 			res := p.Execute(context.Background(), PhaseRequest{
+				Addon: addon,
 				Params: NewPhaseRequestParameters(
-					WithApplyNetworkPolicies{Value: tc.ApplyNetworkPolicy},
+					WithApplyNetworkPolicies{Value: controllers.BoolPtr(true)},
 				),
 			})
 			require.NoError(t, res.Error())
 
-			assert.Equal(t, PhaseStatusSuccess, res.Status())
+			close(recorder.Events)
+
+			var events []string
+
+			for e := range recorder.Events {
+				events = append(events, e)
+			}
+
+			assert.Equal(t, tc.ExpectedEvents, events)
+		})
+	}
+}
+
+func TestPhaseApplyNetworkPolicies_Templates(t *testing.T) {
+	t.Parallel()
+
+	templates := DefaultNetworkPolicyTemplates()
+
+	for name, tc := range map[string]struct {
+		Params         PhaseRequestParameters
+		ExpectApplied  []string
+		ExpectRemoved  []string
+		ExpectedStatus PhaseStatus
+		ExpectedReason refv1alpha1.NetworkPoliciesAppliedReason
+	}{
+		"selection applied/rest pruned": {
+			Params: NewPhaseRequestParameters(
+				WithApplyNetworkPolicies{Value: controllers.BoolPtr(true)},
+				WithNetworkPolicies{"allow-dns", "deny-all-ingress"},
+			),
+			ExpectApplied:  []string{"test-allow-dns", "test-ingress"},
+			ExpectedStatus: PhaseStatusSuccess,
+			ExpectedReason: refv1alpha1.NetworkPoliciesAppliedReasonApplied,
+		},
+		"disabled/all removed": {
+			Params: NewPhaseRequestParameters(
+				WithApplyNetworkPolicies{Value: controllers.BoolPtr(false)},
+				WithNetworkPolicies{"allow-dns"},
+			),
+			ExpectRemoved: []string{
+				"test-allow-dns",
+				"test-allow-from-cidrs",
+				"test-allow-from-monitoring",
+				"test-allow-from-same-namespace",
+				"test-ingress",
+				"test-restrict-egress",
+			},
+			ExpectedStatus: PhaseStatusSuccess,
+			ExpectedReason: refv1alpha1.NetworkPoliciesAppliedReasonRemoved,
+		},
+		"unknown template": {
+			Params: NewPhaseRequestParameters(
+				WithApplyNetworkPolicies{Value: controllers.BoolPtr(true)},
+				WithNetworkPolicies{"unknown"},
+			),
+			ExpectedStatus: PhaseStatusFailure,
+			ExpectedReason: refv1alpha1.NetworkPoliciesAppliedReasonInvalidTemplate,
+		},
+		"allow-from-cidrs without CIDRs": {
+			Params: NewPhaseRequestParameters(
+				WithApplyNetworkPolicies{Value: controllers.BoolPtr(true)},
+				WithNetworkPolicies{AllowFromCIDRsTemplateName},
+			),
+			ExpectedStatus: PhaseStatusFailure,
+			ExpectedReason: refv1alpha1.NetworkPoliciesAppliedReasonInvalidTemplate,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var c recordingNetworkPolicyClient
+
+			p := NewPhaseApplyNetworkPolicies(
+				&c,
+				WithOperatorName("test"),
+				WithAddonNamespace("test-namespace"),
+				WithNetworkPolicyTemplates(templates),
+			)
+
+			res := p.Execute(context.Background(), PhaseRequest{Params: tc.Params})
+
+			assert.Equal(t, tc.ExpectedStatus, res.Status())
+			assertCondition(t, res.Conditions(), refv1alpha1.ReferenceAddonConditionNetworkPoliciesApplied, tc.ExpectedReason)
+
+			assert.Equal(t, tc.ExpectApplied, c.Applied)
+			assert.Equal(t, tc.ExpectRemoved, c.Removed)
+		})
+	}
+}
+
+func TestPhaseApplyNetworkPolicies_Prune(t *testing.T) {
+	t.Parallel()
+
+	stale := netv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-stale",
+			Namespace: "test-namespace",
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		DryRun         bool
+		PruneErr       error
+		ExpectedStatus PhaseStatus
+		ExpectedReason refv1alpha1.NetworkPoliciesAppliedReason
+		ExpectedEvents []string
+	}{
+		"pruned": {
+			ExpectedStatus: PhaseStatusSuccess,
+			ExpectedReason: refv1alpha1.NetworkPoliciesAppliedReasonApplied,
+			ExpectedEvents: []string{
+				`Normal NetworkPolicyPruned pruned NetworkPolicy "test-stale"`,
+				"Normal NetworkPoliciesApplied applied 0 NetworkPolicies",
+			},
+		},
+		"dry run": {
+			DryRun:         true,
+			ExpectedStatus: PhaseStatusSuccess,
+			ExpectedReason: refv1alpha1.NetworkPoliciesAppliedReasonApplied,
+			ExpectedEvents: []string{
+				`Normal NetworkPolicyPruneDryRun dry-run: would prune NetworkPolicy "test-stale"`,
+				"Normal NetworkPoliciesApplied applied 0 NetworkPolicies",
+			},
+		},
+		"prune failed": {
+			PruneErr:       errors.New("test error"),
+			ExpectedStatus: PhaseStatusError,
+			ExpectedReason: refv1alpha1.NetworkPoliciesAppliedReasonPruneFailed,
+			ExpectedEvents: []string{
+				`Normal NetworkPolicyPruned pruned NetworkPolicy "test-stale"`,
+				"Warning NetworkPoliciesFailed test error",
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var m NetworkPolicyClientMock
+			m.
+				On("ApplyNetworkPolicies", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(nil, nil)
+			m.
+				On("PruneNetworkPolicies",
+					mock.Anything,
+					WithNamespace("test-namespace"),
+					WithLabelSelector{Selector: labels.SelectorFromSet(labels.Set{
+						ManagedByLabel: "test",
+						OwnerLabel:     "test-addon",
+					})},
+					mock.Anything,
+					WithNetworkPolicyPruneDryRun(tc.DryRun),
+				).
+				Return([]netv1.NetworkPolicy{stale}, tc.PruneErr)
+
+			recorder := record.NewFakeRecorder(10)
+
+			p := NewPhaseApplyNetworkPolicies(
+				&m,
+				WithEventRecorder{Recorder: recorder},
+				WithOperatorName("test"),
+				WithAddonNamespace("test-namespace"),
+				WithNetworkPolicyPruneDryRun(tc.DryRun),
+			)
+
+			var addon refv1alpha1.ReferenceAddon
+			addon.Name = "test-addon"
+
+			res := p.Execute(context.Background(), PhaseRequest{
+				Addon: addon,
+				Params: NewPhaseRequestParameters(
+					WithApplyNetworkPolicies{Value: controllers.BoolPtr(true)},
+				),
+			})
+
+			assert.Equal(t, tc.ExpectedStatus, res.Status())
+			assertCondition(t, res.Conditions(), refv1alpha1.ReferenceAddonConditionNetworkPoliciesApplied, tc.ExpectedReason)
+
+			close(recorder.Events)
+
+			var events []string
+
+			for e := range recorder.Events {
+				events = append(events, e)
+			}
+
+			assert.Equal(t, tc.ExpectedEvents, events)
+
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPhaseApplyNetworkPolicies_Conflict(t *testing.T) {
+	t.Parallel()
+
+	var m NetworkPolicyClientMock
+	m.
+		On("ApplyNetworkPolicies", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, &controllers.ApplyConflictError{
+			Kind:     "NetworkPolicy",
+			Name:     "test-ingress",
+			Managers: []string{"kubectl-edit"},
+			Fields:   []string{".spec.policyTypes"},
+		})
+
+	recorder := record.NewFakeRecorder(10)
+
+	p := NewPhaseApplyNetworkPolicies(
+		&m,
+		WithEventRecorder{Recorder: recorder},
+	)
+
+	res := p.Execute(context.Background(), PhaseRequest{
+		Params: NewPhaseRequestParameters(
+			WithApplyNetworkPolicies{Value: controllers.BoolPtr(true)},
+		),
+	})
+
+	assert.Equal(t, PhaseStatusFailure, res.Status())
+	assertCondition(t, res.Conditions(), refv1alpha1.ReferenceAddonConditionNetworkPoliciesApplied, refv1alpha1.NetworkPoliciesAppliedReasonConflict)
+
+	close(recorder.Events)
+
+	var events []string
+
+	for e := range recorder.Events {
+		events = append(events, e)
+	}
+
+	assert.Equal(t, []string{
+		`Warning NetworkPoliciesFailed applying NetworkPolicy "test-ingress": conflict with field manager "kubectl-edit" on [.spec.policyTypes]`,
+	}, events)
+
+	m.AssertExpectations(t)
+}
+
+func TestPhaseApplyNetworkPolicies_Drift(t *testing.T) {
+	t.Parallel()
+
+	drifted := []NetworkPolicyDrift{
+		{
+			Name:      "test-ingress",
+			Namespace: "test-namespace",
+			Fields:    []string{"spec.ingress[0]", "spec.policyTypes"},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		ReportOnly      bool
+		ApplyErr        error
+		ExpectedStatus  PhaseStatus
+		ExpectedReason  refv1alpha1.NetworkPoliciesAppliedReason
+		ExpectedMessage string
+		ExpectedEvents  []string
+	}{
+		"drift reverted": {
+			ExpectedStatus:  PhaseStatusSuccess,
+			ExpectedReason:  refv1alpha1.NetworkPoliciesAppliedReasonDriftCorrected,
+			ExpectedMessage: `applied 0 NetworkPolicies: reverted manual changes to "test-ingress"`,
+			ExpectedEvents: []string{
+				`Warning NetworkPolicyDrifted NetworkPolicy "test-ingress" drifted from the desired spec in [spec.ingress[0], spec.policyTypes]: reverted`,
+			},
+		},
+		"report-only": {
+			ReportOnly:      true,
+			ExpectedStatus:  PhaseStatusSuccess,
+			ExpectedReason:  refv1alpha1.NetworkPoliciesAppliedReasonDriftDetected,
+			ExpectedMessage: `report-only: manual changes to NetworkPolicies "test-ingress" were not reverted`,
+			ExpectedEvents: []string{
+				`Warning NetworkPolicyDrifted NetworkPolicy "test-ingress" drifted from the desired spec in [spec.ingress[0], spec.policyTypes]: not reverted in report-only mode`,
+			},
+		},
+		"apply failed": {
+			ApplyErr:        errors.New("test error"),
+			ExpectedStatus:  PhaseStatusError,
+			ExpectedReason:  refv1alpha1.NetworkPoliciesAppliedReasonApplyFailed,
+			ExpectedMessage: "test error",
+			ExpectedEvents: []string{
+				`Warning NetworkPolicyDrifted NetworkPolicy "test-ingress" drifted from the desired spec in [spec.ingress[0], spec.policyTypes]: reverted`,
+				"Warning NetworkPoliciesFailed test error",
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var m NetworkPolicyClientMock
+			m.
+				On("ApplyNetworkPolicies", mock.Anything, mock.Anything, mock.Anything, WithNetworkPolicyDriftReportOnly(tc.ReportOnly)).
+				Return(drifted, tc.ApplyErr)
+			m.
+				On("PruneNetworkPolicies", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(nil, nil).
+				Maybe()
+
+			var driftRecorder networkPolicyDriftRecorderMock
+			driftRecorder.
+				On("RecordNetworkPolicyDrift", "test-namespace", "test-ingress").
+				Return()
+
+			recorder := record.NewFakeRecorder(10)
+
+			p := NewPhaseApplyNetworkPolicies(
+				&m,
+				WithEventRecorder{Recorder: recorder},
+				WithNetworkPolicyDriftReportOnly(tc.ReportOnly),
+				WithNetworkPolicyDriftRecorder{Recorder: &driftRecorder},
+			)
+
+			res := p.Execute(context.Background(), PhaseRequest{
+				Params: NewPhaseRequestParameters(
+					WithApplyNetworkPolicies{Value: controllers.BoolPtr(true)},
+				),
+			})
+
+			assert.Equal(t, tc.ExpectedStatus, res.Status())
+
+			assertCondition(t, res.Conditions(), refv1alpha1.ReferenceAddonConditionNetworkPoliciesApplied, tc.ExpectedReason)
+
+			cond := meta.FindStatusCondition(res.Conditions(), refv1alpha1.ReferenceAddonConditionNetworkPoliciesApplied.String())
+			require.NotNil(t, cond)
+			assert.Equal(t, tc.ExpectedMessage, cond.Message)
+
+			close(recorder.Events)
+
+			var events []string
+
+			for e := range recorder.Events {
+				events = append(events, e)
+			}
+
+			assert.Equal(t, tc.ExpectedEvents, events)
 
 			m.AssertExpectations(t)
+			driftRecorder.AssertExpectations(t)
 		})
 	}
 }
 
+type networkPolicyDriftRecorderMock struct {
+	mock.Mock
+}
+
+func (m *networkPolicyDriftRecorderMock) RecordNetworkPolicyDrift(namespace, name string) {
+	m.Called(namespace, name)
+}
+
+// recordingNetworkPolicyClient records the names of the
+// NetworkPolicies passed to it.
+type recordingNetworkPolicyClient struct {
+	Applied []string
+	Removed []string
+}
+
+func (c *recordingNetworkPolicyClient) ApplyNetworkPolicies(_ context.Context, opts ...ApplyNetorkPoliciesOption) ([]NetworkPolicyDrift, error) {
+	var cfg ApplyNetorkPoliciesConfig
+
+	cfg.Option(opts...)
+
+	c.Applied = append(c.Applied, policyNames(cfg.Policies)...)
+
+	return nil, nil
+}
+
+func (c *recordingNetworkPolicyClient) RemoveNetworkPolicies(_ context.Context, policies ...netv1.NetworkPolicy) error {
+	c.Removed = append(c.Removed, policyNames(policies)...)
+
+	return nil
+}
+
+func (c *recordingNetworkPolicyClient) PruneNetworkPolicies(context.Context, ...PruneNetworkPoliciesOption) ([]netv1.NetworkPolicy, error) {
+	return nil, nil
+}
+
 type NetworkPolicyClientMock struct {
 	mock.Mock
 }
 
-func (m *NetworkPolicyClientMock) ApplyNetworkPolicies(ctx context.Context, opts ...ApplyNetorkPoliciesOption) error {
+func (m *NetworkPolicyClientMock) ApplyNetworkPolicies(ctx context.Context, opts ...ApplyNetorkPoliciesOption) ([]NetworkPolicyDrift, error) {
 	argList := make([]interface{}, 0, 1+len(opts))
 
 	argList = append(argList, ctx)
@@ -122,7 +591,9 @@ func (m *NetworkPolicyClientMock) ApplyNetworkPolicies(ctx context.Context, opts
 
 	args := m.Called(argList...)
 
-	return args.Error(0)
+	drifted, _ := args.Get(0).([]NetworkPolicyDrift)
+
+	return drifted, args.Error(1)
 }
 
 func (m *NetworkPolicyClientMock) RemoveNetworkPolicies(ctx context.Context, policies ...netv1.NetworkPolicy) error {
@@ -139,6 +610,22 @@ func (m *NetworkPolicyClientMock) RemoveNetworkPolicies(ctx context.Context, pol
 	return args.Error(0)
 }
 
+func (m *NetworkPolicyClientMock) PruneNetworkPolicies(ctx context.Context, opts ...PruneNetworkPoliciesOption) ([]netv1.NetworkPolicy, error) {
+	argList := make([]interface{}, 0, 1+len(opts))
+
+	argList = append(argList, ctx)
+
+	for _, o := range opts {
+		argList = append(argList, o)
+	}
+
+	args := m.Called(argList...)
+
+	pruned, _ := args.Get(0).([]netv1.NetworkPolicy)
+
+	return pruned, args.Error(1)
+}
+
 func TestNetworkPolicyClientImplInterfaces(t *testing.T) {
 	t.Parallel()
 
@@ -237,15 +724,17 @@ func TestNetworkPolicyClientImpl_ApplyNetworkPolicies(t *testing.T) {
 			c := fake.
 				NewClientBuilder().
 				WithObjects(objs...).
+				WithInterceptorFuncs(internaltesting.ServerSideApplyFuncs()).
 				Build()
 
 			npClient := NewNetworkPolicyClientImpl(c)
 
-			require.NoError(t, npClient.ApplyNetworkPolicies(
+			drifted, err := npClient.ApplyNetworkPolicies(
 				context.Background(),
 				WithPolicies(tc.DesiredPolicies),
-			),
 			)
+			require.NoError(t, err)
+			assert.Empty(t, drifted)
 
 			for _, expected := range tc.ExpectedPolicies {
 				assert.NoError(t, c.Get(
@@ -358,3 +847,224 @@ func TestNetworkPolicyClientImpl_RemoveNetworkPolicies(t *testing.T) {
 		})
 	}
 }
+
+func TestNetworkPolicyClientImpl_PruneNetworkPolicies(t *testing.T) {
+	t.Parallel()
+
+	managed := map[string]string{
+		ManagedByLabel: "test",
+		OwnerLabel:     "test-addon",
+	}
+
+	newPolicy := func(name, namespace string, lbls map[string]string) netv1.NetworkPolicy {
+		return netv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    lbls,
+			},
+		}
+	}
+
+	var (
+		desired        = newPolicy("test-desired", "test-namespace", managed)
+		stale          = newPolicy("test-stale", "test-namespace", managed)
+		unlabeled      = newPolicy("test-unlabeled", "test-namespace", nil)
+		otherNamespace = newPolicy("test-stale", "other-namespace", managed)
+	)
+
+	for name, tc := range map[string]struct {
+		Selector          labels.Selector
+		DryRun            bool
+		ExpectedPruned    []string
+		ExpectedRemaining []netv1.NetworkPolicy
+		AssertError       require.ErrorAssertionFunc
+	}{
+		"stale policy pruned": {
+			Selector:          labels.SelectorFromSet(managed),
+			ExpectedPruned:    []string{"test-stale"},
+			ExpectedRemaining: []netv1.NetworkPolicy{desired, unlabeled, otherNamespace},
+			AssertError:       require.NoError,
+		},
+		"dry run": {
+			Selector:          labels.SelectorFromSet(managed),
+			DryRun:            true,
+			ExpectedPruned:    []string{"test-stale"},
+			ExpectedRemaining: []netv1.NetworkPolicy{desired, stale, unlabeled, otherNamespace},
+			AssertError:       require.NoError,
+		},
+		"no selector": {
+			ExpectedRemaining: []netv1.NetworkPolicy{desired, stale, unlabeled, otherNamespace},
+			AssertError:       require.Error,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := fake.
+				NewClientBuilder().
+				WithObjects(desired.DeepCopy(), stale.DeepCopy(), unlabeled.DeepCopy(), otherNamespace.DeepCopy()).
+				Build()
+
+			npClient := NewNetworkPolicyClientImpl(c)
+
+			pruned, err := npClient.PruneNetworkPolicies(
+				context.Background(),
+				WithNamespace("test-namespace"),
+				WithLabelSelector{Selector: tc.Selector},
+				WithPolicies{desired},
+				WithNetworkPolicyPruneDryRun(tc.DryRun),
+			)
+			tc.AssertError(t, err)
+
+			assert.Equal(t, tc.ExpectedPruned, policyNames(pruned))
+
+			for _, expected := range tc.ExpectedRemaining {
+				assert.NoError(t, c.Get(
+					context.Background(),
+					client.ObjectKeyFromObject(&expected),
+					new(netv1.NetworkPolicy),
+				),
+				)
+			}
+		})
+	}
+}
+
+func TestNetworkPolicyClientImpl_ApplyNetworkPoliciesDrift(t *testing.T) {
+	t.Parallel()
+
+	desired := netv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-namespace",
+		},
+		Spec: netv1.NetworkPolicySpec{
+			PolicyTypes: []netv1.PolicyType{netv1.PolicyTypeIngress},
+		},
+	}
+
+	tampered := *desired.DeepCopy()
+	tampered.Spec.Ingress = []netv1.NetworkPolicyIngressRule{{}}
+
+	for name, tc := range map[string]struct {
+		ReportOnly      bool
+		ExpectedIngress []netv1.NetworkPolicyIngressRule
+	}{
+		"drift reverted": {
+			ExpectedIngress: nil,
+		},
+		"report-only": {
+			ReportOnly:      true,
+			ExpectedIngress: tampered.Spec.Ingress,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := fake.
+				NewClientBuilder().
+				WithObjects(tampered.DeepCopy()).
+				WithInterceptorFuncs(internaltesting.ServerSideApplyFuncs()).
+				Build()
+
+			drifted, err := NewNetworkPolicyClientImpl(c).ApplyNetworkPolicies(
+				context.Background(),
+				WithPolicies{desired},
+				WithNetworkPolicyDriftReportOnly(tc.ReportOnly),
+			)
+			require.NoError(t, err)
+
+			assert.Equal(t, []NetworkPolicyDrift{
+				{
+					Name:      "test",
+					Namespace: "test-namespace",
+					Fields:    []string{"spec.ingress"},
+				},
+			}, drifted)
+
+			var actual netv1.NetworkPolicy
+
+			require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(&desired), &actual))
+
+			assert.Equal(t, tc.ExpectedIngress, actual.Spec.Ingress)
+		})
+	}
+}
+
+func TestDiffNetworkPolicySpec(t *testing.T) {
+	t.Parallel()
+
+	tcp := corev1.ProtocolTCP
+	udp := corev1.ProtocolUDP
+	port := intstr.FromInt32(53)
+
+	for name, tc := range map[string]struct {
+		Desired        netv1.NetworkPolicySpec
+		Actual         netv1.NetworkPolicySpec
+		ExpectedFields []string
+	}{
+		"equal": {
+			Desired: netv1.NetworkPolicySpec{
+				PolicyTypes: []netv1.PolicyType{netv1.PolicyTypeIngress, netv1.PolicyTypeEgress},
+			},
+			Actual: netv1.NetworkPolicySpec{
+				PolicyTypes: []netv1.PolicyType{netv1.PolicyTypeEgress, netv1.PolicyTypeIngress},
+			},
+		},
+		"defaulted values": {
+			Desired: netv1.NetworkPolicySpec{
+				Egress: []netv1.NetworkPolicyEgressRule{
+					{Ports: []netv1.NetworkPolicyPort{{Port: &port}}},
+				},
+			},
+			Actual: netv1.NetworkPolicySpec{
+				Egress: []netv1.NetworkPolicyEgressRule{
+					{Ports: []netv1.NetworkPolicyPort{{Protocol: &tcp, Port: &port}}},
+				},
+				PolicyTypes: []netv1.PolicyType{netv1.PolicyTypeIngress, netv1.PolicyTypeEgress},
+			},
+		},
+		"pod selector changed": {
+			Actual: netv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			},
+			ExpectedFields: []string{"spec.podSelector"},
+		},
+		"rule added": {
+			Actual: netv1.NetworkPolicySpec{
+				Ingress: []netv1.NetworkPolicyIngressRule{{}},
+			},
+			ExpectedFields: []string{"spec.ingress"},
+		},
+		"rule changed": {
+			Desired: netv1.NetworkPolicySpec{
+				Egress: []netv1.NetworkPolicyEgressRule{
+					{},
+					{Ports: []netv1.NetworkPolicyPort{{Protocol: &udp, Port: &port}}},
+				},
+				PolicyTypes: []netv1.PolicyType{netv1.PolicyTypeEgress},
+			},
+			Actual: netv1.NetworkPolicySpec{
+				Egress: []netv1.NetworkPolicyEgressRule{
+					{},
+					{Ports: []netv1.NetworkPolicyPort{{Port: &port}}},
+				},
+				PolicyTypes: []netv1.PolicyType{netv1.PolicyTypeIngress},
+			},
+			ExpectedFields: []string{"spec.egress[1]", "spec.policyTypes"},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.ExpectedFields, diffNetworkPolicySpec(tc.Desired, tc.Actual))
+		})
+	}
+}
@@ -0,0 +1,545 @@
+package referenceaddon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	refv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
+	"github.com/openshift/reference-addon/internal/controllers"
+	"go.uber.org/multierr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AppliedSpecHashAnnotation records the hash of the spec which was last
+// applied to a managed object. A mismatch between the annotation and the
+// object's current spec indicates that the object was edited by hand.
+const AppliedSpecHashAnnotation = "reference.addons.managed.openshift.io/applied-spec-hash"
+
+// ResourceGuardrails are the namespace wide ResourceQuota limits and
+// LimitRange container defaults applied for a size tier.
+type ResourceGuardrails struct {
+	// Quota is the hard limit of the addon namespace's ResourceQuota.
+	Quota corev1.ResourceList
+	// DefaultRequest is the LimitRange default container request.
+	DefaultRequest corev1.ResourceList
+	// Default is the LimitRange default container limit.
+	Default corev1.ResourceList
+	// Max is the LimitRange maximum container limit.
+	Max corev1.ResourceList
+}
+
+// DefaultResourceGuardrailTiers returns the guardrails applied to
+// the addon namespace for each predefined size tier.
+func DefaultResourceGuardrailTiers() map[string]ResourceGuardrails {
+	var (
+		defaultRequest = newResourceList("50m", "64Mi")
+		defaultLimit   = newResourceList("200m", "256Mi")
+	)
+
+	return map[string]ResourceGuardrails{
+		"small": {
+			Quota:          newQuotaResourceList("10", "1", "1Gi", "2", "2Gi"),
+			DefaultRequest: defaultRequest,
+			Default:        defaultLimit,
+			Max:            newResourceList("1", "1Gi"),
+		},
+		"medium": {
+			Quota:          newQuotaResourceList("20", "2", "4Gi", "4", "8Gi"),
+			DefaultRequest: defaultRequest,
+			Default:        defaultLimit,
+			Max:            newResourceList("2", "2Gi"),
+		},
+		"large": {
+			Quota:          newQuotaResourceList("40", "4", "8Gi", "8", "16Gi"),
+			DefaultRequest: defaultRequest,
+			Default:        defaultLimit,
+			Max:            newResourceList("4", "4Gi"),
+		},
+	}
+}
+
+func newResourceList(cpu, memory string) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+}
+
+func newQuotaResourceList(pods, cpuRequests, memRequests, cpuLimits, memLimits string) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourcePods:           resource.MustParse(pods),
+		corev1.ResourceRequestsCPU:    resource.MustParse(cpuRequests),
+		corev1.ResourceRequestsMemory: resource.MustParse(memRequests),
+		corev1.ResourceLimitsCPU:      resource.MustParse(cpuLimits),
+		corev1.ResourceLimitsMemory:   resource.MustParse(memLimits),
+	}
+}
+
+func NewPhaseApplyResourceGuardrails(client ResourceGuardrailsClient, opts ...PhaseApplyResourceGuardrailsOption) *PhaseApplyResourceGuardrails {
+	var cfg PhaseApplyResourceGuardrailsConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	return &PhaseApplyResourceGuardrails{
+		cfg: cfg,
+
+		client: client,
+	}
+}
+
+// PhaseApplyResourceGuardrails applies a ResourceQuota and LimitRange
+// owned by the ReferenceAddon to the addon namespace. Both are sized
+// according to the 'size' parameter and removed once it is unset.
+type PhaseApplyResourceGuardrails struct {
+	cfg PhaseApplyResourceGuardrailsConfig
+
+	client ResourceGuardrailsClient
+}
+
+func (p *PhaseApplyResourceGuardrails) Name() string {
+	return "applyResourceGuardrails"
+}
+
+func (p *PhaseApplyResourceGuardrails) Execute(ctx context.Context, req PhaseRequest) PhaseResult {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	size, ok := req.Params.GetSize()
+	if !ok {
+		return p.ensureGuardrailsRemoved(ctx, req)
+	}
+
+	guardrails, err := p.resolveGuardrails(size, req.Params)
+	if err != nil {
+		cond := newResourceGuardrailsAppliedCondition(
+			refv1alpha1.ResourceGuardrailsAppliedReasonInvalidSize,
+			err.Error(),
+		)
+
+		recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeWarning, EventReasonResourceGuardrailsFailed)
+
+		return PhaseResultFailure(cond.Message, WithConditions{cond})
+	}
+
+	return p.ensureGuardrailsApplied(ctx, req, size, guardrails)
+}
+
+func (p *PhaseApplyResourceGuardrails) ensureGuardrailsRemoved(ctx context.Context, req PhaseRequest) PhaseResult {
+	quota, limitRange := p.desiredObjects("", ResourceGuardrails{})
+
+	if err := p.client.RemoveResourceGuardrails(ctx, quota, limitRange); err != nil {
+		cond := newResourceGuardrailsAppliedCondition(
+			refv1alpha1.ResourceGuardrailsAppliedReasonRemoveFailed,
+			err.Error(),
+		)
+
+		recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeWarning, EventReasonResourceGuardrailsFailed)
+
+		return PhaseResultError(
+			fmt.Errorf("removing resource guardrails: %w", err),
+			WithConditions{cond},
+		)
+	}
+
+	cond := newResourceGuardrailsAppliedCondition(
+		refv1alpha1.ResourceGuardrailsAppliedReasonRemoved,
+		"'Size' parameter not set",
+	)
+
+	recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeNormal, EventReasonResourceGuardrailsRemoved)
+
+	return PhaseResultSuccess(WithConditions{cond})
+}
+
+func (p *PhaseApplyResourceGuardrails) ensureGuardrailsApplied(
+	ctx context.Context,
+	req PhaseRequest,
+	size string,
+	guardrails ResourceGuardrails,
+) PhaseResult {
+	quota, limitRange := p.desiredObjects(size, guardrails)
+
+	drifted, err := p.client.ApplyResourceGuardrails(ctx, quota, limitRange, WithOwner{Owner: &req.Addon})
+	if err != nil {
+		cond := newResourceGuardrailsAppliedCondition(
+			refv1alpha1.ResourceGuardrailsAppliedReasonApplyFailed,
+			err.Error(),
+		)
+
+		recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeWarning, EventReasonResourceGuardrailsFailed)
+
+		return PhaseResultError(
+			fmt.Errorf("applying resource guardrails: %w", err),
+			WithConditions{cond},
+		)
+	}
+
+	if len(drifted) > 0 {
+		p.cfg.Log.Info("reverted manual changes to resource guardrails", "objects", drifted)
+
+		cond := newResourceGuardrailsAppliedCondition(
+			refv1alpha1.ResourceGuardrailsAppliedReasonDriftCorrected,
+			fmt.Sprintf("size %q: reverted manual changes to %s", size, strings.Join(drifted, ", ")),
+		)
+
+		// Drift is reported on every occurrence rather than only on
+		// transitions since each one is a separate manual edit.
+		p.cfg.Recorder.Event(&req.Addon, corev1.EventTypeWarning, EventReasonResourceGuardrailsDrifted, cond.Message)
+
+		return PhaseResultSuccess(WithConditions{cond})
+	}
+
+	cond := newResourceGuardrailsAppliedCondition(
+		refv1alpha1.ResourceGuardrailsAppliedReasonApplied,
+		fmt.Sprintf("size %q: applied ResourceQuota %q and LimitRange %q", size, quota.Name, limitRange.Name),
+	)
+
+	recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeNormal, EventReasonResourceGuardrailsApplied)
+
+	return PhaseResultSuccess(WithConditions{cond})
+}
+
+// resolveGuardrails maps the requested size to the guardrails applied to
+// the addon namespace. The custom size extends the smallest tier by the
+// requests and limits of the custom sample workload including one surge
+// replica so that rollouts are never blocked by the quota.
+func (p *PhaseApplyResourceGuardrails) resolveGuardrails(size string, params PhaseRequestParameters) (ResourceGuardrails, error) {
+	if size != AddonSizeCustom {
+		guardrails, ok := p.cfg.Tiers[size]
+		if !ok {
+			return ResourceGuardrails{}, fmt.Errorf("unknown size %q", size)
+		}
+
+		return guardrails, nil
+	}
+
+	custom, ok := params.GetCustomSize()
+	if !ok {
+		return ResourceGuardrails{}, fmt.Errorf("size %q requires the 'CustomSize' parameter to be set", size)
+	}
+
+	base, ok := p.cfg.Tiers[p.cfg.CustomBaseTier]
+	if !ok {
+		return ResourceGuardrails{}, fmt.Errorf("unknown base size %q for size %q", p.cfg.CustomBaseTier, size)
+	}
+
+	pods := int64(custom.Replicas) + 1
+
+	quota := base.Quota.DeepCopy()
+
+	addQuantity(quota, corev1.ResourcePods, *resource.NewQuantity(pods, resource.DecimalSI))
+
+	for name, qty := range custom.Resources.Requests {
+		addQuantity(quota, corev1.ResourceName("requests."+name), scaleQuantity(qty, pods))
+	}
+
+	for name, qty := range custom.Resources.Limits {
+		addQuantity(quota, corev1.ResourceName("limits."+name), scaleQuantity(qty, pods))
+	}
+
+	maxLimits := base.Max.DeepCopy()
+
+	for name, qty := range custom.Resources.Limits {
+		if current, ok := maxLimits[name]; !ok || qty.Cmp(current) > 0 {
+			maxLimits[name] = qty.DeepCopy()
+		}
+	}
+
+	return ResourceGuardrails{
+		Quota:          quota,
+		DefaultRequest: base.DefaultRequest.DeepCopy(),
+		Default:        base.Default.DeepCopy(),
+		Max:            maxLimits,
+	}, nil
+}
+
+func addQuantity(list corev1.ResourceList, name corev1.ResourceName, qty resource.Quantity) {
+	current, ok := list[name]
+	if !ok {
+		return
+	}
+
+	current.Add(qty)
+
+	list[name] = current
+}
+
+func scaleQuantity(qty resource.Quantity, factor int64) resource.Quantity {
+	return *resource.NewMilliQuantity(qty.MilliValue()*factor, qty.Format)
+}
+
+func (p *PhaseApplyResourceGuardrails) desiredObjects(size string, guardrails ResourceGuardrails) (corev1.ResourceQuota, corev1.LimitRange) {
+	annotations := map[string]string{
+		SizeAnnotation: size,
+	}
+
+	quota := corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        generateResourceQuotaName(p.cfg.OperatorName),
+			Namespace:   p.cfg.Namespace,
+			Annotations: annotations,
+		},
+		Spec: corev1.ResourceQuotaSpec{
+			Hard: guardrails.Quota,
+		},
+	}
+
+	limitRange := corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        generateLimitRangeName(p.cfg.OperatorName),
+			Namespace:   p.cfg.Namespace,
+			Annotations: annotations,
+		},
+		Spec: corev1.LimitRangeSpec{
+			Limits: []corev1.LimitRangeItem{
+				{
+					Type:           corev1.LimitTypeContainer,
+					DefaultRequest: guardrails.DefaultRequest,
+					Default:        guardrails.Default,
+					Max:            guardrails.Max,
+				},
+			},
+		},
+	}
+
+	return quota, limitRange
+}
+
+func newResourceGuardrailsAppliedCondition(reason refv1alpha1.ResourceGuardrailsAppliedReason, msg string) metav1.Condition {
+	return newCondition(refv1alpha1.ReferenceAddonConditionResourceGuardrailsApplied, reason, msg)
+}
+
+type PhaseApplyResourceGuardrailsConfig struct {
+	Log      logr.Logger
+	Recorder record.EventRecorder
+
+	OperatorName string
+	Namespace    string
+	Tiers        map[string]ResourceGuardrails
+	// CustomBaseTier is the tier extended by the
+	// sample workload's custom size.
+	CustomBaseTier string
+}
+
+func (c *PhaseApplyResourceGuardrailsConfig) Option(opts ...PhaseApplyResourceGuardrailsOption) {
+	for _, opt := range opts {
+		opt.ConfigurePhaseApplyResourceGuardrails(c)
+	}
+}
+
+func (c *PhaseApplyResourceGuardrailsConfig) Default() {
+	if c.Log.GetSink() == nil {
+		c.Log = logr.Discard()
+	}
+
+	if c.Recorder == nil {
+		c.Recorder = controllers.NopEventRecorder{}
+	}
+
+	if c.Tiers == nil {
+		c.Tiers = DefaultResourceGuardrailTiers()
+	}
+
+	if c.CustomBaseTier == "" {
+		c.CustomBaseTier = "small"
+	}
+}
+
+type PhaseApplyResourceGuardrailsOption interface {
+	ConfigurePhaseApplyResourceGuardrails(*PhaseApplyResourceGuardrailsConfig)
+}
+
+type ResourceGuardrailsClient interface {
+	// ApplyResourceGuardrails creates or updates the given ResourceQuota
+	// and LimitRange and returns the names of the objects whose spec was
+	// changed by hand since it was last applied.
+	ApplyResourceGuardrails(
+		ctx context.Context,
+		quota corev1.ResourceQuota,
+		limitRange corev1.LimitRange,
+		opts ...ApplyResourceGuardrailsOption,
+	) ([]string, error)
+	RemoveResourceGuardrails(ctx context.Context, quota corev1.ResourceQuota, limitRange corev1.LimitRange) error
+}
+
+func NewResourceGuardrailsClientImpl(client client.Client) *ResourceGuardrailsClientImpl {
+	return &ResourceGuardrailsClientImpl{
+		client: client,
+		// Manual changes are reverted by design, so ownership of
+		// fields changed by other field managers is taken back.
+		applier: controllers.NewApplier(client, controllers.WithForceOwnership(true)),
+	}
+}
+
+type ResourceGuardrailsClientImpl struct {
+	client  client.Client
+	applier *controllers.Applier
+}
+
+func (c *ResourceGuardrailsClientImpl) ApplyResourceGuardrails(
+	ctx context.Context,
+	quota corev1.ResourceQuota,
+	limitRange corev1.LimitRange,
+	opts ...ApplyResourceGuardrailsOption,
+) ([]string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var cfg ApplyResourceGuardrailsConfig
+
+	cfg.Option(opts...)
+
+	if cfg.Owner != nil {
+		if err := ctrl.SetControllerReference(cfg.Owner, &quota, c.client.Scheme()); err != nil {
+			return nil, fmt.Errorf("setting controller reference: %w", err)
+		}
+
+		if err := ctrl.SetControllerReference(cfg.Owner, &limitRange, c.client.Scheme()); err != nil {
+			return nil, fmt.Errorf("setting controller reference: %w", err)
+		}
+	}
+
+	var (
+		drifted  []string
+		finalErr error
+	)
+
+	quotaDrifted, err := c.applyQuota(ctx, quota)
+	if err != nil {
+		multierr.AppendInto(&finalErr, err)
+	} else if quotaDrifted {
+		drifted = append(drifted, fmt.Sprintf("ResourceQuota %q", quota.Name))
+	}
+
+	limitRangeDrifted, err := c.applyLimitRange(ctx, limitRange)
+	if err != nil {
+		multierr.AppendInto(&finalErr, err)
+	} else if limitRangeDrifted {
+		drifted = append(drifted, fmt.Sprintf("LimitRange %q", limitRange.Name))
+	}
+
+	return drifted, finalErr
+}
+
+func (c *ResourceGuardrailsClientImpl) applyQuota(ctx context.Context, quota corev1.ResourceQuota) (bool, error) {
+	desired := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            quota.Name,
+			Namespace:       quota.Namespace,
+			Labels:          quota.Labels,
+			Annotations:     labels.Merge(quota.Annotations, map[string]string{AppliedSpecHashAnnotation: specHash(quota.Spec)}),
+			OwnerReferences: quota.OwnerReferences,
+		},
+		Spec: quota.Spec,
+	}
+
+	var (
+		actual  corev1.ResourceQuota
+		drifted bool
+	)
+
+	if err := c.client.Get(ctx, client.ObjectKeyFromObject(desired), &actual); err == nil {
+		drifted = hasDrifted(&actual, specHash(actual.Spec))
+	} else if !errors.IsNotFound(err) {
+		return false, fmt.Errorf("getting ResourceQuota %q: %w", quota.Name, err)
+	}
+
+	return drifted, c.applier.Apply(ctx, desired)
+}
+
+func (c *ResourceGuardrailsClientImpl) applyLimitRange(ctx context.Context, limitRange corev1.LimitRange) (bool, error) {
+	desired := &corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            limitRange.Name,
+			Namespace:       limitRange.Namespace,
+			Labels:          limitRange.Labels,
+			Annotations:     labels.Merge(limitRange.Annotations, map[string]string{AppliedSpecHashAnnotation: specHash(limitRange.Spec)}),
+			OwnerReferences: limitRange.OwnerReferences,
+		},
+		Spec: limitRange.Spec,
+	}
+
+	var (
+		actual  corev1.LimitRange
+		drifted bool
+	)
+
+	if err := c.client.Get(ctx, client.ObjectKeyFromObject(desired), &actual); err == nil {
+		drifted = hasDrifted(&actual, specHash(actual.Spec))
+	} else if !errors.IsNotFound(err) {
+		return false, fmt.Errorf("getting LimitRange %q: %w", limitRange.Name, err)
+	}
+
+	return drifted, c.applier.Apply(ctx, desired)
+}
+
+// hasDrifted reports whether the current spec of an existing object no
+// longer matches the spec which was last applied to it.
+func hasDrifted(obj metav1.Object, currentHash string) bool {
+	applied, ok := obj.GetAnnotations()[AppliedSpecHashAnnotation]
+	if !ok {
+		return false
+	}
+
+	return applied != currentHash
+}
+
+// specHash returns a short, stable hash of the JSON encoding of the given spec.
+func specHash(spec any) string {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+func (c *ResourceGuardrailsClientImpl) RemoveResourceGuardrails(
+	ctx context.Context,
+	quota corev1.ResourceQuota,
+	limitRange corev1.LimitRange,
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var finalErr error
+
+	if err := c.client.Delete(ctx, &quota); err != nil && !errors.IsNotFound(err) {
+		multierr.AppendInto(&finalErr, fmt.Errorf("deleting ResourceQuota %q: %w", quota.Name, err))
+	}
+
+	if err := c.client.Delete(ctx, &limitRange); err != nil && !errors.IsNotFound(err) {
+		multierr.AppendInto(&finalErr, fmt.Errorf("deleting LimitRange %q: %w", limitRange.Name, err))
+	}
+
+	return finalErr
+}
+
+type ApplyResourceGuardrailsConfig struct {
+	Owner metav1.Object
+}
+
+func (c *ApplyResourceGuardrailsConfig) Option(opts ...ApplyResourceGuardrailsOption) {
+	for _, opt := range opts {
+		opt.ConfigureApplyResourceGuardrails(c)
+	}
+}
+
+type ApplyResourceGuardrailsOption interface {
+	ConfigureApplyResourceGuardrails(c *ApplyResourceGuardrailsConfig)
+}
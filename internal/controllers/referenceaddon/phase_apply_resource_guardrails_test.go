@@ -0,0 +1,297 @@
+package referenceaddon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	refv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
+	"github.com/openshift/reference-addon/internal/controllers"
+	internaltesting "github.com/openshift/reference-addon/internal/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPhaseApplyResourceGuardrailsInterfaces(t *testing.T) {
+	t.Parallel()
+
+	require.Implements(t, new(Phase), new(PhaseApplyResourceGuardrails))
+}
+
+func TestPhaseApplyResourceGuardrails(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Size           *string
+		Drifted        []string
+		ClientErr      error
+		ExpectApply    bool
+		ExpectRemove   bool
+		ExpectedStatus PhaseStatus
+		ExpectedReason refv1alpha1.ResourceGuardrailsAppliedReason
+		ExpectedEvents []string
+	}{
+		"size unset": {
+			ExpectRemove:   true,
+			ExpectedStatus: PhaseStatusSuccess,
+			ExpectedReason: refv1alpha1.ResourceGuardrailsAppliedReasonRemoved,
+			ExpectedEvents: []string{
+				"Normal ResourceGuardrailsRemoved 'Size' parameter not set",
+			},
+		},
+		"size unset/remove failed": {
+			ClientErr:      errors.New("test error"),
+			ExpectRemove:   true,
+			ExpectedStatus: PhaseStatusError,
+			ExpectedReason: refv1alpha1.ResourceGuardrailsAppliedReasonRemoveFailed,
+			ExpectedEvents: []string{
+				"Warning ResourceGuardrailsFailed test error",
+			},
+		},
+		"size set": {
+			Size:           controllers.StringPtr("medium"),
+			ExpectApply:    true,
+			ExpectedStatus: PhaseStatusSuccess,
+			ExpectedReason: refv1alpha1.ResourceGuardrailsAppliedReasonApplied,
+			ExpectedEvents: []string{
+				`Normal ResourceGuardrailsApplied size "medium": applied ResourceQuota "test-quota" and LimitRange "test-limits"`,
+			},
+		},
+		"size set/drifted": {
+			Size:           controllers.StringPtr("medium"),
+			Drifted:        []string{`ResourceQuota "test-quota"`},
+			ExpectApply:    true,
+			ExpectedStatus: PhaseStatusSuccess,
+			ExpectedReason: refv1alpha1.ResourceGuardrailsAppliedReasonDriftCorrected,
+			ExpectedEvents: []string{
+				`Warning ResourceGuardrailsDrifted size "medium": reverted manual changes to ResourceQuota "test-quota"`,
+			},
+		},
+		"size set/apply failed": {
+			Size:           controllers.StringPtr("small"),
+			ClientErr:      errors.New("test error"),
+			ExpectApply:    true,
+			ExpectedStatus: PhaseStatusError,
+			ExpectedReason: refv1alpha1.ResourceGuardrailsAppliedReasonApplyFailed,
+			ExpectedEvents: []string{
+				"Warning ResourceGuardrailsFailed test error",
+			},
+		},
+		"custom size without customsize": {
+			Size:           controllers.StringPtr(AddonSizeCustom),
+			ExpectedStatus: PhaseStatusFailure,
+			ExpectedReason: refv1alpha1.ResourceGuardrailsAppliedReasonInvalidSize,
+			ExpectedEvents: []string{
+				`Warning ResourceGuardrailsFailed size "custom" requires the 'CustomSize' parameter to be set`,
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var m ResourceGuardrailsClientMock
+
+			if tc.ExpectApply {
+				m.
+					On("ApplyResourceGuardrails", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(tc.Drifted, tc.ClientErr)
+			}
+
+			if tc.ExpectRemove {
+				m.
+					On("RemoveResourceGuardrails", mock.Anything, mock.Anything, mock.Anything).
+					Return(tc.ClientErr)
+			}
+
+			recorder := record.NewFakeRecorder(10)
+
+			p := NewPhaseApplyResourceGuardrails(
+				&m,
+				WithEventRecorder{Recorder: recorder},
+				WithOperatorName("test"),
+				WithAddonNamespace("test-namespace"),
+			)
+
+			res := p.Execute(context.Background(), PhaseRequest{
+				Params: NewPhaseRequestParameters(
+					WithSize{Value: tc.Size},
+				),
+			})
+
+			assert.Equal(t, tc.ExpectedStatus, res.Status())
+			assertCondition(t, res.Conditions(), refv1alpha1.ReferenceAddonConditionResourceGuardrailsApplied, tc.ExpectedReason)
+
+			close(recorder.Events)
+
+			var events []string
+
+			for e := range recorder.Events {
+				events = append(events, e)
+			}
+
+			assert.Equal(t, tc.ExpectedEvents, events)
+
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPhaseApplyResourceGuardrails_CustomSize(t *testing.T) {
+	t.Parallel()
+
+	p := NewPhaseApplyResourceGuardrails(nil)
+
+	params := NewPhaseRequestParameters(
+		WithSize{Value: controllers.StringPtr(AddonSizeCustom)},
+		WithParameter{
+			Key: customSizeParameterID,
+			Value: json.RawMessage(`{
+				"replicas": 3,
+				"resources": {
+					"requests": {"cpu": "500m", "memory": "1Gi"},
+					"limits": {"cpu": "2", "memory": "2Gi"}
+				}
+			}`),
+		},
+	)
+
+	guardrails, err := p.resolveGuardrails(AddonSizeCustom, params)
+	require.NoError(t, err)
+
+	// The small tier is extended by four replicas (three plus one surge).
+	for name, expected := range map[corev1.ResourceName]string{
+		corev1.ResourcePods:           "14",
+		corev1.ResourceRequestsCPU:    "3",
+		corev1.ResourceRequestsMemory: "5Gi",
+		corev1.ResourceLimitsCPU:      "10",
+		corev1.ResourceLimitsMemory:   "10Gi",
+	} {
+		var (
+			qty  = guardrails.Quota[name]
+			want = resource.MustParse(expected)
+		)
+
+		assert.Zero(t, want.Cmp(qty), "%s: expected %s, got %s", name, expected, qty.String())
+	}
+
+	var (
+		maxCPU  = guardrails.Max[corev1.ResourceCPU]
+		wantCPU = resource.MustParse("2")
+	)
+
+	assert.Zero(t, wantCPU.Cmp(maxCPU), "max cpu raised to the custom limit")
+
+	baseQuota := DefaultResourceGuardrailTiers()["small"].Quota[corev1.ResourcePods]
+	configuredQuota := p.cfg.Tiers["small"].Quota[corev1.ResourcePods]
+
+	assert.Zero(t, baseQuota.Cmp(configuredQuota), "base tier must not be modified")
+}
+
+type ResourceGuardrailsClientMock struct {
+	mock.Mock
+}
+
+func (m *ResourceGuardrailsClientMock) ApplyResourceGuardrails(
+	ctx context.Context,
+	quota corev1.ResourceQuota,
+	limitRange corev1.LimitRange,
+	opts ...ApplyResourceGuardrailsOption,
+) ([]string, error) {
+	argList := make([]interface{}, 0, 3+len(opts))
+
+	argList = append(argList, ctx, quota, limitRange)
+
+	for _, o := range opts {
+		argList = append(argList, o)
+	}
+
+	args := m.Called(argList...)
+
+	drifted, _ := args.Get(0).([]string)
+
+	return drifted, args.Error(1)
+}
+
+func (m *ResourceGuardrailsClientMock) RemoveResourceGuardrails(
+	ctx context.Context,
+	quota corev1.ResourceQuota,
+	limitRange corev1.LimitRange,
+) error {
+	args := m.Called(ctx, quota, limitRange)
+
+	return args.Error(0)
+}
+
+func TestResourceGuardrailsClientImplInterfaces(t *testing.T) {
+	t.Parallel()
+
+	require.Implements(t, new(ResourceGuardrailsClient), new(ResourceGuardrailsClientImpl))
+}
+
+func TestResourceGuardrailsClientImpl_ApplyResourceGuardrails(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	c := fake.
+		NewClientBuilder().
+		WithInterceptorFuncs(internaltesting.ServerSideApplyFuncs()).
+		Build()
+
+	p := NewPhaseApplyResourceGuardrails(
+		nil,
+		WithOperatorName("test"),
+		WithAddonNamespace("test-namespace"),
+	)
+
+	guardClient := NewResourceGuardrailsClientImpl(c)
+
+	small, smallLimits := p.desiredObjects("small", p.cfg.Tiers["small"])
+
+	drifted, err := guardClient.ApplyResourceGuardrails(ctx, small, smallLimits)
+	require.NoError(t, err)
+	assert.Empty(t, drifted, "newly created objects have not drifted")
+
+	drifted, err = guardClient.ApplyResourceGuardrails(ctx, small, smallLimits)
+	require.NoError(t, err)
+	assert.Empty(t, drifted, "reapplying unchanged objects is not drift")
+
+	large, largeLimits := p.desiredObjects("large", p.cfg.Tiers["large"])
+
+	drifted, err = guardClient.ApplyResourceGuardrails(ctx, large, largeLimits)
+	require.NoError(t, err)
+	assert.Empty(t, drifted, "changing the size is not drift")
+
+	var quota corev1.ResourceQuota
+
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(&large), &quota))
+	assert.Equal(t, "large", quota.Annotations[SizeAnnotation])
+
+	quota.Spec.Hard[corev1.ResourcePods] = resource.MustParse("1000")
+	require.NoError(t, c.Update(ctx, &quota))
+
+	drifted, err = guardClient.ApplyResourceGuardrails(ctx, large, largeLimits)
+	require.NoError(t, err)
+	assert.Equal(t, []string{`ResourceQuota "test-quota"`}, drifted)
+
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(&large), &quota))
+
+	pods := quota.Spec.Hard[corev1.ResourcePods]
+
+	assert.Equal(t, "40", pods.String(), "manual change must be reverted")
+
+	require.NoError(t, guardClient.RemoveResourceGuardrails(ctx, large, largeLimits))
+	require.NoError(t, guardClient.RemoveResourceGuardrails(ctx, large, largeLimits), "removing absent objects succeeds")
+
+	assert.Error(t, c.Get(ctx, client.ObjectKeyFromObject(&large), &quota))
+	assert.Error(t, c.Get(ctx, client.ObjectKeyFromObject(&largeLimits), new(corev1.LimitRange)))
+}
@@ -0,0 +1,405 @@
+package referenceaddon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	refv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
+	"github.com/openshift/reference-addon/internal/controllers"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultSampleWorkloadImage is the image run by the sample workload
+// unless another image is configured.
+const DefaultSampleWorkloadImage = "registry.k8s.io/pause:3.10"
+
+// SizeAnnotation records the size tier which a managed
+// object was last sized for.
+const SizeAnnotation = "reference.addons.managed.openshift.io/size"
+
+// DefaultWorkloadSizeTiers returns the replicas and resources of
+// the sample workload for each predefined size tier.
+func DefaultWorkloadSizeTiers() map[string]refv1alpha1.WorkloadSize {
+	return map[string]refv1alpha1.WorkloadSize{
+		"small":  newWorkloadSize(1, "50m", "64Mi", "100m", "128Mi"),
+		"medium": newWorkloadSize(2, "100m", "128Mi", "200m", "256Mi"),
+		"large":  newWorkloadSize(3, "250m", "256Mi", "500m", "512Mi"),
+	}
+}
+
+func newWorkloadSize(replicas int32, cpuRequest, memRequest, cpuLimit, memLimit string) refv1alpha1.WorkloadSize {
+	return refv1alpha1.WorkloadSize{
+		Replicas: replicas,
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpuRequest),
+				corev1.ResourceMemory: resource.MustParse(memRequest),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpuLimit),
+				corev1.ResourceMemory: resource.MustParse(memLimit),
+			},
+		},
+	}
+}
+
+func NewPhaseApplySampleWorkload(client DeploymentClient, opts ...PhaseApplySampleWorkloadOption) *PhaseApplySampleWorkload {
+	var cfg PhaseApplySampleWorkloadConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	return &PhaseApplySampleWorkload{
+		cfg: cfg,
+
+		client: client,
+	}
+}
+
+// PhaseApplySampleWorkload sizes a sample workload Deployment owned by
+// the ReferenceAddon according to the 'size' parameter. Changes are
+// rolled out without reducing the number of available replicas and the
+// rollout progress is reported through the WorkloadAvailable condition.
+type PhaseApplySampleWorkload struct {
+	cfg PhaseApplySampleWorkloadConfig
+
+	client DeploymentClient
+}
+
+func (p *PhaseApplySampleWorkload) Name() string {
+	return "applySampleWorkload"
+}
+
+func (p *PhaseApplySampleWorkload) Execute(ctx context.Context, req PhaseRequest) PhaseResult {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	size, ok := req.Params.GetSize()
+	if !ok {
+		return PhaseResultSuccess(
+			WithConditions{
+				newWorkloadAvailableCondition(
+					refv1alpha1.WorkloadAvailableReasonNotConfigured,
+					"'Size' parameter not set",
+				),
+			},
+		)
+	}
+
+	workloadSize, err := p.resolveSize(size, req.Params)
+	if err != nil {
+		cond := newWorkloadAvailableCondition(
+			refv1alpha1.WorkloadAvailableReasonInvalidSize,
+			err.Error(),
+		)
+
+		recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeWarning, EventReasonWorkloadFailed)
+
+		return PhaseResultFailure(cond.Message, WithConditions{cond})
+	}
+
+	p.cfg.Log.Info("applying sample workload", "size", size, "replicas", workloadSize.Replicas)
+
+	actual, err := p.client.ApplyDeployment(ctx, p.desiredDeployment(size, workloadSize), WithOwner{Owner: &req.Addon})
+	if controllers.IsApplyConflict(err) {
+		cond := newWorkloadAvailableCondition(
+			refv1alpha1.WorkloadAvailableReasonConflict,
+			err.Error(),
+		)
+
+		recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeWarning, EventReasonWorkloadFailed)
+
+		return PhaseResultFailure(cond.Message, WithConditions{cond})
+	} else if err != nil {
+		cond := newWorkloadAvailableCondition(
+			refv1alpha1.WorkloadAvailableReasonApplyFailed,
+			err.Error(),
+		)
+
+		recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeWarning, EventReasonWorkloadFailed)
+
+		return PhaseResultError(
+			fmt.Errorf("applying sample workload: %w", err),
+			WithConditions{cond},
+		)
+	}
+
+	reason, msg := deploymentRolloutStatus(actual)
+
+	cond := newWorkloadAvailableCondition(reason, fmt.Sprintf("size %q: %s", size, msg))
+
+	switch reason {
+	case refv1alpha1.WorkloadAvailableReasonRolloutFailed:
+		recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeWarning, EventReasonWorkloadFailed)
+
+		return PhaseResultFailure(cond.Message, WithConditions{cond})
+	case refv1alpha1.WorkloadAvailableReasonProgressing:
+		recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeNormal, EventReasonWorkloadProgressing)
+
+		return PhaseResultSuccess(
+			WithConditions{cond},
+			WithRequeueAfter(p.cfg.RolloutPollInterval),
+		)
+	}
+
+	p.cfg.Log.Info("sample workload available", "size", size, "replicas", workloadSize.Replicas)
+
+	recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeNormal, EventReasonWorkloadAvailable)
+
+	return PhaseResultSuccess(WithConditions{cond})
+}
+
+// resolveSize maps the requested size to the sample workload's sizing.
+// The custom size is taken from the 'customsize' parameter while every
+// other size must match a configured tier.
+func (p *PhaseApplySampleWorkload) resolveSize(size string, params PhaseRequestParameters) (refv1alpha1.WorkloadSize, error) {
+	if size == AddonSizeCustom {
+		custom, ok := params.GetCustomSize()
+		if !ok {
+			return refv1alpha1.WorkloadSize{}, fmt.Errorf("size %q requires the 'CustomSize' parameter to be set", size)
+		}
+
+		return custom, nil
+	}
+
+	tier, ok := p.cfg.Tiers[size]
+	if !ok {
+		return refv1alpha1.WorkloadSize{}, fmt.Errorf("unknown size %q", size)
+	}
+
+	return tier, nil
+}
+
+func (p *PhaseApplySampleWorkload) desiredDeployment(size string, workloadSize refv1alpha1.WorkloadSize) appsv1.Deployment {
+	var (
+		name     = generateSampleWorkloadName(p.cfg.OperatorName)
+		selector = map[string]string{
+			"app.kubernetes.io/name":      name,
+			"app.kubernetes.io/component": "sample-workload",
+		}
+		maxSurge       = intstr.FromInt32(1)
+		maxUnavailable = intstr.FromInt32(0)
+	)
+
+	return appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: p.cfg.Namespace,
+			Labels:    selector,
+			Annotations: map[string]string{
+				SizeAnnotation: size,
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &workloadSize.Replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selector,
+			},
+			// Surge new replicas before old ones are removed so that
+			// resizing never reduces the available capacity.
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{
+					MaxSurge:       &maxSurge,
+					MaxUnavailable: &maxUnavailable,
+				},
+			},
+			ProgressDeadlineSeconds: controllers.Int32Ptr(int32(p.cfg.ProgressDeadline.Seconds())),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: selector,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:      "sample",
+							Image:     p.cfg.Image,
+							Resources: workloadSize.Resources,
+							SecurityContext: &corev1.SecurityContext{
+								AllowPrivilegeEscalation: controllers.BoolPtr(false),
+								RunAsNonRoot:             controllers.BoolPtr(true),
+								Capabilities: &corev1.Capabilities{
+									Drop: []corev1.Capability{"ALL"},
+								},
+								SeccompProfile: &corev1.SeccompProfile{
+									Type: corev1.SeccompProfileTypeRuntimeDefault,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// deploymentRolloutStatus determines whether the latest revision of the
+// given Deployment has been fully rolled out.
+func deploymentRolloutStatus(deploy appsv1.Deployment) (refv1alpha1.WorkloadAvailableReason, string) {
+	if deploy.Generation > deploy.Status.ObservedGeneration {
+		return refv1alpha1.WorkloadAvailableReasonProgressing, "waiting for rollout to be observed"
+	}
+
+	for _, cond := range deploy.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Reason == "ProgressDeadlineExceeded" {
+			return refv1alpha1.WorkloadAvailableReasonRolloutFailed, fmt.Sprintf("rollout exceeded its progress deadline: %s", cond.Message)
+		}
+	}
+
+	var replicas int32 = 1
+	if deploy.Spec.Replicas != nil {
+		replicas = *deploy.Spec.Replicas
+	}
+
+	status := deploy.Status
+
+	switch {
+	case status.UpdatedReplicas < replicas:
+		return refv1alpha1.WorkloadAvailableReasonProgressing,
+			fmt.Sprintf("%d of %d replicas updated", status.UpdatedReplicas, replicas)
+	case status.Replicas > status.UpdatedReplicas:
+		return refv1alpha1.WorkloadAvailableReasonProgressing,
+			fmt.Sprintf("%d old replicas pending termination", status.Replicas-status.UpdatedReplicas)
+	case status.AvailableReplicas < status.UpdatedReplicas:
+		return refv1alpha1.WorkloadAvailableReasonProgressing,
+			fmt.Sprintf("%d of %d updated replicas available", status.AvailableReplicas, status.UpdatedReplicas)
+	}
+
+	return refv1alpha1.WorkloadAvailableReasonAvailable, fmt.Sprintf("%d replicas available", status.AvailableReplicas)
+}
+
+func newWorkloadAvailableCondition(reason refv1alpha1.WorkloadAvailableReason, msg string) metav1.Condition {
+	return newCondition(refv1alpha1.ReferenceAddonConditionWorkloadAvailable, reason, msg)
+}
+
+type PhaseApplySampleWorkloadConfig struct {
+	Log      logr.Logger
+	Recorder record.EventRecorder
+
+	OperatorName string
+	Namespace    string
+	Image        string
+	Tiers        map[string]refv1alpha1.WorkloadSize
+
+	ProgressDeadline    time.Duration
+	RolloutPollInterval time.Duration
+}
+
+func (c *PhaseApplySampleWorkloadConfig) Option(opts ...PhaseApplySampleWorkloadOption) {
+	for _, opt := range opts {
+		opt.ConfigurePhaseApplySampleWorkload(c)
+	}
+}
+
+func (c *PhaseApplySampleWorkloadConfig) Default() {
+	if c.Log.GetSink() == nil {
+		c.Log = logr.Discard()
+	}
+
+	if c.Recorder == nil {
+		c.Recorder = controllers.NopEventRecorder{}
+	}
+
+	if c.Image == "" {
+		c.Image = DefaultSampleWorkloadImage
+	}
+
+	if c.Tiers == nil {
+		c.Tiers = DefaultWorkloadSizeTiers()
+	}
+
+	if c.ProgressDeadline <= 0 {
+		c.ProgressDeadline = 5 * time.Minute
+	}
+
+	if c.RolloutPollInterval <= 0 {
+		c.RolloutPollInterval = 10 * time.Second
+	}
+}
+
+type PhaseApplySampleWorkloadOption interface {
+	ConfigurePhaseApplySampleWorkload(*PhaseApplySampleWorkloadConfig)
+}
+
+type DeploymentClient interface {
+	ApplyDeployment(ctx context.Context, deploy appsv1.Deployment, opts ...ApplyDeploymentOption) (appsv1.Deployment, error)
+}
+
+func NewDeploymentClientImpl(client client.Client) *DeploymentClientImpl {
+	return &DeploymentClientImpl{
+		client:  client,
+		applier: controllers.NewApplier(client),
+	}
+}
+
+type DeploymentClientImpl struct {
+	client  client.Client
+	applier *controllers.Applier
+}
+
+// ApplyDeployment server-side applies the given Deployment and returns
+// the Deployment as it was last observed in the cluster.
+func (c *DeploymentClientImpl) ApplyDeployment(ctx context.Context, deploy appsv1.Deployment, opts ...ApplyDeploymentOption) (appsv1.Deployment, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var cfg ApplyDeploymentConfig
+
+	cfg.Option(opts...)
+
+	if cfg.Owner != nil {
+		if err := ctrl.SetControllerReference(cfg.Owner, &deploy, c.client.Scheme()); err != nil {
+			return appsv1.Deployment{}, fmt.Errorf("setting controller reference: %w", err)
+		}
+	}
+
+	desired := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            deploy.Name,
+			Namespace:       deploy.Namespace,
+			Labels:          deploy.Labels,
+			Annotations:     deploy.Annotations,
+			OwnerReferences: deploy.OwnerReferences,
+		},
+		Spec: deploy.Spec,
+	}
+
+	var actual appsv1.Deployment
+
+	// The selector of an existing Deployment is immutable.
+	if err := c.client.Get(ctx, client.ObjectKeyFromObject(desired), &actual); err == nil {
+		desired.Spec.Selector = actual.Spec.Selector
+	} else if !errors.IsNotFound(err) {
+		return appsv1.Deployment{}, fmt.Errorf("getting Deployment %q: %w", deploy.Name, err)
+	}
+
+	if err := c.applier.Apply(ctx, desired); err != nil {
+		return appsv1.Deployment{}, err
+	}
+
+	return *desired, nil
+}
+
+type ApplyDeploymentConfig struct {
+	Owner metav1.Object
+}
+
+func (c *ApplyDeploymentConfig) Option(opts ...ApplyDeploymentOption) {
+	for _, opt := range opts {
+		opt.ConfigureApplyDeployment(c)
+	}
+}
+
+type ApplyDeploymentOption interface {
+	ConfigureApplyDeployment(c *ApplyDeploymentConfig)
+}
@@ -0,0 +1,357 @@
+package referenceaddon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	refv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
+	"github.com/openshift/reference-addon/internal/controllers"
+	internaltesting "github.com/openshift/reference-addon/internal/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPhaseApplySampleWorkloadInterfaces(t *testing.T) {
+	t.Parallel()
+
+	require.Implements(t, new(Phase), new(PhaseApplySampleWorkload))
+}
+
+func TestPhaseApplySampleWorkload(t *testing.T) {
+	t.Parallel()
+
+	available := func(replicas int32) appsv1.Deployment {
+		return appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+			Status: appsv1.DeploymentStatus{
+				Replicas:          replicas,
+				UpdatedReplicas:   replicas,
+				AvailableReplicas: replicas,
+			},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		Params           PhaseRequestParameters
+		Actual           appsv1.Deployment
+		ApplyErr         error
+		ExpectedReplicas *int32
+		ExpectedStatus   PhaseStatus
+		ExpectedReason   refv1alpha1.WorkloadAvailableReason
+		ExpectRequeue    bool
+	}{
+		"size unset": {
+			Params:         NewPhaseRequestParameters(),
+			ExpectedStatus: PhaseStatusSuccess,
+			ExpectedReason: refv1alpha1.WorkloadAvailableReasonNotConfigured,
+		},
+		"small/available": {
+			Params: NewPhaseRequestParameters(
+				WithSize{Value: controllers.StringPtr("small")},
+			),
+			Actual:           available(1),
+			ExpectedReplicas: controllers.Int32Ptr(1),
+			ExpectedStatus:   PhaseStatusSuccess,
+			ExpectedReason:   refv1alpha1.WorkloadAvailableReasonAvailable,
+		},
+		"large/progressing": {
+			Params: NewPhaseRequestParameters(
+				WithSize{Value: controllers.StringPtr("large")},
+			),
+			Actual: appsv1.Deployment{
+				Spec:   appsv1.DeploymentSpec{Replicas: controllers.Int32Ptr(3)},
+				Status: appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+			},
+			ExpectedReplicas: controllers.Int32Ptr(3),
+			ExpectedStatus:   PhaseStatusSuccess,
+			ExpectedReason:   refv1alpha1.WorkloadAvailableReasonProgressing,
+			ExpectRequeue:    true,
+		},
+		"custom/available": {
+			Params: NewPhaseRequestParameters(
+				WithSize{Value: controllers.StringPtr(AddonSizeCustom)},
+				WithParameter{Key: customSizeParameterID, Value: json.RawMessage(`{"replicas": 5}`)},
+			),
+			Actual:           available(5),
+			ExpectedReplicas: controllers.Int32Ptr(5),
+			ExpectedStatus:   PhaseStatusSuccess,
+			ExpectedReason:   refv1alpha1.WorkloadAvailableReasonAvailable,
+		},
+		"custom/customsize unset": {
+			Params: NewPhaseRequestParameters(
+				WithSize{Value: controllers.StringPtr(AddonSizeCustom)},
+			),
+			ExpectedStatus: PhaseStatusFailure,
+			ExpectedReason: refv1alpha1.WorkloadAvailableReasonInvalidSize,
+		},
+		"unknown size": {
+			Params: NewPhaseRequestParameters(
+				WithSize{Value: controllers.StringPtr("huge")},
+			),
+			ExpectedStatus: PhaseStatusFailure,
+			ExpectedReason: refv1alpha1.WorkloadAvailableReasonInvalidSize,
+		},
+		"rollout failed": {
+			Params: NewPhaseRequestParameters(
+				WithSize{Value: controllers.StringPtr("medium")},
+			),
+			Actual: appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{Replicas: controllers.Int32Ptr(2)},
+				Status: appsv1.DeploymentStatus{
+					Conditions: []appsv1.DeploymentCondition{
+						{
+							Type:   appsv1.DeploymentProgressing,
+							Status: corev1.ConditionFalse,
+							Reason: "ProgressDeadlineExceeded",
+						},
+					},
+				},
+			},
+			ExpectedReplicas: controllers.Int32Ptr(2),
+			ExpectedStatus:   PhaseStatusFailure,
+			ExpectedReason:   refv1alpha1.WorkloadAvailableReasonRolloutFailed,
+		},
+		"apply failed": {
+			Params: NewPhaseRequestParameters(
+				WithSize{Value: controllers.StringPtr("small")},
+			),
+			ApplyErr:         errors.New("test error"),
+			ExpectedReplicas: controllers.Int32Ptr(1),
+			ExpectedStatus:   PhaseStatusError,
+			ExpectedReason:   refv1alpha1.WorkloadAvailableReasonApplyFailed,
+		},
+		"apply conflict": {
+			Params: NewPhaseRequestParameters(
+				WithSize{Value: controllers.StringPtr("small")},
+			),
+			ApplyErr: &controllers.ApplyConflictError{
+				Kind:     "Deployment",
+				Name:     "test",
+				Managers: []string{"kubectl-edit"},
+				Fields:   []string{".spec.replicas"},
+			},
+			ExpectedReplicas: controllers.Int32Ptr(1),
+			ExpectedStatus:   PhaseStatusFailure,
+			ExpectedReason:   refv1alpha1.WorkloadAvailableReasonConflict,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var m DeploymentClientMock
+
+			if tc.ExpectedReplicas != nil {
+				m.
+					On("ApplyDeployment", mock.Anything, mock.MatchedBy(func(d appsv1.Deployment) bool {
+						return *d.Spec.Replicas == *tc.ExpectedReplicas
+					}), mock.Anything).
+					Return(tc.Actual, tc.ApplyErr)
+			}
+
+			p := NewPhaseApplySampleWorkload(&m)
+
+			res := p.Execute(context.Background(), PhaseRequest{
+				Params: tc.Params,
+			})
+
+			assert.Equal(t, tc.ExpectedStatus, res.Status())
+			assertCondition(t, res.Conditions(), refv1alpha1.ReferenceAddonConditionWorkloadAvailable, tc.ExpectedReason)
+			assert.Equal(t, tc.ExpectRequeue, res.RequeueAfter() > 0)
+
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPhaseApplySampleWorkload_Events(t *testing.T) {
+	t.Parallel()
+
+	var m DeploymentClientMock
+	m.
+		On("ApplyDeployment", mock.Anything, mock.Anything, mock.Anything).
+		Return(appsv1.Deployment{
+			Spec:   appsv1.DeploymentSpec{Replicas: controllers.Int32Ptr(2)},
+			Status: appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+		}, nil)
+
+	recorder := record.NewFakeRecorder(10)
+
+	p := NewPhaseApplySampleWorkload(
+		&m,
+		WithEventRecorder{Recorder: recorder},
+	)
+
+	req := PhaseRequest{
+		Params: NewPhaseRequestParameters(
+			WithSize{Value: controllers.StringPtr("medium")},
+		),
+	}
+
+	res := p.Execute(context.Background(), req)
+	require.NoError(t, res.Error())
+
+	// A repeated progress report must not emit another event.
+	req.Addon.Status.Conditions = res.Conditions()
+
+	res = p.Execute(context.Background(), req)
+	require.NoError(t, res.Error())
+
+	close(recorder.Events)
+
+	var events []string
+
+	for e := range recorder.Events {
+		events = append(events, e)
+	}
+
+	assert.Equal(t, []string{
+		`Normal WorkloadProgressing size "medium": 1 of 2 replicas updated`,
+	}, events)
+}
+
+func TestDeploymentRolloutStatus(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Deployment     appsv1.Deployment
+		ExpectedReason refv1alpha1.WorkloadAvailableReason
+	}{
+		"not observed": {
+			Deployment: appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 1},
+			},
+			ExpectedReason: refv1alpha1.WorkloadAvailableReasonProgressing,
+		},
+		"updating replicas": {
+			Deployment: appsv1.Deployment{
+				Spec:   appsv1.DeploymentSpec{Replicas: controllers.Int32Ptr(3)},
+				Status: appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 1, AvailableReplicas: 3},
+			},
+			ExpectedReason: refv1alpha1.WorkloadAvailableReasonProgressing,
+		},
+		"terminating old replicas": {
+			Deployment: appsv1.Deployment{
+				Spec:   appsv1.DeploymentSpec{Replicas: controllers.Int32Ptr(2)},
+				Status: appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 2, AvailableReplicas: 3},
+			},
+			ExpectedReason: refv1alpha1.WorkloadAvailableReasonProgressing,
+		},
+		"updated replicas unavailable": {
+			Deployment: appsv1.Deployment{
+				Spec:   appsv1.DeploymentSpec{Replicas: controllers.Int32Ptr(2)},
+				Status: appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 1},
+			},
+			ExpectedReason: refv1alpha1.WorkloadAvailableReasonProgressing,
+		},
+		"scaled to zero": {
+			Deployment: appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{Replicas: controllers.Int32Ptr(0)},
+			},
+			ExpectedReason: refv1alpha1.WorkloadAvailableReasonAvailable,
+		},
+		"available": {
+			Deployment: appsv1.Deployment{
+				Spec:   appsv1.DeploymentSpec{Replicas: controllers.Int32Ptr(2)},
+				Status: appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+			},
+			ExpectedReason: refv1alpha1.WorkloadAvailableReasonAvailable,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			reason, _ := deploymentRolloutStatus(tc.Deployment)
+
+			assert.Equal(t, tc.ExpectedReason, reason)
+		})
+	}
+}
+
+type DeploymentClientMock struct {
+	mock.Mock
+}
+
+func (m *DeploymentClientMock) ApplyDeployment(ctx context.Context, deploy appsv1.Deployment, opts ...ApplyDeploymentOption) (appsv1.Deployment, error) {
+	argList := make([]interface{}, 0, 2+len(opts))
+
+	argList = append(argList, ctx, deploy)
+
+	for _, o := range opts {
+		argList = append(argList, o)
+	}
+
+	args := m.Called(argList...)
+
+	return args.Get(0).(appsv1.Deployment), args.Error(1)
+}
+
+func TestDeploymentClientImplInterfaces(t *testing.T) {
+	t.Parallel()
+
+	require.Implements(t, new(DeploymentClient), new(DeploymentClientImpl))
+}
+
+func TestDeploymentClientImpl_ApplyDeployment(t *testing.T) {
+	t.Parallel()
+
+	existing := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-namespace",
+			Labels:    map[string]string{"keep": "true"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: controllers.Int32Ptr(1),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "test"},
+			},
+		},
+	}
+
+	c := fake.
+		NewClientBuilder().
+		WithObjects(existing).
+		WithInterceptorFuncs(internaltesting.ServerSideApplyFuncs()).
+		Build()
+
+	p := NewPhaseApplySampleWorkload(
+		nil,
+		WithOperatorName("test"),
+		WithAddonNamespace("test-namespace"),
+	)
+
+	desired := p.desiredDeployment("large", DefaultWorkloadSizeTiers()["large"])
+	desired.Name = "test"
+
+	actual, err := NewDeploymentClientImpl(c).ApplyDeployment(context.Background(), desired)
+	require.NoError(t, err)
+
+	var stored appsv1.Deployment
+
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(existing), &stored))
+
+	assert.Equal(t, actual.ResourceVersion, stored.ResourceVersion)
+	assert.Equal(t, int32(3), *stored.Spec.Replicas)
+	assert.Equal(t, existing.Spec.Selector, stored.Spec.Selector, "selector of existing Deployment must not change")
+	assert.Equal(t, "true", stored.Labels["keep"])
+	assert.Equal(t, "large", stored.Annotations[SizeAnnotation])
+	assert.Equal(t,
+		resource.MustParse("500m"),
+		stored.Spec.Template.Spec.Containers[0].Resources.Limits[corev1.ResourceCPU],
+	)
+}
@@ -2,12 +2,21 @@ package referenceaddon
 
 import (
 	"context"
+	"fmt"
+
+	refv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
+	"github.com/openshift/reference-addon/internal/controllers"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 )
 
 func NewPhaseSendDummyMetrics(sampler ResponseSampler, opts ...PhaseSendDummyMetricsOption) *PhaseSendDummyMetrics {
 	var cfg PhaseSendDummyMetricsConfig
 
 	cfg.Option(opts...)
+	cfg.Default()
 
 	return &PhaseSendDummyMetrics{
 		cfg: cfg,
@@ -22,16 +31,61 @@ type PhaseSendDummyMetrics struct {
 	sampler ResponseSampler
 }
 
+func (p *PhaseSendDummyMetrics) Name() string {
+	return "sendDummyMetrics"
+}
+
 func (p *PhaseSendDummyMetrics) Execute(ctx context.Context, req PhaseRequest) PhaseResult {
-	p.sampler.RequestSampleResponseData(p.cfg.SampleURLs...)
+	urls := p.cfg.SampleURLs
+
+	if override, ok := req.Params.GetSampleURLs(); ok {
+		urls = override
+	}
+
+	if len(urls) == 0 {
+		return PhaseResultSuccess(
+			WithConditions{
+				newMetricsSampledCondition(
+					refv1alpha1.MetricsSampledReasonNoSampleURLs,
+					"no sample URLs configured",
+				),
+			},
+		)
+	}
+
+	if req.Unchanged && meta.IsStatusConditionTrue(req.Addon.Status.Conditions, refv1alpha1.ReferenceAddonConditionMetricsSampled.String()) {
+		// Sampling was already requested for the current parameters.
+		return PhaseResultSuccess()
+	}
 
-	return PhaseResultSuccess()
+	p.sampler.RequestSampleResponseData(req.Addon.Namespace, urls...)
+
+	cond := newMetricsSampledCondition(
+		refv1alpha1.MetricsSampledReasonSampled,
+		fmt.Sprintf("sampled %d URL(s)", len(urls)),
+	)
+
+	recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeNormal, EventReasonMetricsSampled)
+
+	return PhaseResultSuccess(WithConditions{cond})
+}
+
+func newMetricsSampledCondition(reason refv1alpha1.MetricsSampledReason, msg string) metav1.Condition {
+	return newCondition(refv1alpha1.ReferenceAddonConditionMetricsSampled, reason, msg)
 }
 
 type PhaseSendDummyMetricsConfig struct {
+	Recorder record.EventRecorder
+
 	SampleURLs []string
 }
 
+func (c *PhaseSendDummyMetricsConfig) Default() {
+	if c.Recorder == nil {
+		c.Recorder = controllers.NopEventRecorder{}
+	}
+}
+
 func (c *PhaseSendDummyMetricsConfig) Option(opts ...PhaseSendDummyMetricsOption) {
 	for _, opt := range opts {
 		opt.ConfigurePhaseSendDummyMetrics(c)
@@ -42,6 +96,11 @@ type PhaseSendDummyMetricsOption interface {
 	ConfigurePhaseSendDummyMetrics(*PhaseSendDummyMetricsConfig)
 }
 
+// ResponseSampler records the sample metrics of the
+// ReferenceAddon in the given namespace.
 type ResponseSampler interface {
-	RequestSampleResponseData(urls ...string)
+	RequestSampleResponseData(namespace string, urls ...string)
+	// ResetSampleResponseData removes the sample
+	// metrics recorded for the given namespace.
+	ResetSampleResponseData(namespace string)
 }
@@ -4,9 +4,12 @@ import (
 	"context"
 	"testing"
 
+	refv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestPhaseSendDummyMetricsInterface(t *testing.T) {
@@ -19,10 +22,24 @@ func TestPhaseSendDummyMetrics(t *testing.T) {
 	t.Parallel()
 
 	for name, tc := range map[string]struct {
-		SampleURLs []string
+		SampleURLs         []string
+		Params             PhaseRequestParameters
+		ExpectedSampleURLs []string
+		ExpectedReason     refv1alpha1.MetricsSampledReason
 	}{
 		"happy path": {
-			SampleURLs: []string{"https://fake.io"},
+			SampleURLs:         []string{"https://fake.io"},
+			ExpectedSampleURLs: []string{"https://fake.io"},
+			ExpectedReason:     refv1alpha1.MetricsSampledReasonSampled,
+		},
+		"sample urls parameter set": {
+			SampleURLs:         []string{"https://fake.io"},
+			Params:             NewPhaseRequestParameters(WithSampleURLs{"https://override.io"}),
+			ExpectedSampleURLs: []string{"https://override.io"},
+			ExpectedReason:     refv1alpha1.MetricsSampledReasonSampled,
+		},
+		"no sample urls": {
+			ExpectedReason: refv1alpha1.MetricsSampledReasonNoSampleURLs,
 		},
 	} {
 		tc := tc
@@ -30,33 +47,71 @@ func TestPhaseSendDummyMetrics(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			argList := make([]interface{}, 0, len(tc.SampleURLs))
+			argList := make([]interface{}, 0, len(tc.ExpectedSampleURLs)+1)
+			argList = append(argList, "test-namespace")
 
-			for _, url := range tc.SampleURLs {
+			for _, url := range tc.ExpectedSampleURLs {
 				argList = append(argList, url)
 			}
 
 			var sampler ResponseSamplerMock
-			sampler.
-				On("RequestSampleResponseData", argList...).
-				Return()
+
+			if len(tc.ExpectedSampleURLs) > 0 {
+				sampler.
+					On("RequestSampleResponseData", argList...).
+					Return()
+			}
 
 			p := NewPhaseSendDummyMetrics(&sampler, WithSampleURLs(tc.SampleURLs))
 
-			res := p.Execute(context.Background(), PhaseRequest{})
+			res := p.Execute(context.Background(), PhaseRequest{
+				Addon: refv1alpha1.ReferenceAddon{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+				},
+				Params: tc.Params,
+			})
 			require.NoError(t, res.Error())
 
 			assert.Equal(t, PhaseStatusSuccess, res.Status())
+			assertCondition(t, res.Conditions(), refv1alpha1.ReferenceAddonConditionMetricsSampled, tc.ExpectedReason)
+
+			sampler.AssertExpectations(t)
 		})
 	}
 }
 
+func TestPhaseSendDummyMetrics_Unchanged(t *testing.T) {
+	t.Parallel()
+
+	// no expectations: sampling must not be requested again
+	var sampler ResponseSamplerMock
+
+	p := NewPhaseSendDummyMetrics(&sampler, WithSampleURLs{"https://fake.io"})
+
+	var addon refv1alpha1.ReferenceAddon
+
+	meta.SetStatusCondition(&addon.Status.Conditions, newMetricsSampledCondition(
+		refv1alpha1.MetricsSampledReasonSampled,
+		"sampled 1 URL(s)",
+	))
+
+	res := p.Execute(context.Background(), PhaseRequest{
+		Addon:     addon,
+		Unchanged: true,
+	})
+	require.NoError(t, res.Error())
+
+	assert.Equal(t, PhaseStatusSuccess, res.Status())
+	assert.Empty(t, res.Conditions())
+}
+
 type ResponseSamplerMock struct {
 	mock.Mock
 }
 
-func (r *ResponseSamplerMock) RequestSampleResponseData(urls ...string) {
-	argList := make([]interface{}, 0, len(urls))
+func (r *ResponseSamplerMock) RequestSampleResponseData(namespace string, urls ...string) {
+	argList := make([]interface{}, 0, len(urls)+1)
+	argList = append(argList, namespace)
 
 	for _, url := range urls {
 		argList = append(argList, url)
@@ -64,3 +119,7 @@ func (r *ResponseSamplerMock) RequestSampleResponseData(urls ...string) {
 
 	r.Called(argList...)
 }
+
+func (r *ResponseSamplerMock) ResetSampleResponseData(namespace string) {
+	r.Called(namespace)
+}
@@ -4,6 +4,11 @@ import (
 	"context"
 
 	"github.com/go-logr/logr"
+	refv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
+	"github.com/openshift/reference-addon/internal/controllers"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 )
 
 func NewPhaseSmokeTestRun(opts ...PhaseSmokeTestRunOption) *PhaseSmokeTestRun {
@@ -21,29 +26,61 @@ type PhaseSmokeTestRun struct {
 	cfg PhaseSmokeTestRunConfig
 }
 
+func (p *PhaseSmokeTestRun) Name() string {
+	return "smokeTestRun"
+}
+
 func (p *PhaseSmokeTestRun) Execute(_ context.Context, req PhaseRequest) PhaseResult {
 	enableSmokeTest, ok := req.Params.GetEnableSmokeTest()
 	if !ok {
 		p.cfg.Log.V(1).Info("'EnableSmokeTest' parameter not set")
 
-		return PhaseResultSuccess()
+		return PhaseResultSuccess(
+			WithConditions{
+				newSmokeTestConfiguredCondition(
+					refv1alpha1.SmokeTestConfiguredReasonNotConfigured,
+					"'EnableSmokeTest' parameter not set",
+				),
+			},
+		)
 	}
 
-	if enableSmokeTest {
-		p.cfg.SmokeTester.Enable()
+	if !enableSmokeTest {
+		p.cfg.SmokeTester.Disable(req.Addon.Namespace)
+
+		p.cfg.Log.V(1).Info("disabling smoke test")
 
-		p.cfg.Log.Info("enabling smoke test")
-	} else {
-		p.cfg.SmokeTester.Disable()
+		cond := newSmokeTestConfiguredCondition(
+			refv1alpha1.SmokeTestConfiguredReasonDisabled,
+			"smoke test disabled",
+		)
 
-		p.cfg.Log.Info("disabling smoke test")
+		recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeNormal, EventReasonSmokeTestDisabled)
+
+		return PhaseResultSuccess(WithConditions{cond})
 	}
 
-	return PhaseResultSuccess()
+	p.cfg.SmokeTester.Enable(req.Addon.Namespace)
+
+	p.cfg.Log.V(1).Info("enabling smoke test")
+
+	cond := newSmokeTestConfiguredCondition(
+		refv1alpha1.SmokeTestConfiguredReasonEnabled,
+		"smoke test enabled",
+	)
+
+	recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeNormal, EventReasonSmokeTestEnabled)
+
+	return PhaseResultSuccess(WithConditions{cond})
+}
+
+func newSmokeTestConfiguredCondition(reason refv1alpha1.SmokeTestConfiguredReason, msg string) metav1.Condition {
+	return newCondition(refv1alpha1.ReferenceAddonConditionSmokeTestConfigured, reason, msg)
 }
 
 type PhaseSmokeTestRunConfig struct {
-	Log logr.Logger
+	Log      logr.Logger
+	Recorder record.EventRecorder
 
 	SmokeTester SmokeTester
 }
@@ -58,13 +95,22 @@ func (c *PhaseSmokeTestRunConfig) Default() {
 	if c.Log.GetSink() == nil {
 		c.Log = logr.Discard()
 	}
+
+	if c.Recorder == nil {
+		c.Recorder = controllers.NopEventRecorder{}
+	}
 }
 
 type PhaseSmokeTestRunOption interface {
 	ConfigurePhaseSmokeTestRun(*PhaseSmokeTestRunConfig)
 }
 
+// SmokeTester records the smoke test metric of the
+// ReferenceAddon in the given namespace.
 type SmokeTester interface {
-	Enable()
-	Disable()
+	Enable(namespace string)
+	Disable(namespace string)
+	// Reset removes the smoke test metric
+	// recorded for the given namespace.
+	Reset(namespace string)
 }
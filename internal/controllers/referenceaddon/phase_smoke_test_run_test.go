@@ -4,9 +4,11 @@ import (
 	"context"
 	"testing"
 
+	refv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestPhaseSmokeTestRunInterface(t *testing.T) {
@@ -25,15 +27,19 @@ func TestPhaseSmokeTestRun_Execute(t *testing.T) {
 
 	for name, tc := range map[string]struct {
 		EnableSmokeTest *bool
+		ExpectedReason  refv1alpha1.SmokeTestConfiguredReason
 	}{
 		"enablesmoketest 'nil'": {
 			EnableSmokeTest: nil,
+			ExpectedReason:  refv1alpha1.SmokeTestConfiguredReasonNotConfigured,
 		},
 		"enablesmoketest 'false'": {
 			EnableSmokeTest: &f,
+			ExpectedReason:  refv1alpha1.SmokeTestConfiguredReasonDisabled,
 		},
 		"enablesmoketest 'true'": {
 			EnableSmokeTest: &tr,
+			ExpectedReason:  refv1alpha1.SmokeTestConfiguredReasonEnabled,
 		},
 	} {
 		tc := tc
@@ -45,9 +51,9 @@ func TestPhaseSmokeTestRun_Execute(t *testing.T) {
 
 			if tc.EnableSmokeTest != nil {
 				if *tc.EnableSmokeTest {
-					tester.On("Enable")
+					tester.On("Enable", "test-namespace")
 				} else {
-					tester.On("Disable")
+					tester.On("Disable", "test-namespace")
 				}
 			}
 
@@ -57,6 +63,9 @@ func TestPhaseSmokeTestRun_Execute(t *testing.T) {
 			)
 
 			res := phase.Execute(context.Background(), PhaseRequest{
+				Addon: refv1alpha1.ReferenceAddon{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+				},
 				Params: NewPhaseRequestParameters(
 					WithEnableSmokeTest{
 						Value: tc.EnableSmokeTest,
@@ -65,6 +74,7 @@ func TestPhaseSmokeTestRun_Execute(t *testing.T) {
 			})
 
 			assert.Equal(t, PhaseStatusSuccess, res.Status())
+			assertCondition(t, res.Conditions(), refv1alpha1.ReferenceAddonConditionSmokeTestConfigured, tc.ExpectedReason)
 			tester.AssertExpectations(t)
 		})
 	}
@@ -74,10 +84,14 @@ type SmokeTesterMock struct {
 	mock.Mock
 }
 
-func (m *SmokeTesterMock) Enable() {
-	m.Called()
+func (m *SmokeTesterMock) Enable(namespace string) {
+	m.Called(namespace)
 }
 
-func (m *SmokeTesterMock) Disable() {
-	m.Called()
+func (m *SmokeTesterMock) Disable(namespace string) {
+	m.Called(namespace)
+}
+
+func (m *SmokeTesterMock) Reset(namespace string) {
+	m.Called(namespace)
 }
@@ -0,0 +1,263 @@
+package referenceaddon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	av1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	refv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
+	"github.com/openshift/reference-addon/internal/controllers"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewPhaseTeardown(
+	npClient NetworkPolicyClient,
+	sampler ResponseSampler,
+	uninstaller Uninstaller,
+	signaler UninstallSignaler,
+	opts ...PhaseTeardownOption,
+) *PhaseTeardown {
+	var cfg PhaseTeardownConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	return &PhaseTeardown{
+		cfg: cfg,
+
+		npClient:    npClient,
+		sampler:     sampler,
+		uninstaller: uninstaller,
+		signaler:    signaler,
+	}
+}
+
+// PhaseTeardown removes all resources managed on behalf of a ReferenceAddon
+// which is being deleted. Steps are executed in order and are idempotent so
+// that a failed teardown can be resumed by the next reconcile.
+type PhaseTeardown struct {
+	cfg PhaseTeardownConfig
+
+	npClient    NetworkPolicyClient
+	sampler     ResponseSampler
+	uninstaller Uninstaller
+	signaler    UninstallSignaler
+}
+
+func (p *PhaseTeardown) Name() string {
+	return "teardown"
+}
+
+type teardownStep struct {
+	Reason refv1alpha1.TearingDownReason
+	Run    func(ctx context.Context, req PhaseRequest, report *refv1alpha1.UninstallStepReport) error
+}
+
+func (p *PhaseTeardown) Execute(ctx context.Context, req PhaseRequest) PhaseResult {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	steps := []teardownStep{
+		{Reason: refv1alpha1.TearingDownReasonRemovingNetworkPolicies, Run: p.removeNetworkPolicies},
+		{Reason: refv1alpha1.TearingDownReasonResettingMetrics, Run: p.resetMetrics},
+		{Reason: refv1alpha1.TearingDownReasonDeletingCSVs, Run: p.deleteCSVs},
+	}
+
+	report := &refv1alpha1.UninstallReport{
+		Steps: make([]refv1alpha1.UninstallStepReport, 0, len(steps)),
+	}
+
+	for _, step := range steps {
+		report.Steps = append(report.Steps, refv1alpha1.UninstallStepReport{
+			Name:   step.Reason,
+			Result: refv1alpha1.UninstallStepResultPending,
+		})
+	}
+
+	for i, step := range steps {
+		log := p.cfg.Log.WithValues("step", step.Reason.String())
+
+		log.Info("executing teardown step")
+
+		stepReport := &report.Steps[i]
+
+		if err := step.Run(ctx, req, stepReport); err != nil {
+			log.Error(err, "teardown step failed")
+
+			stepReport.Result = refv1alpha1.UninstallStepResultFailed
+			stepReport.Message = err.Error()
+
+			return PhaseResultError(
+				fmt.Errorf("executing teardown step %q: %w", step.Reason, err),
+				WithConditions{
+					newTearingDownCondition(step.Reason, fmt.Sprintf("failed: %s", err)),
+				},
+				WithUninstallReport{Report: report},
+			)
+		}
+
+		if stepReport.Result == refv1alpha1.UninstallStepResultPending {
+			stepReport.Result = refv1alpha1.UninstallStepResultSucceeded
+		}
+	}
+
+	report.Complete = true
+
+	return PhaseResultSuccess(
+		WithConditions{
+			newTearingDownCondition(
+				refv1alpha1.TearingDownReasonReleasingFinalizer,
+				"all teardown steps completed",
+			),
+		},
+		WithUninstallReport{Report: report},
+	)
+}
+
+func (p *PhaseTeardown) removeNetworkPolicies(ctx context.Context, req PhaseRequest, report *refv1alpha1.UninstallStepReport) error {
+	for _, policy := range p.cfg.Policies {
+		report.Found = append(report.Found, policy.Name)
+	}
+
+	if err := p.npClient.RemoveNetworkPolicies(ctx, p.cfg.Policies...); err != nil {
+		return fmt.Errorf("removing NetworkPolicies: %w", err)
+	}
+
+	report.Removed = report.Found
+
+	p.cfg.Recorder.Eventf(&req.Addon, corev1.EventTypeNormal, EventReasonNetworkPoliciesRemoved,
+		"removed %d NetworkPolicies", len(p.cfg.Policies),
+	)
+
+	return nil
+}
+
+func (p *PhaseTeardown) resetMetrics(_ context.Context, _ PhaseRequest, _ *refv1alpha1.UninstallStepReport) error {
+	p.cfg.SmokeTester.Reset(p.cfg.AddonNamespace)
+	p.sampler.ResetSampleResponseData(p.cfg.AddonNamespace)
+
+	return nil
+}
+
+func (p *PhaseTeardown) deleteCSVs(ctx context.Context, req PhaseRequest, report *refv1alpha1.UninstallStepReport) error {
+	// Deleting a ReferenceAddon alone must not remove the operator.
+	if !p.signaler.SignalUninstall(ctx) {
+		p.cfg.Log.Info("uninstall not signaled; skipping ClusterServiceVersion removal")
+
+		report.Result = refv1alpha1.UninstallStepResultSkipped
+		report.Message = "uninstall has not been signaled"
+
+		return nil
+	}
+
+	if p.cfg.DryRun {
+		p.cfg.Log.Info("dry run enabled; skipping ClusterServiceVersion removal")
+
+		report.Result = refv1alpha1.UninstallStepResultSkipped
+		report.Message = "uninstall dry run is enabled"
+
+		return nil
+	}
+
+	res, err := p.uninstaller.Uninstall(ctx, WithNamespace(p.cfg.AddonNamespace), WithCSVMatcher{Matcher: p.cfg.CSVMatcher})
+
+	report.Found = res.Found
+	report.Removed = res.Removed
+
+	if err != nil {
+		return fmt.Errorf("uninstalling addon: %w", err)
+	}
+
+	if len(res.Removed) > 0 {
+		p.cfg.Recorder.Eventf(&req.Addon, corev1.EventTypeNormal, EventReasonCSVsDeleted,
+			"deleted ClusterServiceVersions: %s", strings.Join(res.Removed, ", "),
+		)
+	}
+
+	return nil
+}
+
+func newTearingDownCondition(reason refv1alpha1.TearingDownReason, msg string) metav1.Condition {
+	return newCondition(refv1alpha1.ReferenceAddonConditionTearingDown, reason, msg)
+}
+
+type PhaseTeardownConfig struct {
+	Log      logr.Logger
+	Recorder record.EventRecorder
+
+	AddonNamespace string
+	OperatorName   string
+	CSVMatcher     controllers.CSVMatcher
+	Policies       []netv1.NetworkPolicy
+	SmokeTester    SmokeTester
+	DryRun         bool
+}
+
+func (c *PhaseTeardownConfig) Option(opts ...PhaseTeardownOption) {
+	for _, opt := range opts {
+		opt.ConfigurePhaseTeardown(c)
+	}
+}
+
+func (c *PhaseTeardownConfig) Default() {
+	if c.Log.GetSink() == nil {
+		c.Log = logr.Discard()
+	}
+
+	if c.Recorder == nil {
+		c.Recorder = controllers.NopEventRecorder{}
+	}
+
+	if c.CSVMatcher.Package == "" {
+		c.CSVMatcher.Package = c.OperatorName
+	}
+}
+
+type PhaseTeardownOption interface {
+	ConfigurePhaseTeardown(*PhaseTeardownConfig)
+}
+
+// TeardownAcknowledger reports whether a completed teardown has been
+// propagated to the AddonInstance so that the ReferenceAddon may be removed.
+type TeardownAcknowledger interface {
+	TeardownAcknowledged(ctx context.Context) (bool, error)
+}
+
+func NewAddonInstanceTeardownAcknowledger(client client.Client, key types.NamespacedName) *AddonInstanceTeardownAcknowledger {
+	return &AddonInstanceTeardownAcknowledger{
+		client: client,
+		key:    key,
+	}
+}
+
+// AddonInstanceTeardownAcknowledger acknowledges a teardown once the
+// AddonInstance reports a True ReadyToBeDeleted condition. A missing
+// AddonInstance acknowledges every teardown since there is nothing to
+// report the completion to.
+type AddonInstanceTeardownAcknowledger struct {
+	client client.Client
+	key    types.NamespacedName
+}
+
+func (a *AddonInstanceTeardownAcknowledger) TeardownAcknowledged(ctx context.Context) (bool, error) {
+	var ai av1alpha1.AddonInstance
+
+	if err := a.client.Get(ctx, a.key, &ai); apierrors.IsNotFound(err) {
+		return true, nil
+	} else if err != nil {
+		return false, fmt.Errorf("getting AddonInstance: %w", err)
+	}
+
+	condT := av1alpha1.AddonInstanceConditionReadyToBeDeleted.String()
+
+	return meta.IsStatusConditionTrue(ai.Status.Conditions, condT), nil
+}
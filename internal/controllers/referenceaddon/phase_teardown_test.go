@@ -0,0 +1,263 @@
+package referenceaddon
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	av1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	refv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
+	"github.com/openshift/reference-addon/internal/controllers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPhaseTeardownInterfaces(t *testing.T) {
+	t.Parallel()
+
+	require.Implements(t, new(Phase), new(PhaseTeardown))
+	require.Implements(t, new(TeardownAcknowledger), new(AddonInstanceTeardownAcknowledger))
+}
+
+func TestAddonInstanceTeardownAcknowledger(t *testing.T) {
+	t.Parallel()
+
+	key := types.NamespacedName{Namespace: "test-namespace", Name: "addon-instance"}
+
+	addonInstance := func(status metav1.ConditionStatus) *av1alpha1.AddonInstance {
+		ai := &av1alpha1.AddonInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+		}
+
+		meta.SetStatusCondition(&ai.Status.Conditions, metav1.Condition{
+			Type:   av1alpha1.AddonInstanceConditionReadyToBeDeleted.String(),
+			Status: status,
+			Reason: "Test",
+		})
+
+		return ai
+	}
+
+	for name, tc := range map[string]struct {
+		Objects              []client.Object
+		ExpectedAcknowledged bool
+	}{
+		"AddonInstance not found": {
+			ExpectedAcknowledged: true,
+		},
+		"ReadyToBeDeleted False": {
+			Objects:              []client.Object{addonInstance(metav1.ConditionFalse)},
+			ExpectedAcknowledged: false,
+		},
+		"ReadyToBeDeleted True": {
+			Objects:              []client.Object{addonInstance(metav1.ConditionTrue)},
+			ExpectedAcknowledged: true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			scheme := runtime.NewScheme()
+			require.NoError(t, av1alpha1.AddToScheme(scheme))
+
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tc.Objects...).
+				Build()
+
+			acked, err := NewAddonInstanceTeardownAcknowledger(c, key).TeardownAcknowledged(context.Background())
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.ExpectedAcknowledged, acked)
+		})
+	}
+}
+
+func TestPhaseTeardown(t *testing.T) {
+	t.Parallel()
+
+	policy := netv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-policy",
+			Namespace: "test-namespace",
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		Signaled       bool
+		DryRun         bool
+		RemoveError    error
+		UninstallError error
+		ExpectedStatus PhaseStatus
+		ExpectedReason refv1alpha1.TearingDownReason
+		ExpectedEvents []string
+		ExpectedSteps  []refv1alpha1.UninstallStepResult
+	}{
+		"uninstall signaled": {
+			Signaled:       true,
+			ExpectedStatus: PhaseStatusSuccess,
+			ExpectedReason: refv1alpha1.TearingDownReasonReleasingFinalizer,
+			ExpectedEvents: []string{
+				"Normal NetworkPoliciesRemoved removed 1 NetworkPolicies",
+				"Normal CSVsDeleted deleted ClusterServiceVersions: test-operator.v0.0.0",
+			},
+			ExpectedSteps: []refv1alpha1.UninstallStepResult{
+				refv1alpha1.UninstallStepResultSucceeded,
+				refv1alpha1.UninstallStepResultSucceeded,
+				refv1alpha1.UninstallStepResultSucceeded,
+			},
+		},
+		"uninstall not signaled": {
+			Signaled:       false,
+			ExpectedStatus: PhaseStatusSuccess,
+			ExpectedReason: refv1alpha1.TearingDownReasonReleasingFinalizer,
+			ExpectedEvents: []string{
+				"Normal NetworkPoliciesRemoved removed 1 NetworkPolicies",
+			},
+			ExpectedSteps: []refv1alpha1.UninstallStepResult{
+				refv1alpha1.UninstallStepResultSucceeded,
+				refv1alpha1.UninstallStepResultSucceeded,
+				refv1alpha1.UninstallStepResultSkipped,
+			},
+		},
+		"uninstall signaled in dry run": {
+			Signaled:       true,
+			DryRun:         true,
+			ExpectedStatus: PhaseStatusSuccess,
+			ExpectedReason: refv1alpha1.TearingDownReasonReleasingFinalizer,
+			ExpectedEvents: []string{
+				"Normal NetworkPoliciesRemoved removed 1 NetworkPolicies",
+			},
+			ExpectedSteps: []refv1alpha1.UninstallStepResult{
+				refv1alpha1.UninstallStepResultSucceeded,
+				refv1alpha1.UninstallStepResultSucceeded,
+				refv1alpha1.UninstallStepResultSkipped,
+			},
+		},
+		"removing network policies fails": {
+			RemoveError:    errors.New("test error"),
+			ExpectedStatus: PhaseStatusError,
+			ExpectedReason: refv1alpha1.TearingDownReasonRemovingNetworkPolicies,
+			ExpectedSteps: []refv1alpha1.UninstallStepResult{
+				refv1alpha1.UninstallStepResultFailed,
+				refv1alpha1.UninstallStepResultPending,
+				refv1alpha1.UninstallStepResultPending,
+			},
+		},
+		"deleting csvs fails": {
+			Signaled:       true,
+			UninstallError: errors.New("test error"),
+			ExpectedStatus: PhaseStatusError,
+			ExpectedReason: refv1alpha1.TearingDownReasonDeletingCSVs,
+			ExpectedEvents: []string{
+				"Normal NetworkPoliciesRemoved removed 1 NetworkPolicies",
+			},
+			ExpectedSteps: []refv1alpha1.UninstallStepResult{
+				refv1alpha1.UninstallStepResultSucceeded,
+				refv1alpha1.UninstallStepResultSucceeded,
+				refv1alpha1.UninstallStepResultFailed,
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var npClient NetworkPolicyClientMock
+
+			npClient.
+				On("RemoveNetworkPolicies", mock.Anything, policy).
+				Return(tc.RemoveError)
+
+			var (
+				sampler     ResponseSamplerMock
+				tester      SmokeTesterMock
+				signaler    uninstallSignalerMock
+				uninstaller uninstallerMock
+			)
+
+			if tc.RemoveError == nil {
+				sampler.On("ResetSampleResponseData", "test-namespace")
+				tester.On("Reset", "test-namespace")
+				signaler.
+					On("SignalUninstall", mock.Anything).
+					Return(tc.Signaled)
+			}
+
+			if tc.Signaled && !tc.DryRun {
+				res := UninstallResult{Found: []string{"test-operator.v0.0.0"}}
+
+				if tc.UninstallError == nil {
+					res.Removed = res.Found
+				}
+
+				uninstaller.
+					On("Uninstall", mock.Anything, WithNamespace("test-namespace"), WithCSVMatcher{Matcher: controllers.CSVMatcher{Package: "test-operator"}}).
+					Return(res, tc.UninstallError)
+			}
+
+			recorder := record.NewFakeRecorder(10)
+
+			p := NewPhaseTeardown(
+				&npClient,
+				&sampler,
+				&uninstaller,
+				&signaler,
+				WithAddonNamespace("test-namespace"),
+				WithOperatorName("test-operator"),
+				WithUninstallDryRun(tc.DryRun),
+				WithPolicies{policy},
+				WithSmokeTester{Tester: &tester},
+				WithEventRecorder{Recorder: recorder},
+			)
+
+			res := p.Execute(context.Background(), PhaseRequest{})
+
+			assert.Equal(t, tc.ExpectedStatus, res.Status())
+			assertCondition(t, res.Conditions(), refv1alpha1.ReferenceAddonConditionTearingDown, tc.ExpectedReason)
+
+			report := res.UninstallReport()
+			require.NotNil(t, report)
+			assert.Equal(t, tc.ExpectedStatus == PhaseStatusSuccess, report.Complete)
+
+			steps := make([]refv1alpha1.UninstallStepResult, 0, len(report.Steps))
+
+			for _, step := range report.Steps {
+				steps = append(steps, step.Result)
+			}
+
+			assert.Equal(t, tc.ExpectedSteps, steps)
+
+			close(recorder.Events)
+
+			var events []string
+
+			for e := range recorder.Events {
+				events = append(events, e)
+			}
+
+			assert.Equal(t, tc.ExpectedEvents, events)
+
+			npClient.AssertExpectations(t)
+			sampler.AssertExpectations(t)
+			tester.AssertExpectations(t)
+			signaler.AssertExpectations(t)
+			uninstaller.AssertExpectations(t)
+		})
+	}
+}
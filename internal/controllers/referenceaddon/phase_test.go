@@ -0,0 +1,145 @@
+package referenceaddon
+
+import (
+	"encoding/json"
+	"testing"
+
+	refv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
+	"github.com/openshift/reference-addon/internal/controllers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPhaseRequestParameters_OverrideWithSpec(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Params              PhaseRequestParameters
+		Spec                refv1alpha1.ReferenceAddonSpec
+		ExpectedParams      PhaseRequestParameters
+		ExpectedInvalidKeys []string
+	}{
+		"empty spec/empty params": {
+			Params:         NewPhaseRequestParameters(),
+			Spec:           refv1alpha1.ReferenceAddonSpec{},
+			ExpectedParams: NewPhaseRequestParameters(),
+		},
+		"empty spec/params set": {
+			Params: NewPhaseRequestParameters(
+				WithApplyNetworkPolicies{Value: controllers.BoolPtr(true)},
+				WithSize{Value: controllers.StringPtr("small")},
+			),
+			Spec: refv1alpha1.ReferenceAddonSpec{},
+			ExpectedParams: NewPhaseRequestParameters(
+				WithApplyNetworkPolicies{Value: controllers.BoolPtr(true)},
+				WithSize{Value: controllers.StringPtr("small")},
+			),
+		},
+		"spec set/empty params": {
+			Params: NewPhaseRequestParameters(),
+			Spec: refv1alpha1.ReferenceAddonSpec{
+				EnableSmokeTest: controllers.BoolPtr(true),
+				SampleURLs:      []string{"https://fake.io"},
+			},
+			ExpectedParams: NewPhaseRequestParameters(
+				WithEnableSmokeTest{Value: controllers.BoolPtr(true)},
+				WithSampleURLs{"https://fake.io"},
+				WithParameterSource{Key: enableSmokeTestID, Source: ParameterSourceSpec},
+				WithParameterSource{Key: sampleURLsParameterID, Source: ParameterSourceSpec},
+			),
+		},
+		"spec overrides params": {
+			Params: NewPhaseRequestParameters(
+				WithApplyNetworkPolicies{Value: controllers.BoolPtr(true)},
+				WithEnableSmokeTest{Value: controllers.BoolPtr(true)},
+				WithSize{Value: controllers.StringPtr("small")},
+			),
+			Spec: refv1alpha1.ReferenceAddonSpec{
+				ApplyNetworkPolicies: controllers.BoolPtr(false),
+				Size:                 controllers.StringPtr("large"),
+			},
+			ExpectedParams: NewPhaseRequestParameters(
+				WithApplyNetworkPolicies{Value: controllers.BoolPtr(false)},
+				WithEnableSmokeTest{Value: controllers.BoolPtr(true)},
+				WithSize{Value: controllers.StringPtr("large")},
+				WithParameterSource{Key: applyNetworkPoliciesID, Source: ParameterSourceSpec},
+				WithParameterSource{Key: sizeParameterID, Source: ParameterSourceSpec},
+			),
+		},
+		"spec custom size": {
+			Params: NewPhaseRequestParameters(),
+			Spec: refv1alpha1.ReferenceAddonSpec{
+				Size:       controllers.StringPtr(AddonSizeCustom),
+				CustomSize: &refv1alpha1.WorkloadSize{Replicas: 2},
+			},
+			ExpectedParams: NewPhaseRequestParameters(
+				WithSize{Value: controllers.StringPtr(AddonSizeCustom)},
+				WithParameter{Key: customSizeParameterID, Value: json.RawMessage(`{"replicas":2,"resources":{}}`)},
+				WithParameterSource{Key: sizeParameterID, Source: ParameterSourceSpec},
+				WithParameterSource{Key: customSizeParameterID, Source: ParameterSourceSpec},
+			),
+		},
+		"spec network policies": {
+			Params: NewPhaseRequestParameters(
+				WithNetworkPolicies{DefaultNetworkPolicyTemplateName},
+			),
+			Spec: refv1alpha1.ReferenceAddonSpec{
+				NetworkPolicies: []string{"allow-dns", "allow-from-cidrs"},
+				AllowedCIDRs:    []string{"10.0.0.0/8"},
+			},
+			ExpectedParams: NewPhaseRequestParameters(
+				WithNetworkPolicies{"allow-dns", "allow-from-cidrs"},
+				WithAllowedCIDRs{"10.0.0.0/8"},
+				WithParameterSource{Key: networkPoliciesID, Source: ParameterSourceSpec},
+				WithParameterSource{Key: allowedCIDRsID, Source: ParameterSourceSpec},
+			),
+		},
+		"spec invalid CIDR": {
+			Params: NewPhaseRequestParameters(
+				WithAllowedCIDRs{"10.0.0.0/8"},
+			),
+			Spec: refv1alpha1.ReferenceAddonSpec{
+				AllowedCIDRs: []string{"10.0.0.0/33"},
+			},
+			ExpectedParams: NewPhaseRequestParameters(
+				WithAllowedCIDRs{"10.0.0.0/8"},
+			),
+			ExpectedInvalidKeys: []string{allowedCIDRsID},
+		},
+		"spec invalid sample URL": {
+			Params: NewPhaseRequestParameters(),
+			Spec: refv1alpha1.ReferenceAddonSpec{
+				EnableSmokeTest: controllers.BoolPtr(true),
+				SampleURLs:      []string{"fake.io"},
+			},
+			ExpectedParams: NewPhaseRequestParameters(
+				WithEnableSmokeTest{Value: controllers.BoolPtr(true)},
+				WithParameterSource{Key: enableSmokeTestID, Source: ParameterSourceSpec},
+			),
+			ExpectedInvalidKeys: []string{sampleURLsParameterID},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			params, err := tc.Params.OverrideWithSpec(tc.Spec, DefaultParameterRegistry())
+
+			assert.Equal(t, tc.ExpectedParams, params)
+			assert.Equal(t, tc.ExpectedInvalidKeys, InvalidParameterKeys(err))
+		})
+	}
+}
+
+func assertCondition(t *testing.T, conds []metav1.Condition, condT refv1alpha1.ReferenceAddonCondition, reason conditionReason) {
+	t.Helper()
+
+	cond := meta.FindStatusCondition(conds, condT.String())
+	require.NotNil(t, cond, "condition %q not found", condT)
+
+	assert.Equal(t, reason.String(), cond.Reason)
+	assert.Equal(t, reason.Status(), cond.Status)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	refv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
@@ -12,11 +13,14 @@ import (
 	"go.uber.org/multierr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func NewPhaseUninstall(signaler UninstallSignaler, uninstaller Uninstaller, opts ...PhaseUninstallOption) *PhaseUninstall {
+func NewPhaseUninstall(signaler UninstallSignaler, deleter AddonDeleter, lister CSVClient, opts ...PhaseUninstallOption) *PhaseUninstall {
 	var cfg PhaseUninstallConfig
 
 	cfg.Option(opts...)
@@ -25,45 +29,168 @@ func NewPhaseUninstall(signaler UninstallSignaler, uninstaller Uninstaller, opts
 	return &PhaseUninstall{
 		cfg: cfg,
 
-		signaler:    signaler,
-		uninstaller: uninstaller,
+		signaler: signaler,
+		deleter:  deleter,
+		lister:   lister,
 	}
 }
 
+// PhaseUninstall deletes the ReferenceAddon once an uninstall has been
+// signaled and the configured grace period has elapsed. Removal of all
+// managed resources, including the operator's ClusterServiceVersions,
+// is then carried out by PhaseTeardown before the ReferenceAddon
+// finalizer is released.
 type PhaseUninstall struct {
 	cfg PhaseUninstallConfig
 
-	signaler    UninstallSignaler
-	uninstaller Uninstaller
+	signaler UninstallSignaler
+	deleter  AddonDeleter
+	lister   CSVClient
+}
+
+func (p *PhaseUninstall) Name() string {
+	return "uninstall"
 }
 
 func (p *PhaseUninstall) Execute(ctx context.Context, req PhaseRequest) PhaseResult {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	scheduledAt := req.Addon.Status.UninstallScheduledAt
+
 	if !p.signaler.SignalUninstall(ctx) {
-		return PhaseResultSuccess()
+		if scheduledAt == nil {
+			return PhaseResultSuccess(
+				WithConditions{
+					newUninstallPendingCondition(
+						refv1alpha1.UninstallPendingReasonNotRequested,
+						"uninstall has not been signaled",
+					),
+				},
+				WithUninstallSchedule{},
+			)
+		}
+
+		p.cfg.Log.Info("uninstall signal withdrawn; aborting scheduled uninstall")
+
+		cond := newUninstallPendingCondition(
+			refv1alpha1.UninstallPendingReasonAborted,
+			fmt.Sprintf("uninstall scheduled at %s was aborted", scheduledAt.UTC().Format(time.RFC3339)),
+		)
+
+		recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeNormal, EventReasonUninstallAborted)
+
+		return PhaseResultSuccess(WithConditions{cond}, WithUninstallSchedule{})
+	}
+
+	if p.cfg.DryRun {
+		return p.dryRun(ctx, req)
+	}
+
+	now := p.cfg.Clock.Now()
+
+	if p.cfg.GracePeriod > 0 {
+		if scheduledAt == nil {
+			scheduledAt = &metav1.Time{Time: now.Add(p.cfg.GracePeriod)}
+		}
+
+		if remaining := scheduledAt.Sub(now); remaining > 0 {
+			p.cfg.Log.Info("uninstall scheduled", "scheduledAt", scheduledAt.Time)
+
+			cond := newUninstallPendingCondition(
+				refv1alpha1.UninstallPendingReasonScheduled,
+				fmt.Sprintf(
+					"uninstall proceeds at %s unless the uninstall signal is withdrawn",
+					scheduledAt.UTC().Format(time.RFC3339),
+				),
+			)
+
+			recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeWarning, EventReasonUninstallScheduled)
+
+			return PhaseResultSuccess(
+				WithConditions{cond},
+				WithUninstallSchedule{At: scheduledAt},
+				WithRequeueAfter(remaining),
+			)
+		}
 	}
 
-	if err := p.uninstaller.Uninstall(ctx, p.cfg.AddonNamespace, p.cfg.OperatorName); err != nil {
-		return PhaseResultError(fmt.Errorf("uninstalling addon: %w", err))
+	p.cfg.Log.Info("uninstall signaled; deleting ReferenceAddon")
+
+	if err := p.deleter.Delete(ctx, &req.Addon); err != nil {
+		cond := newUninstallPendingCondition(
+			refv1alpha1.UninstallPendingReasonFailed,
+			err.Error(),
+		)
+
+		recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeWarning, EventReasonUninstallFailed)
+
+		return PhaseResultError(
+			fmt.Errorf("deleting ReferenceAddon: %w", err),
+			WithConditions{cond},
+		)
 	}
 
+	cond := newUninstallPendingCondition(
+		refv1alpha1.UninstallPendingReasonInProgress,
+		"uninstallation started",
+	)
+
+	recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeNormal, EventReasonUninstallStarted)
+
 	return PhaseResultBlocking(
 		WithConditions{
 			newAvailableCondition(
 				refv1alpha1.ReferenceAddonAvailableReasonUninstalling,
 				"uninstallation started",
 			),
+			cond,
 		},
 	)
 }
 
+// dryRun reports the ClusterServiceVersions which would be
+// removed by an uninstall without removing anything.
+func (p *PhaseUninstall) dryRun(ctx context.Context, req PhaseRequest) PhaseResult {
+	csvs, err := p.lister.ListCSVs(ctx, WithNamespace(p.cfg.AddonNamespace), WithCSVMatcher{Matcher: p.cfg.CSVMatcher})
+	if err != nil {
+		return PhaseResultError(fmt.Errorf("listing ClusterServiceVersions: %w", err))
+	}
+
+	names := make([]string, 0, len(csvs))
+
+	for _, csv := range csvs {
+		names = append(names, csv.Name)
+	}
+
+	msg := "dry run: no ClusterServiceVersions would be deleted"
+	if len(names) > 0 {
+		msg = fmt.Sprintf("dry run: would delete ClusterServiceVersions: %s", strings.Join(names, ", "))
+	}
+
+	p.cfg.Log.Info("uninstall signaled in dry run mode", "clusterServiceVersions", names)
+
+	cond := newUninstallPendingCondition(refv1alpha1.UninstallPendingReasonDryRun, msg)
+
+	recordConditionEvent(p.cfg.Recorder, &req.Addon, cond, corev1.EventTypeNormal, EventReasonUninstallDryRun)
+
+	return PhaseResultSuccess(WithConditions{cond}, WithUninstallSchedule{})
+}
+
+func newUninstallPendingCondition(reason refv1alpha1.UninstallPendingReason, msg string) metav1.Condition {
+	return newCondition(refv1alpha1.ReferenceAddonConditionUninstallPending, reason, msg)
+}
+
 type PhaseUninstallConfig struct {
-	Log logr.Logger
+	Log      logr.Logger
+	Recorder record.EventRecorder
+	Clock    clock.PassiveClock
 
 	AddonNamespace string
 	OperatorName   string
+	CSVMatcher     controllers.CSVMatcher
+	GracePeriod    time.Duration
+	DryRun         bool
 }
 
 func (c *PhaseUninstallConfig) Option(opts ...PhaseUninstallOption) {
@@ -76,14 +203,40 @@ func (c *PhaseUninstallConfig) Default() {
 	if c.Log.GetSink() == nil {
 		c.Log = logr.Discard()
 	}
+
+	if c.Recorder == nil {
+		c.Recorder = controllers.NopEventRecorder{}
+	}
+
+	if c.Clock == nil {
+		c.Clock = clock.RealClock{}
+	}
+
+	if c.CSVMatcher.Package == "" {
+		c.CSVMatcher.Package = c.OperatorName
+	}
 }
 
 type PhaseUninstallOption interface {
 	ConfigurePhaseUninstall(*PhaseUninstallConfig)
 }
 
+type AddonDeleter interface {
+	Delete(ctx context.Context, addon *refv1alpha1.ReferenceAddon) error
+}
+
 type Uninstaller interface {
-	Uninstall(ctx context.Context, namespace, operatorName string) error
+	// Uninstall removes the operator's ClusterServiceVersions selected
+	// by the given options and reports which were found and removed.
+	// The result is populated as far as possible when an error is returned.
+	Uninstall(ctx context.Context, opts ...ListCSVsOption) (UninstallResult, error)
+}
+
+// UninstallResult lists the names of the ClusterServiceVersions
+// handled by an Uninstaller.
+type UninstallResult struct {
+	Found   []string
+	Removed []string
 }
 
 func NewUninstallerImpl(client CSVClient, opts ...UninstallerImplOption) *UninstallerImpl {
@@ -105,20 +258,30 @@ type UninstallerImpl struct {
 	client CSVClient
 }
 
-func (u UninstallerImpl) Uninstall(ctx context.Context, namespace, operatorName string) error {
+func (u UninstallerImpl) Uninstall(ctx context.Context, opts ...ListCSVsOption) (UninstallResult, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	csvs, err := u.client.ListCSVs(ctx, WithNamespace(namespace), WithPrefix(operatorName))
+	var res UninstallResult
+
+	csvs, err := u.client.ListCSVs(ctx, opts...)
 	if err != nil {
-		return fmt.Errorf("listing ClusterServiceVersions with name %q: %w", operatorName, err)
+		return res, fmt.Errorf("listing ClusterServiceVersions: %w", err)
+	}
+
+	res.Found = make([]string, 0, len(csvs))
+
+	for _, csv := range csvs {
+		res.Found = append(res.Found, csv.Name)
 	}
 
 	if err := u.client.RemoveCSVs(ctx, csvs...); err != nil {
-		return fmt.Errorf("removing csvs: %w", err)
+		return res, fmt.Errorf("removing csvs: %w", err)
 	}
 
-	return nil
+	res.Removed = res.Found
+
+	return res, nil
 }
 
 type UninstallerImplConfig struct {
@@ -148,7 +311,7 @@ type CSVClient interface {
 
 type ListCSVsConfig struct {
 	Namespace string
-	Prefix    string
+	Matcher   controllers.CSVMatcher
 }
 
 func (c *ListCSVsConfig) Option(opts ...ListCSVsOption) {
@@ -191,6 +354,10 @@ func (c *CSVClientImpl) ListCSVs(ctx context.Context, opts ...ListCSVsOption) ([
 		listOptions = append(listOptions, client.InNamespace(cfg.Namespace))
 	}
 
+	if cfg.Matcher.Selector != nil {
+		listOptions = append(listOptions, client.MatchingLabelsSelector{Selector: cfg.Matcher.Selector})
+	}
+
 	var csvs opsv1alpha1.ClusterServiceVersionList
 
 	if err := c.client.List(ctx, &csvs, listOptions...); err != nil {
@@ -200,7 +367,7 @@ func (c *CSVClientImpl) ListCSVs(ctx context.Context, opts ...ListCSVsOption) ([
 	var res []opsv1alpha1.ClusterServiceVersion
 
 	for _, csv := range csvs.Items {
-		if !strings.HasPrefix(csv.Name, cfg.Prefix) {
+		if !cfg.Matcher.Matches(&csv) {
 			continue
 		}
 
@@ -256,6 +423,7 @@ func NewConfigMapUninstallSignaler(client client.Client, opts ...ConfigMapUninst
 	var cfg ConfigMapUninstallSignalerConfig
 
 	cfg.Option(opts...)
+	cfg.Default()
 
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("validating config: %w", err)
@@ -283,6 +451,8 @@ func (s *ConfigMapUninstallSignaler) SignalUninstall(ctx context.Context) bool {
 	var cm corev1.ConfigMap
 
 	if err := s.client.Get(ctx, tgt, &cm); err != nil {
+		logSignalerError(s.cfg.Log, err, "getting ConfigMap", tgt)
+
 		return false
 	}
 
@@ -292,6 +462,7 @@ func (s *ConfigMapUninstallSignaler) SignalUninstall(ctx context.Context) bool {
 }
 
 type ConfigMapUninstallSignalerConfig struct {
+	Log            logr.Logger
 	AddonNamespace string
 	OperatorName   string
 	DeleteLabel    string
@@ -303,6 +474,12 @@ func (c *ConfigMapUninstallSignalerConfig) Option(opts ...ConfigMapUninstallSign
 	}
 }
 
+func (c *ConfigMapUninstallSignalerConfig) Default() {
+	if c.Log.GetSink() == nil {
+		c.Log = logr.Discard()
+	}
+}
+
 func (c *ConfigMapUninstallSignalerConfig) Validate() error {
 	var finalErr error
 
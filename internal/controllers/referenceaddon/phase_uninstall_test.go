@@ -2,15 +2,22 @@ package referenceaddon
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
+	refv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
+	"github.com/openshift/reference-addon/internal/controllers"
 	opsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -24,15 +31,100 @@ func TestPhaseUninstallInterfaces(t *testing.T) {
 func TestPhaseUninstall(t *testing.T) {
 	t.Parallel()
 
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	past := metav1.NewTime(now.Add(-time.Minute))
+	future := metav1.NewTime(now.Add(time.Minute))
+
 	for name, tc := range map[string]struct {
-		AddonNamespace string
-		OperatorName   string
-		DeleteLabel    string
+		Signaled             bool
+		DeleteError          error
+		GracePeriod          time.Duration
+		DryRun               bool
+		ScheduledAt          *metav1.Time
+		ExpectDelete         bool
+		ExpectedStatus       PhaseStatus
+		ExpectedReason       refv1alpha1.UninstallPendingReason
+		ExpectedScheduledAt  *metav1.Time
+		ExpectedRequeueAfter time.Duration
+		ExpectedEvents       []string
 	}{
 		"happy path": {
-			AddonNamespace: "test-namespace",
-			OperatorName:   "test-operator",
-			DeleteLabel:    "addon-test-operator-delete",
+			Signaled:       true,
+			ExpectDelete:   true,
+			ExpectedStatus: PhaseStatusBlocking,
+			ExpectedReason: refv1alpha1.UninstallPendingReasonInProgress,
+			ExpectedEvents: []string{
+				"Normal UninstallStarted uninstallation started",
+			},
+		},
+		"delete fails": {
+			Signaled:       true,
+			DeleteError:    errors.New("test error"),
+			ExpectDelete:   true,
+			ExpectedStatus: PhaseStatusError,
+			ExpectedReason: refv1alpha1.UninstallPendingReasonFailed,
+			ExpectedEvents: []string{
+				"Warning UninstallFailed test error",
+			},
+		},
+		"uninstall not signaled": {
+			Signaled:       false,
+			ExpectedStatus: PhaseStatusSuccess,
+			ExpectedReason: refv1alpha1.UninstallPendingReasonNotRequested,
+		},
+		"grace period/newly signaled": {
+			Signaled:             true,
+			GracePeriod:          time.Minute,
+			ExpectedStatus:       PhaseStatusSuccess,
+			ExpectedReason:       refv1alpha1.UninstallPendingReasonScheduled,
+			ExpectedScheduledAt:  &future,
+			ExpectedRequeueAfter: time.Minute,
+			ExpectedEvents: []string{
+				"Warning UninstallScheduled uninstall proceeds at 2024-01-01T00:01:00Z unless the uninstall signal is withdrawn",
+			},
+		},
+		"grace period/not elapsed": {
+			Signaled:             true,
+			GracePeriod:          time.Minute,
+			ScheduledAt:          &future,
+			ExpectedStatus:       PhaseStatusSuccess,
+			ExpectedReason:       refv1alpha1.UninstallPendingReasonScheduled,
+			ExpectedScheduledAt:  &future,
+			ExpectedRequeueAfter: time.Minute,
+			ExpectedEvents: []string{
+				"Warning UninstallScheduled uninstall proceeds at 2024-01-01T00:01:00Z unless the uninstall signal is withdrawn",
+			},
+		},
+		"grace period/elapsed": {
+			Signaled:            true,
+			GracePeriod:         time.Minute,
+			ScheduledAt:         &past,
+			ExpectDelete:        true,
+			ExpectedScheduledAt: &past,
+			ExpectedStatus:      PhaseStatusBlocking,
+			ExpectedReason:      refv1alpha1.UninstallPendingReasonInProgress,
+			ExpectedEvents: []string{
+				"Normal UninstallStarted uninstallation started",
+			},
+		},
+		"grace period/signal withdrawn": {
+			Signaled:       false,
+			GracePeriod:    time.Minute,
+			ScheduledAt:    &future,
+			ExpectedStatus: PhaseStatusSuccess,
+			ExpectedReason: refv1alpha1.UninstallPendingReasonAborted,
+			ExpectedEvents: []string{
+				"Normal UninstallAborted uninstall scheduled at 2024-01-01T00:01:00Z was aborted",
+			},
+		},
+		"dry run": {
+			Signaled:       true,
+			DryRun:         true,
+			ExpectedStatus: PhaseStatusSuccess,
+			ExpectedReason: refv1alpha1.UninstallPendingReasonDryRun,
+			ExpectedEvents: []string{
+				"Normal UninstallDryRun dry run: would delete ClusterServiceVersions: test-operator.v0.0.0",
+			},
 		},
 	} {
 		tc := tc
@@ -44,26 +136,74 @@ func TestPhaseUninstall(t *testing.T) {
 
 			signaler.
 				On("SignalUninstall", mock.Anything).
-				Return(true)
+				Return(tc.Signaled)
 
-			var uninstaller uninstallerMock
+			var deleter addonDeleterMock
 
-			uninstaller.
-				On("Uninstall", mock.Anything, tc.AddonNamespace, tc.OperatorName).
-				Return(nil)
+			if tc.ExpectDelete {
+				deleter.
+					On("Delete", mock.Anything, mock.Anything).
+					Return(tc.DeleteError)
+			}
+
+			var lister csvClientMock
+
+			if tc.DryRun {
+				lister.
+					On("ListCSVs", mock.Anything, WithNamespace("test-namespace"), WithCSVMatcher{Matcher: controllers.CSVMatcher{Package: "test-operator"}}).
+					Return([]opsv1alpha1.ClusterServiceVersion{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "test-operator.v0.0.0",
+							},
+						},
+					}, nil)
+			}
+
+			recorder := record.NewFakeRecorder(10)
 
 			p := NewPhaseUninstall(
 				&signaler,
-				&uninstaller,
-				WithAddonNamespace(tc.AddonNamespace),
-				WithOperatorName(tc.OperatorName),
+				&deleter,
+				&lister,
+				WithAddonNamespace("test-namespace"),
+				WithOperatorName("test-operator"),
+				WithUninstallGracePeriod(tc.GracePeriod),
+				WithUninstallDryRun(tc.DryRun),
+				WithClock{Clock: clocktesting.NewFakePassiveClock(now)},
+				WithEventRecorder{Recorder: recorder},
 			)
 
-			res := p.Execute(context.Background(), PhaseRequest{})
-			require.NoError(t, res.Error())
+			var addon refv1alpha1.ReferenceAddon
+
+			addon.Status.UninstallScheduledAt = tc.ScheduledAt
+
+			res := p.Execute(context.Background(), PhaseRequest{Addon: addon})
+
+			assert.Equal(t, tc.ExpectedStatus, res.Status())
+			assertCondition(t, res.Conditions(), refv1alpha1.ReferenceAddonConditionUninstallPending, tc.ExpectedReason)
+			assert.Equal(t, tc.ExpectedRequeueAfter, res.RequeueAfter())
+
+			scheduledAt := tc.ScheduledAt
+			if at, ok := res.UninstallSchedule(); ok {
+				scheduledAt = at
+			}
+
+			assert.Equal(t, tc.ExpectedScheduledAt, scheduledAt)
+
+			close(recorder.Events)
+
+			var events []string
+
+			for e := range recorder.Events {
+				events = append(events, e)
+			}
+
+			assert.Equal(t, tc.ExpectedEvents, events)
 
 			signaler.AssertExpectations(t)
-			uninstaller.AssertExpectations(t)
+			deleter.AssertExpectations(t)
+			lister.AssertExpectations(t)
 		})
 	}
 }
@@ -78,16 +218,34 @@ func (m *uninstallSignalerMock) SignalUninstall(ctx context.Context) bool {
 	return args.Bool(0)
 }
 
-type uninstallerMock struct {
+type addonDeleterMock struct {
 	mock.Mock
 }
 
-func (m *uninstallerMock) Uninstall(ctx context.Context, namespace, operatorName string) error {
-	args := m.Called(ctx, namespace, operatorName)
+func (m *addonDeleterMock) Delete(ctx context.Context, addon *refv1alpha1.ReferenceAddon) error {
+	args := m.Called(ctx, addon)
 
 	return args.Error(0)
 }
 
+type uninstallerMock struct {
+	mock.Mock
+}
+
+func (m *uninstallerMock) Uninstall(ctx context.Context, opts ...ListCSVsOption) (UninstallResult, error) {
+	argList := make([]interface{}, 0, len(opts)+1)
+
+	argList = append(argList, ctx)
+
+	for _, opt := range opts {
+		argList = append(argList, opt)
+	}
+
+	args := m.Called(argList...)
+
+	return args.Get(0).(UninstallResult), args.Error(1)
+}
+
 func TestUninstallSignalerImpl(t *testing.T) {
 	t.Parallel()
 
@@ -197,7 +355,7 @@ func TestUninstallImpl(t *testing.T) {
 	for name, tc := range map[string]struct {
 		ActualCSV    opsv1alpha1.ClusterServiceVersion
 		CSVNamespace string
-		CSVPrefix    string
+		CSVPackage   string
 	}{
 		"happy path": {
 			ActualCSV: opsv1alpha1.ClusterServiceVersion{
@@ -207,7 +365,7 @@ func TestUninstallImpl(t *testing.T) {
 				},
 			},
 			CSVNamespace: "test-namespace",
-			CSVPrefix:    "test-operator",
+			CSVPackage:   "test-operator",
 		},
 	} {
 		tc := tc
@@ -217,14 +375,19 @@ func TestUninstallImpl(t *testing.T) {
 
 			var csvClient csvClientMock
 			csvClient.
-				On("ListCSVs", mock.Anything, WithNamespace(tc.CSVNamespace), WithPrefix(tc.CSVPrefix)).
+				On("ListCSVs", mock.Anything, WithNamespace(tc.CSVNamespace), WithPackage(tc.CSVPackage)).
 				Return([]opsv1alpha1.ClusterServiceVersion{tc.ActualCSV}, nil)
 			csvClient.
 				On("RemoveCSVs", mock.Anything, tc.ActualCSV).
 				Return(nil)
 
 			uninstaller := NewUninstallerImpl(&csvClient)
-			require.NoError(t, uninstaller.Uninstall(context.Background(), tc.CSVNamespace, tc.CSVPrefix))
+
+			res, err := uninstaller.Uninstall(context.Background(), WithNamespace(tc.CSVNamespace), WithPackage(tc.CSVPackage))
+			require.NoError(t, err)
+
+			assert.Equal(t, []string{tc.ActualCSV.Name}, res.Found)
+			assert.Equal(t, []string{tc.ActualCSV.Name}, res.Removed)
 		})
 	}
 }
@@ -267,20 +430,58 @@ func TestCSVListerImpl_ListCSVs(t *testing.T) {
 	scheme := runtime.NewScheme()
 	require.NoError(t, opsv1alpha1.AddToScheme(scheme))
 
+	csv := func(name string, lbls map[string]string) *opsv1alpha1.ClusterServiceVersion {
+		return &opsv1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test-namespace",
+				Labels:    lbls,
+			},
+		}
+	}
+
 	for name, tc := range map[string]struct {
-		ActualCSV    opsv1alpha1.ClusterServiceVersion
-		CSVNamespace string
-		CSVPrefix    string
+		ActualCSVs    []client.Object
+		Options       []ListCSVsOption
+		ExpectedNames []string
 	}{
-		"happy path": {
-			ActualCSV: opsv1alpha1.ClusterServiceVersion{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-operator.v0.0.0",
-					Namespace: "test-namespace",
-				},
+		"package name": {
+			ActualCSVs: []client.Object{
+				csv("test-operator.v0.0.0", nil),
+				csv("test-operator-foo.v0.0.0", nil),
 			},
-			CSVNamespace: "test-namespace",
-			CSVPrefix:    "test-operator",
+			Options: []ListCSVsOption{
+				WithNamespace("test-namespace"),
+				WithPackage("test-operator"),
+			},
+			ExpectedNames: []string{"test-operator.v0.0.0"},
+		},
+		"package label": {
+			ActualCSVs: []client.Object{
+				csv("renamed.v0.0.0", map[string]string{
+					controllers.OLMPackageLabel("test-operator", "test-namespace"): "",
+				}),
+				csv("test-operator-foo.v0.0.0", map[string]string{
+					controllers.OLMPackageLabel("test-operator-foo", "test-namespace"): "",
+				}),
+			},
+			Options: []ListCSVsOption{
+				WithNamespace("test-namespace"),
+				WithPackage("test-operator"),
+			},
+			ExpectedNames: []string{"renamed.v0.0.0"},
+		},
+		"label selector": {
+			ActualCSVs: []client.Object{
+				csv("test-operator.v0.0.0", map[string]string{"channel": "stable"}),
+				csv("test-operator.v0.0.1", map[string]string{"channel": "beta"}),
+			},
+			Options: []ListCSVsOption{
+				WithNamespace("test-namespace"),
+				WithPackage("test-operator"),
+				WithLabelSelector{Selector: labels.SelectorFromSet(labels.Set{"channel": "stable"})},
+			},
+			ExpectedNames: []string{"test-operator.v0.0.0"},
 		},
 	} {
 		tc := tc
@@ -290,15 +491,20 @@ func TestCSVListerImpl_ListCSVs(t *testing.T) {
 
 			client := fake.NewClientBuilder().
 				WithScheme(scheme).
-				WithObjects(&tc.ActualCSV).
+				WithObjects(tc.ActualCSVs...).
 				Build()
 
 			lister := NewCSVClientImpl(client)
 
-			csvs, err := lister.ListCSVs(context.Background(), WithNamespace(tc.CSVNamespace), WithPrefix(tc.CSVPrefix))
+			csvs, err := lister.ListCSVs(context.Background(), tc.Options...)
 			require.NoError(t, err)
 
-			assert.Contains(t, csvs, tc.ActualCSV)
+			names := make([]string, 0, len(csvs))
+			for _, csv := range csvs {
+				names = append(names, csv.Name)
+			}
+
+			assert.ElementsMatch(t, tc.ExpectedNames, names)
 		})
 	}
 }
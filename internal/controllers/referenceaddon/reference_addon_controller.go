@@ -2,16 +2,24 @@ package referenceaddon
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
+	av1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
-	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -19,6 +27,7 @@ import (
 	"github.com/openshift/reference-addon/internal/controllers"
 	"github.com/openshift/reference-addon/internal/metrics"
 	opsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,96 +40,309 @@ func NewReferenceAddonReconciler(client client.Client, getter ParameterGetter, o
 	cfg.Option(opts...)
 	cfg.Default()
 
-	signaler, err := NewConfigMapUninstallSignaler(
-		client,
-		WithAddonNamespace(cfg.AddonNamespace),
+	if err := cfg.InstallMode.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch cfg.ReferenceAddonMode {
+	case ReferenceAddonModeAdopt, ReferenceAddonModeBootstrap:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownReferenceAddonMode, cfg.ReferenceAddonMode)
+	}
+
+	if cfg.InstallMode.IsMultiNamespace() && cfg.ParameterGetterFactory == nil {
+		return nil, fmt.Errorf("install mode %q requires a parameter getter factory", cfg.InstallMode)
+	}
+
+	csvMatcher, err := controllers.NewCSVMatcher(cfg.OperatorName, cfg.CSVLabelSelector, cfg.CSVVersionRange)
+	if err != nil {
+		return nil, fmt.Errorf("initializing ClusterServiceVersion matcher: %w", err)
+	}
+
+	phaseLog := cfg.Log.WithName("phase")
+
+	addonClient := NewReferenceAddonClient(client)
+
+	pb := &pipelineBuilder{
+		cfg:         cfg,
+		client:      client,
+		addonClient: addonClient,
+		csvClient: NewCSVClientImpl(
+			client,
+			WithLog{Log: phaseLog.WithName("csvClient")},
+		),
+		npClient:      NewNetworkPolicyClientImpl(client),
+		deployClient:  NewDeploymentClientImpl(client),
+		guardClient:   NewResourceGuardrailsClientImpl(client),
+		sampler:       metrics.NewResponseSamplerImpl(cfg.AddonNamespace),
+		smokeTester:   metrics.NewSmokeTester(cfg.AddonNamespace),
+		driftRecorder: metrics.NewDriftRecorder(),
+		templates:     DefaultNetworkPolicyTemplates(),
+		csvMatcher:    csvMatcher,
+	}
+
+	// The pipeline of the operator's namespace is built eagerly so
+	// that misconfigurations are reported on startup.
+	own, err := pb.Build(cfg.AddonNamespace, getter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReferenceAddonReconciler{
+		cfg:        cfg,
+		client:     addonClient,
+		csvMatcher: csvMatcher,
+		pipelines: map[string]*addonPipeline{
+			cfg.AddonNamespace: own,
+		},
+		newPipeline: func(namespace string) (*addonPipeline, error) {
+			if cfg.ParameterGetterFactory == nil {
+				return nil, fmt.Errorf("no parameter getter configured for namespace %q", namespace)
+			}
+
+			getter, err := cfg.ParameterGetterFactory(namespace)
+			if err != nil {
+				return nil, fmt.Errorf("initializing parameter getter: %w", err)
+			}
+
+			return pb.Build(namespace, getter)
+		},
+		failures:    make(map[types.NamespacedName]phaseFailure),
+		paramErrors: make(map[types.NamespacedName]string),
+	}, nil
+}
+
+// ReferenceAddonMode determines who owns the lifecycle of the ReferenceAddon.
+type ReferenceAddonMode string
+
+const (
+	// ReferenceAddonModeAdopt reconciles ReferenceAddons created by users
+	// and never creates them. A deleted ReferenceAddon stays deleted.
+	ReferenceAddonModeAdopt ReferenceAddonMode = "adopt"
+	// ReferenceAddonModeBootstrap additionally creates the ReferenceAddon
+	// in the operator's namespace whenever it does not exist.
+	ReferenceAddonModeBootstrap ReferenceAddonMode = "bootstrap"
+)
+
+var ErrUnknownReferenceAddonMode = errors.New("unknown reference addon mode")
+
+// pipelineBuilder builds the reconcile phases of the ReferenceAddon in
+// a namespace from the components which are shared by all namespaces.
+type pipelineBuilder struct {
+	cfg ReferenceAddonReconcilerConfig
+
+	client        client.Client
+	addonClient   ReferenceAddonClient
+	csvClient     *CSVClientImpl
+	npClient      NetworkPolicyClient
+	deployClient  DeploymentClient
+	guardClient   ResourceGuardrailsClient
+	sampler       ResponseSampler
+	smokeTester   SmokeTester
+	driftRecorder NetworkPolicyDriftRecorder
+	templates     []NetworkPolicyTemplate
+	csvMatcher    controllers.CSVMatcher
+}
+
+func (b *pipelineBuilder) Build(namespace string, getter ParameterGetter) (*addonPipeline, error) {
+	cfg := b.cfg
+
+	addonInstanceNamespace := cfg.AddonInstanceNamespace
+	if cfg.InstallMode.IsMultiNamespace() {
+		// Every namespace is expected to hold its own AddonInstance.
+		addonInstanceNamespace = namespace
+	}
+
+	log := cfg.Log
+	if cfg.InstallMode.IsMultiNamespace() {
+		log = log.WithValues("namespace", namespace)
+	}
+
+	signaler, err := NewUninstallSignaler(
+		b.client,
+		cfg.UninstallSignalers,
+		cfg.UninstallSignalMode,
+		WithLog{Log: log.WithName("uninstallSignaler")},
+		WithAddonNamespace(namespace),
+		WithAddonInstanceNamespace(addonInstanceNamespace),
+		WithAddonInstanceName(cfg.AddonInstanceName),
 		WithOperatorName(cfg.OperatorName),
 		WithDeleteLabel(cfg.DeleteLabel),
+		WithParameterGetter{Getter: getter},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("initializing uninstall signaler: %w", err)
 	}
 
 	var (
-		phaseLog                     = cfg.Log.WithName("phase")
+		phaseLog                     = log.WithName("phase")
 		phaseApplyNetworkPoliciesLog = phaseLog.WithName("applyNetworkPolicies")
+		phaseApplySampleWorkloadLog  = phaseLog.WithName("applySampleWorkload")
+		phaseApplyGuardrailsLog      = phaseLog.WithName("applyResourceGuardrails")
 		PhaseSmokeTestRunLog         = phaseLog.WithName("smokeTestRun")
 		phaseUninstallLog            = phaseLog.WithName("uninstall")
-		uninstallerLog               = phaseUninstallLog.WithName("uninstaller")
+		phaseTeardownLog             = phaseLog.WithName("teardown")
+		uninstallerLog               = phaseTeardownLog.WithName("uninstaller")
 	)
 
-	return &ReferenceAddonReconciler{
-		cfg:         cfg,
-		client:      NewReferenceAddonClient(client),
+	// The teardown removes the policies rendered from every
+	// template regardless of which templates are selected. With
+	// no template selected every template is rendered as unselected.
+	_, templated, err := RenderNetworkPolicies(b.templates, nil, NetworkPolicyTemplateData{
+		Namespace:    namespace,
+		OperatorName: cfg.OperatorName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rendering NetworkPolicy templates: %w", err)
+	}
+
+	return &addonPipeline{
 		paramGetter: getter,
+		signaler:    signaler,
+		teardownAck: NewAddonInstanceTeardownAcknowledger(b.client, types.NamespacedName{
+			Namespace: addonInstanceNamespace,
+			Name:      cfg.AddonInstanceName,
+		}),
 		orderedPhases: []Phase{
 			NewPhaseUninstall(
 				signaler,
-				NewUninstallerImpl(
-					NewCSVClientImpl(
-						client,
-						WithLog{Log: uninstallerLog.WithName("client")},
-					),
-					WithLog{Log: uninstallerLog},
-				),
+				b.addonClient,
+				b.csvClient,
 				WithLog{Log: phaseUninstallLog},
-				WithAddonNamespace(cfg.AddonNamespace),
-				WithOperatorName(cfg.OperatorName),
+				WithEventRecorder{Recorder: cfg.Recorder},
+				WithAddonNamespace(namespace),
+				WithCSVMatcher{Matcher: b.csvMatcher},
+				WithUninstallGracePeriod(cfg.UninstallGracePeriod),
+				WithUninstallDryRun(cfg.UninstallDryRun),
 			),
 			NewPhaseSmokeTestRun(
 				WithLog{Log: PhaseSmokeTestRunLog},
+				WithEventRecorder{Recorder: cfg.Recorder},
 				WithSmokeTester{
-					Tester: metrics.NewSmokeTester(),
+					Tester: b.smokeTester,
 				},
 			),
 			NewPhaseSendDummyMetrics(
-				metrics.NewResponseSamplerImpl(),
+				b.sampler,
+				WithEventRecorder{Recorder: cfg.Recorder},
 				WithSampleURLs{"https://httpstat.us/503", "https://httpstat.us/200"},
 			),
 			NewPhaseApplyNetworkPolicies(
-				NewNetworkPolicyClientImpl(client),
+				b.npClient,
 				WithLog{Log: phaseApplyNetworkPoliciesLog},
-				WithPolicies{
-					netv1.NetworkPolicy{
-						ObjectMeta: metav1.ObjectMeta{
-							Name:      generateIngressPolicyName(cfg.OperatorName),
-							Namespace: cfg.AddonNamespace,
-						},
-						Spec: netv1.NetworkPolicySpec{
-							PodSelector: metav1.LabelSelector{},
-							PolicyTypes: []netv1.PolicyType{
-								netv1.PolicyTypeIngress,
-							},
-						},
-					},
-				},
+				WithEventRecorder{Recorder: cfg.Recorder},
+				WithOperatorName(cfg.OperatorName),
+				WithAddonNamespace(namespace),
+				WithNetworkPolicyTemplates(b.templates),
+				WithNetworkPolicyPruneDryRun(cfg.NetworkPolicyPruneDryRun),
+				WithNetworkPolicyDriftReportOnly(cfg.NetworkPolicyDriftReportOnly),
+				WithNetworkPolicyDriftRecorder{Recorder: b.driftRecorder},
+			),
+			NewPhaseApplyResourceGuardrails(
+				b.guardClient,
+				WithLog{Log: phaseApplyGuardrailsLog},
+				WithEventRecorder{Recorder: cfg.Recorder},
+				WithOperatorName(cfg.OperatorName),
+				WithAddonNamespace(namespace),
+			),
+			NewPhaseApplySampleWorkload(
+				b.deployClient,
+				WithLog{Log: phaseApplySampleWorkloadLog},
+				WithEventRecorder{Recorder: cfg.Recorder},
+				WithOperatorName(cfg.OperatorName),
+				WithAddonNamespace(namespace),
+				WithSampleWorkloadImage(cfg.SampleWorkloadImage),
 			),
 		},
+		teardownPhase: NewPhaseTeardown(
+			b.npClient,
+			b.sampler,
+			NewUninstallerImpl(
+				b.csvClient,
+				WithLog{Log: uninstallerLog},
+			),
+			signaler,
+			WithLog{Log: phaseTeardownLog},
+			WithEventRecorder{Recorder: cfg.Recorder},
+			WithAddonNamespace(namespace),
+			WithCSVMatcher{Matcher: b.csvMatcher},
+			WithUninstallDryRun(cfg.UninstallDryRun),
+			WithSmokeTester{
+				Tester: b.smokeTester,
+			},
+			WithPolicies(templated),
+		),
 	}, nil
 }
 
+// addonPipeline holds the components reconciling
+// the ReferenceAddon of a single namespace.
+type addonPipeline struct {
+	paramGetter   ParameterGetter
+	signaler      UninstallSignaler
+	orderedPhases []Phase
+	teardownPhase Phase
+	teardownAck   TeardownAcknowledger
+	// lastParams are the last parameters retrieved successfully.
+	lastParams *PhaseRequestParameters
+}
+
 type ReferenceAddonReconciler struct {
 	cfg ReferenceAddonReconcilerConfig
 
-	client      ReferenceAddonClient
-	paramGetter ParameterGetter
+	client     ReferenceAddonClient
+	csvMatcher controllers.CSVMatcher
 
-	orderedPhases []Phase
+	lock sync.Mutex
+	// pipelines are keyed by namespace and built on
+	// demand using newPipeline in multi-namespace modes.
+	pipelines   map[string]*addonPipeline
+	newPipeline func(namespace string) (*addonPipeline, error)
+	failures    map[types.NamespacedName]phaseFailure
+	paramErrors map[types.NamespacedName]string
+}
+
+type phaseFailure struct {
+	Phase string
+	Count int
 }
 
 func (r *ReferenceAddonReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	params, err := r.paramGetter.GetParameters(ctx)
+	key := r.addonKey(req)
+
+	pipeline, err := r.pipeline(key.Namespace)
 	if err != nil {
-		// Log error and continue reconcilliation so subsequent phases
-		// can fail if required parameters are missing.
-		r.cfg.Log.Error(err, "unable to sync addon parameters")
+		return ctrl.Result{}, fmt.Errorf("initializing reconcile phases for namespace %q: %w", key.Namespace, err)
+	}
+
+	params, paramErr := pipeline.paramGetter.GetParameters(ctx)
+	if isParameterSourceNotFound(paramErr) {
+		r.cfg.Log.Info("addon parameters not found", "namespace", key.Namespace)
+	} else if paramErr != nil {
+		r.cfg.Log.Error(paramErr, "unable to sync addon parameters", "namespace", key.Namespace)
 	}
 
-	addon, err := r.ensureReferenceAddon(ctx)
+	params, paramsCond := r.resolveParameters(pipeline, params, paramErr)
+
+	addon, err := r.ensureReferenceAddon(ctx, key, pipeline.signaler)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("ensuring ReferenceAddon: %w", err)
 	}
 
+	if addon == nil {
+		// ReferenceAddon was removed as part of an uninstall
+		// or has not been created by a user yet.
+		r.cfg.Log.Info("ReferenceAddon not found", "namespace", key.Namespace, "name", key.Name)
+
+		r.forget(key)
+
+		return ctrl.Result{}, nil
+	}
+
+	if !addon.DeletionTimestamp.IsZero() {
+		return r.teardown(ctx, pipeline, addon)
+	}
+
 	defer func() {
 		if err := r.client.UpdateStatus(ctx, addon); err != nil {
 			r.cfg.Log.Error(err, "updating ReferenceAddon status")
@@ -137,12 +359,34 @@ func (r *ReferenceAddonReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		)
 	}
 
+	effective, specErr := params.OverrideWithSpec(addon.Spec, r.cfg.ParameterRegistry)
+	if specErr != nil {
+		r.cfg.Log.Error(specErr, "invalid ReferenceAddon spec parameters", "namespace", key.Namespace)
+
+		paramsCond = withInvalidSpecParameters(paramsCond, specErr)
+	}
+
+	r.reportParameterError(addon, paramErr, specErr)
+
+	paramsHash := effective.Hash()
+
+	if paramsCond.Status == metav1.ConditionTrue {
+		paramsCond.Message = fmt.Sprintf("%s (hash %s)", paramsCond.Message, paramsHash)
+	}
+
+	paramsCond.ObservedGeneration = addon.Generation
+	meta.SetStatusCondition(&addon.Status.Conditions, paramsCond)
+
 	phaseReq := PhaseRequest{
 		Addon:  *addon,
-		Params: params,
+		Params: effective,
+		Unchanged: addon.Status.ObservedGeneration == addon.Generation &&
+			addon.Status.ObservedParametersHash == paramsHash,
 	}
 
-	for _, p := range r.orderedPhases {
+	var requeueAfter time.Duration
+
+	for _, p := range pipeline.orderedPhases {
 		res := p.Execute(ctx, phaseReq)
 
 		for _, cond := range res.Conditions() {
@@ -151,16 +395,38 @@ func (r *ReferenceAddonReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			meta.SetStatusCondition(&addon.Status.Conditions, cond)
 		}
 
+		if at, ok := res.UninstallSchedule(); ok {
+			addon.Status.UninstallScheduledAt = at
+		}
+
+		if after := res.RequeueAfter(); after > 0 && (requeueAfter == 0 || after < requeueAfter) {
+			requeueAfter = after
+		}
+
 		switch res.Status() {
 		case PhaseStatusError:
+			r.reportPhaseDegraded(addon, p.Name(), refv1alpha1.ReferenceAddonDegradedReasonPhaseErrored, res.Error().Error())
+
 			return ctrl.Result{}, res.Error()
 		case PhaseStatusFailure:
+			r.reportPhaseDegraded(addon, p.Name(), refv1alpha1.ReferenceAddonDegradedReasonPhaseFailed, res.FailureMessage())
+
 			return ctrl.Result{Requeue: true}, nil
 		case PhaseStatusBlocking:
+			r.reportPhaseRecovered(addon, p.Name())
+
 			return ctrl.Result{}, nil
 		}
+
+		r.reportPhaseRecovered(addon, p.Name())
 	}
 
+	meta.SetStatusCondition(&addon.Status.Conditions,
+		newDegradedCondition(
+			refv1alpha1.ReferenceAddonDegradedReasonAsExpected,
+			"all reconcile phases completed successfully",
+		),
+	)
 	meta.SetStatusCondition(&addon.Status.Conditions,
 		newAvailableCondition(
 			refv1alpha1.ReferenceAddonAvailableReasonReady,
@@ -168,11 +434,360 @@ func (r *ReferenceAddonReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		),
 	)
 
+	addon.Status.ObservedGeneration = addon.Generation
+	addon.Status.ObservedParametersHash = paramsHash
+	addon.Status.Parameters = r.cfg.ParameterRegistry.Summarize(effective)
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// teardown stops regular reconciliation of a ReferenceAddon which is being
+// deleted and executes the teardown phase. The ReferenceAddon finalizer is
+// only released once all owned resources have been removed and, if an
+// uninstall was requested, the AddonInstance reports the completed teardown.
+func (r *ReferenceAddonReconciler) teardown(ctx context.Context, pipeline *addonPipeline, addon *refv1alpha1.ReferenceAddon) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(addon, refv1alpha1.ReferenceAddonFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	meta.SetStatusCondition(&addon.Status.Conditions,
+		newTearingDownCondition(
+			refv1alpha1.TearingDownReasonDrainingPhases,
+			"ReferenceAddon is being deleted; reconcile phases stopped",
+		),
+	)
+	meta.SetStatusCondition(&addon.Status.Conditions,
+		newAvailableCondition(
+			refv1alpha1.ReferenceAddonAvailableReasonUninstalling,
+			"ReferenceAddon is being deleted",
+		),
+	)
+
+	teardownPhase := pipeline.teardownPhase
+
+	res := teardownPhase.Execute(ctx, PhaseRequest{Addon: *addon})
+
+	if report := res.UninstallReport(); report != nil {
+		addon.Status.Uninstall = report
+	}
+
+	for _, cond := range res.Conditions() {
+		cond.ObservedGeneration = addon.Generation
+
+		meta.SetStatusCondition(&addon.Status.Conditions, cond)
+	}
+
+	switch res.Status() {
+	case PhaseStatusError:
+		r.reportPhaseDegraded(addon, teardownPhase.Name(), refv1alpha1.ReferenceAddonDegradedReasonPhaseErrored, res.Error().Error())
+	case PhaseStatusFailure:
+		r.reportPhaseDegraded(addon, teardownPhase.Name(), refv1alpha1.ReferenceAddonDegradedReasonPhaseFailed, res.FailureMessage())
+	default:
+		r.reportPhaseRecovered(addon, teardownPhase.Name())
+	}
+
+	// The status holds the uninstall report read by the status
+	// controller and must be written before the finalizer is released.
+	if err := r.client.UpdateStatus(ctx, addon); err != nil {
+		return ctrl.Result{}, fmt.Errorf("updating ReferenceAddon status: %w", err)
+	}
+
+	switch res.Status() {
+	case PhaseStatusError:
+		return ctrl.Result{}, res.Error()
+	case PhaseStatusFailure, PhaseStatusBlocking:
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	if meta.IsStatusConditionTrue(addon.Status.Conditions, refv1alpha1.ReferenceAddonConditionUninstallPending.String()) {
+		// The status controller can no longer report the completed
+		// teardown once the ReferenceAddon has been removed.
+		acked, err := pipeline.teardownAck.TeardownAcknowledged(ctx)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("checking teardown acknowledgement: %w", err)
+		}
+
+		if !acked {
+			r.cfg.Log.Info("waiting for AddonInstance to report teardown completion", "namespace", addon.Namespace)
+
+			return ctrl.Result{Requeue: true}, nil
+		}
+	}
+
+	if err := r.client.RemoveFinalizer(ctx, addon, refv1alpha1.ReferenceAddonFinalizer); err != nil {
+		return ctrl.Result{}, fmt.Errorf("releasing ReferenceAddon finalizer: %w", err)
+	}
+
+	r.forget(client.ObjectKeyFromObject(addon))
+
 	return ctrl.Result{}, nil
 }
 
-func (r *ReferenceAddonReconciler) ensureReferenceAddon(ctx context.Context) (*refv1alpha1.ReferenceAddon, error) {
-	actual, err := r.client.CreateOrUpdate(ctx, r.desiredReferenceAddon())
+// addonKey returns the key of the ReferenceAddon reconciled for the
+// given request. Requests are keyed by the namespace of the ReferenceAddon
+// in multi-namespace install modes while in OwnNamespace mode every
+// request reconciles the ReferenceAddon in the operator's namespace.
+func (r *ReferenceAddonReconciler) addonKey(req ctrl.Request) types.NamespacedName {
+	namespace := r.cfg.AddonNamespace
+	if r.cfg.InstallMode.IsMultiNamespace() {
+		namespace = req.Namespace
+	}
+
+	return types.NamespacedName{
+		Name:      r.cfg.OperatorName,
+		Namespace: namespace,
+	}
+}
+
+// pipeline returns the reconcile phases for the given
+// namespace building them if they do not exist yet.
+func (r *ReferenceAddonReconciler) pipeline(namespace string) (*addonPipeline, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if p, ok := r.pipelines[namespace]; ok {
+		return p, nil
+	}
+
+	p, err := r.newPipeline(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	r.pipelines[namespace] = p
+
+	return p, nil
+}
+
+// forget drops all state tracked for the given ReferenceAddon. The
+// pipeline of any namespace but the operator's is dropped as well and
+// rebuilt should a ReferenceAddon be created in the namespace again.
+func (r *ReferenceAddonReconciler) forget(key types.NamespacedName) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.failures, key)
+	delete(r.paramErrors, key)
+
+	if key.Namespace != r.cfg.AddonNamespace {
+		delete(r.pipelines, key.Namespace)
+	}
+}
+
+// reportPhaseDegraded marks the addon as Degraded by the named phase.
+// Once the same phase has failed 'DegradedThreshold' consecutive times
+// the addon is additionally reported as no longer Available.
+func (r *ReferenceAddonReconciler) reportPhaseDegraded(
+	addon *refv1alpha1.ReferenceAddon,
+	phase string,
+	reason refv1alpha1.ReferenceAddonDegradedReason,
+	msg string,
+) {
+	count := r.recordPhaseFailure(client.ObjectKeyFromObject(addon), phase)
+
+	r.cfg.Log.Info("phase failed", "phase", phase, "reason", reason.String(), "message", msg, "count", count)
+
+	cond := newDegradedCondition(reason, fmt.Sprintf("phase %q: %s", phase, msg))
+
+	if count == 1 {
+		r.cfg.Recorder.Event(addon, corev1.EventTypeWarning, EventReasonPhaseFailed, cond.Message)
+	}
+
+	meta.SetStatusCondition(&addon.Status.Conditions, cond)
+
+	if count < r.cfg.DegradedThreshold {
+		return
+	}
+
+	meta.SetStatusCondition(&addon.Status.Conditions,
+		newAvailableCondition(
+			refv1alpha1.ReferenceAddonAvailableReasonDegraded,
+			fmt.Sprintf("phase %q failed %d consecutive times", phase, count),
+		),
+	)
+}
+
+// reportPhaseRecovered clears the Degraded condition if it was
+// previously raised by the named phase.
+func (r *ReferenceAddonReconciler) reportPhaseRecovered(addon *refv1alpha1.ReferenceAddon, phase string) {
+	if !r.clearPhaseFailure(client.ObjectKeyFromObject(addon), phase) {
+		return
+	}
+
+	cond := newDegradedCondition(
+		refv1alpha1.ReferenceAddonDegradedReasonAsExpected,
+		fmt.Sprintf("phase %q recovered", phase),
+	)
+
+	r.cfg.Recorder.Event(addon, corev1.EventTypeNormal, EventReasonPhaseRecovered, cond.Message)
+
+	meta.SetStatusCondition(&addon.Status.Conditions, cond)
+}
+
+// resolveParameters caches the given parameters if they were retrieved
+// successfully. Otherwise the last valid parameters are returned so that
+// a malformed or unavailable parameter source does not change the
+// addon's behavior. The returned condition reports the outcome.
+func (r *ReferenceAddonReconciler) resolveParameters(pipeline *addonPipeline, params PhaseRequestParameters, err error) (PhaseRequestParameters, metav1.Condition) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	switch {
+	case err == nil:
+		pipeline.lastParams = &params
+
+		return params, newParametersValidCondition(
+			refv1alpha1.ParametersValidReasonValid,
+			"addon parameters are valid",
+		)
+	case isParameterSourceNotFound(err):
+		// All parameters are optional so a missing source
+		// is equivalent to no parameters being set.
+		pipeline.lastParams = &params
+
+		return params, newParametersValidCondition(
+			refv1alpha1.ParametersValidReasonNotFound,
+			"no addon parameters found",
+		)
+	}
+
+	fallback := "no valid parameters have been observed yet"
+
+	if pipeline.lastParams != nil {
+		params = *pipeline.lastParams
+		fallback = "using last valid parameters"
+	}
+
+	if keys := InvalidParameterKeys(err); len(keys) > 0 {
+		return params, newParametersValidCondition(
+			refv1alpha1.ParametersValidReasonInvalidValue,
+			fmt.Sprintf("invalid parameters %s: %v; %s", strings.Join(keys, ", "), err, fallback),
+		)
+	}
+
+	return params, newParametersValidCondition(
+		refv1alpha1.ParametersValidReasonLookupFailed,
+		fmt.Sprintf("retrieving parameters: %v; %s", err, fallback),
+	)
+}
+
+// withInvalidSpecParameters reports spec fields which failed validation
+// on the given ParametersValid condition.
+func withInvalidSpecParameters(cond metav1.Condition, err error) metav1.Condition {
+	msg := fmt.Sprintf(
+		"invalid spec fields %s: %v; using addon parameters instead",
+		strings.Join(InvalidParameterKeys(err), ", "), err,
+	)
+	if cond.Status != metav1.ConditionTrue {
+		msg = fmt.Sprintf("%s; %s", cond.Message, msg)
+	}
+
+	return newParametersValidCondition(refv1alpha1.ParametersValidReasonInvalidValue, msg)
+}
+
+// reportParameterError emits a warning event when the addon parameters
+// could not be parsed or the spec overrides are invalid. The same error
+// is only reported once per addon until valid parameters are observed
+// again. A missing parameter source is not reported since all parameters
+// are optional.
+func (r *ReferenceAddonReconciler) reportParameterError(addon *refv1alpha1.ReferenceAddon, paramErr, specErr error) {
+	key := client.ObjectKeyFromObject(addon)
+
+	if isParameterSourceNotFound(paramErr) {
+		paramErr = nil
+	}
+
+	if specErr != nil {
+		specErr = fmt.Errorf("spec: %w", specErr)
+	}
+
+	err := errors.Join(paramErr, specErr)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if err == nil {
+		delete(r.paramErrors, key)
+
+		return
+	}
+
+	if r.paramErrors[key] == err.Error() {
+		return
+	}
+
+	r.paramErrors[key] = err.Error()
+
+	r.cfg.Recorder.Event(addon, corev1.EventTypeWarning, EventReasonInvalidParameters, err.Error())
+}
+
+// isParameterSourceNotFound reports whether err indicates that the
+// addon parameters have not been provided.
+func isParameterSourceNotFound(err error) bool {
+	return apierrors.IsNotFound(err) || errors.Is(err, ErrParameterSourceNotFound)
+}
+
+func (r *ReferenceAddonReconciler) recordPhaseFailure(key types.NamespacedName, phase string) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	failure := r.failures[key]
+	if failure.Phase != phase {
+		failure = phaseFailure{Phase: phase}
+	}
+
+	failure.Count++
+
+	r.failures[key] = failure
+
+	return failure.Count
+}
+
+func (r *ReferenceAddonReconciler) clearPhaseFailure(key types.NamespacedName, phase string) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	failure, ok := r.failures[key]
+	if !ok || failure.Phase != phase {
+		return false
+	}
+
+	delete(r.failures, key)
+
+	return true
+}
+
+// ensureReferenceAddon returns the ReferenceAddon with the given key after
+// adopting it by adding the ReferenceAddon finalizer. nil is returned if
+// the ReferenceAddon does not exist unless it is bootstrapped in which
+// case it is created or updated. Once an uninstall has been signaled the
+// ReferenceAddon is neither recreated nor adopted.
+func (r *ReferenceAddonReconciler) ensureReferenceAddon(
+	ctx context.Context,
+	key types.NamespacedName,
+	signaler UninstallSignaler,
+) (*refv1alpha1.ReferenceAddon, error) {
+	if signaled := signaler.SignalUninstall(ctx); signaled || !r.bootstraps(key) {
+		actual, err := r.client.Get(ctx, key)
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("getting ReferenceAddon: %w", err)
+		}
+
+		// Finalizers may not be added to objects which are being deleted.
+		if signaled || !actual.DeletionTimestamp.IsZero() {
+			return actual, nil
+		}
+
+		if err := r.client.AddFinalizer(ctx, actual, refv1alpha1.ReferenceAddonFinalizer); err != nil {
+			return nil, fmt.Errorf("adopting ReferenceAddon: %w", err)
+		}
+
+		return actual, nil
+	}
+
+	actual, err := r.client.CreateOrUpdate(ctx, r.desiredReferenceAddon(key))
 	if err != nil {
 		return nil, fmt.Errorf("creating/updating desired ReferenceAddon: %w", err)
 	}
@@ -180,68 +795,145 @@ func (r *ReferenceAddonReconciler) ensureReferenceAddon(ctx context.Context) (*r
 	return actual, nil
 }
 
-func (r *ReferenceAddonReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	desired := r.desiredReferenceAddon()
-	requestObject := types.NamespacedName{
-		Name:      desired.Name,
-		Namespace: desired.Namespace,
-	}
+// bootstraps returns true if the ReferenceAddon with the
+// given key is created whenever it does not exist.
+func (r *ReferenceAddonReconciler) bootstraps(key types.NamespacedName) bool {
+	return r.cfg.ReferenceAddonMode == ReferenceAddonModeBootstrap &&
+		key == r.addonKey(reconcile.Request{})
+}
 
-	refAddonHandler := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+func (r *ReferenceAddonReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	refAddonHandler := handler.EnqueueRequestsFromMapFunc(func(_ context.Context, obj client.Object) []reconcile.Request {
 		return []reconcile.Request{
 			{
-				NamespacedName: requestObject,
+				NamespacedName: r.addonKey(reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace()},
+				}),
 			},
 		}
 	})
 
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&refv1alpha1.ReferenceAddon{}).
-		WatchesRawSource(source.Func(func(_ context.Context, q workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+	bldr := ctrl.NewControllerManagedBy(mgr).
+		For(
+			&refv1alpha1.ReferenceAddon{},
+			builder.WithPredicates(controllers.HasName(r.cfg.OperatorName)),
+		)
+
+	if r.cfg.ReferenceAddonMode == ReferenceAddonModeBootstrap {
+		// The ReferenceAddon of the operator's namespace is reconciled on
+		// startup so that it is created if it does not exist yet.
+		bldr = bldr.WatchesRawSource(source.Func(func(_ context.Context, q workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
 			q.Add(reconcile.Request{
-				NamespacedName: requestObject,
+				NamespacedName: r.addonKey(reconcile.Request{}),
 			})
 
 			return nil
-		})).
+		}))
+	}
+
+	bldr = bldr.
 		Owns(
 			&netv1.NetworkPolicy{},
-			builder.WithPredicates(controllers.HasName(generateIngressPolicyName(r.cfg.OperatorName))),
+			builder.WithPredicates(controllers.HasNamePrefix(r.cfg.OperatorName+"-")),
+		).
+		Owns(
+			&appsv1.Deployment{},
+			builder.WithPredicates(controllers.HasName(generateSampleWorkloadName(r.cfg.OperatorName))),
+		).
+		Owns(
+			&corev1.ResourceQuota{},
+			builder.WithPredicates(controllers.HasName(generateResourceQuotaName(r.cfg.OperatorName))),
+		).
+		Owns(
+			&corev1.LimitRange{},
+			builder.WithPredicates(controllers.HasName(generateLimitRangeName(r.cfg.OperatorName))),
 		).
 		Watches(
 			&opsv1alpha1.ClusterServiceVersion{},
 			refAddonHandler,
-			builder.WithPredicates(controllers.HasNamePrefix(r.cfg.OperatorName)),
+			builder.WithPredicates(controllers.MatchesCSV(r.csvMatcher)),
 		).
 		Watches(
 			&corev1.ConfigMap{},
 			refAddonHandler,
-			builder.WithPredicates(controllers.HasName(r.cfg.OperatorName)),
+			builder.WithPredicates(predicate.Or(
+				controllers.HasName(r.cfg.OperatorName),
+				controllers.HasName(r.cfg.AddonParameterConfigMapName),
+			)),
 		).
 		Watches(
 			&corev1.Secret{},
 			refAddonHandler,
 			builder.WithPredicates(controllers.HasName(r.cfg.AddonParameterSecretname)),
-		).
-		Complete(r)
+		)
+
+	// Only watch the resources of enabled signalers so that optional
+	// APIs are not required to be installed.
+	if r.cfg.hasUninstallSignaler(UninstallSignalerKindAddonInstance) {
+		bldr = bldr.Watches(
+			&av1alpha1.AddonInstance{},
+			refAddonHandler,
+			builder.WithPredicates(controllers.HasName(r.cfg.AddonInstanceName)),
+		)
+	}
+
+	if r.cfg.hasUninstallSignaler(UninstallSignalerKindUninstallRequest) {
+		bldr = bldr.Watches(
+			&refv1alpha1.UninstallRequest{},
+			refAddonHandler,
+			builder.WithPredicates(controllers.HasName(r.cfg.OperatorName)),
+		)
+	}
+
+	return bldr.Complete(r)
 }
 
-func (r *ReferenceAddonReconciler) desiredReferenceAddon() refv1alpha1.ReferenceAddon {
+func (r *ReferenceAddonReconciler) desiredReferenceAddon(key types.NamespacedName) refv1alpha1.ReferenceAddon {
 	return refv1alpha1.ReferenceAddon{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      r.cfg.OperatorName,
-			Namespace: r.cfg.AddonNamespace,
+			Name:       key.Name,
+			Namespace:  key.Namespace,
+			Finalizers: []string{refv1alpha1.ReferenceAddonFinalizer},
 		},
 	}
 }
 
 type ReferenceAddonReconcilerConfig struct {
-	Log logr.Logger
+	Log      logr.Logger
+	Recorder record.EventRecorder
 
+	// InstallMode determines which ReferenceAddons are reconciled.
+	InstallMode controllers.InstallMode
+	// ReferenceAddonMode determines whether the ReferenceAddon in
+	// AddonNamespace is created by the reconciler or by users.
+	ReferenceAddonMode ReferenceAddonMode
+	// ParameterGetterFactory returns the ParameterGetter for ReferenceAddons
+	// outside of AddonNamespace and is required in multi-namespace modes.
+	ParameterGetterFactory   ParameterGetterFactory
 	AddonNamespace           string
 	AddonParameterSecretname string
-	OperatorName             string
-	DeleteLabel              string
+	// AddonParameterConfigMapName is the name of the ConfigMap
+	// parameters are read from when the configmap source is used.
+	AddonParameterConfigMapName string
+	AddonInstanceNamespace      string
+	AddonInstanceName           string
+	OperatorName                string
+	DeleteLabel                 string
+	DegradedThreshold           int
+	UninstallSignalers          []UninstallSignalerKind
+	UninstallSignalMode         UninstallSignalMode
+	UninstallGracePeriod        time.Duration
+	UninstallDryRun             bool
+	CSVLabelSelector            string
+	CSVVersionRange             string
+	ParameterRegistry           *ParameterRegistry
+	SampleWorkloadImage         string
+	// NetworkPolicyPruneDryRun only reports stale NetworkPolicies
+	// instead of deleting them.
+	NetworkPolicyPruneDryRun bool
+	// NetworkPolicyDriftReportOnly only reports NetworkPolicies whose
+	// spec drifted from the desired spec instead of reverting them.
+	NetworkPolicyDriftReportOnly bool
 }
 
 func (c *ReferenceAddonReconcilerConfig) Option(opts ...ReferenceAddonReconcilerOption) {
@@ -254,6 +946,48 @@ func (c *ReferenceAddonReconcilerConfig) Default() {
 	if c.Log.GetSink() == nil {
 		c.Log = logr.Discard()
 	}
+
+	if c.Recorder == nil {
+		c.Recorder = controllers.NopEventRecorder{}
+	}
+
+	if c.InstallMode == "" {
+		c.InstallMode = controllers.InstallModeOwnNamespace
+	}
+
+	if c.ReferenceAddonMode == "" {
+		c.ReferenceAddonMode = ReferenceAddonModeAdopt
+	}
+
+	if c.DegradedThreshold <= 0 {
+		c.DegradedThreshold = 3
+	}
+
+	if len(c.UninstallSignalers) == 0 {
+		c.UninstallSignalers = []UninstallSignalerKind{UninstallSignalerKindConfigMap}
+	}
+
+	if c.UninstallSignalMode == "" {
+		c.UninstallSignalMode = UninstallSignalModeAny
+	}
+
+	if c.AddonInstanceNamespace == "" {
+		c.AddonInstanceNamespace = c.AddonNamespace
+	}
+
+	if c.ParameterRegistry == nil {
+		c.ParameterRegistry = DefaultParameterRegistry()
+	}
+}
+
+func (c *ReferenceAddonReconcilerConfig) hasUninstallSignaler(kind UninstallSignalerKind) bool {
+	for _, k := range c.UninstallSignalers {
+		if k == kind {
+			return true
+		}
+	}
+
+	return false
 }
 
 type ReferenceAddonReconcilerOption interface {
@@ -261,38 +995,114 @@ type ReferenceAddonReconcilerOption interface {
 }
 
 type ReferenceAddonClient interface {
+	Get(ctx context.Context, key types.NamespacedName) (*refv1alpha1.ReferenceAddon, error)
 	CreateOrUpdate(ctx context.Context, addon refv1alpha1.ReferenceAddon) (*refv1alpha1.ReferenceAddon, error)
+	Delete(ctx context.Context, addon *refv1alpha1.ReferenceAddon) error
+	AddFinalizer(ctx context.Context, addon *refv1alpha1.ReferenceAddon, finalizer string) error
+	RemoveFinalizer(ctx context.Context, addon *refv1alpha1.ReferenceAddon, finalizer string) error
 	UpdateStatus(ctx context.Context, addon *refv1alpha1.ReferenceAddon) error
 }
 
 func NewReferenceAddonClient(client client.Client) *ReferenceAddonClientImpl {
 	return &ReferenceAddonClientImpl{
 		client: client,
+		// Fields written by the legacy field manager are not upgraded
+		// since they may include a spec which is owned by users.
+		applier: controllers.NewApplier(client, controllers.WithLegacyFieldManagers{}),
 	}
 }
 
 type ReferenceAddonClientImpl struct {
-	client client.Client
+	client  client.Client
+	applier *controllers.Applier
+}
+
+func (c *ReferenceAddonClientImpl) Get(ctx context.Context, key types.NamespacedName) (*refv1alpha1.ReferenceAddon, error) {
+	var addon refv1alpha1.ReferenceAddon
+
+	if err := c.client.Get(ctx, key, &addon); err != nil {
+		return nil, fmt.Errorf("getting ReferenceAddon: %w", err)
+	}
+
+	return &addon, nil
 }
 
+// CreateOrUpdate creates the given ReferenceAddon including its spec if it
+// does not exist. Otherwise only its labels and finalizers are applied
+// since the spec is owned by users once the ReferenceAddon exists.
 func (c *ReferenceAddonClientImpl) CreateOrUpdate(ctx context.Context, addon refv1alpha1.ReferenceAddon) (*refv1alpha1.ReferenceAddon, error) {
-	actualAddon := &refv1alpha1.ReferenceAddon{
+	var actual refv1alpha1.ReferenceAddon
+
+	if err := c.client.Get(ctx, client.ObjectKeyFromObject(&addon), &actual); apierrors.IsNotFound(err) {
+		desired := addon.DeepCopy()
+
+		if err := c.client.Create(ctx, desired, client.FieldOwner(controllers.DefaultFieldManager)); err != nil {
+			return nil, fmt.Errorf("creating ReferenceAddon: %w", err)
+		}
+
+		return desired, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("getting ReferenceAddon: %w", err)
+	}
+
+	// Finalizers may not be added to objects which are being deleted and
+	// applying without them would release the finalizers applied before.
+	if !actual.DeletionTimestamp.IsZero() {
+		return &actual, nil
+	}
+
+	desired := &refv1alpha1.ReferenceAddon{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      addon.Name,
-			Namespace: addon.Namespace,
+			Name:       addon.Name,
+			Namespace:  addon.Namespace,
+			Labels:     addon.Labels,
+			Finalizers: addon.Finalizers,
 		},
 	}
 
-	if _, err := ctrl.CreateOrUpdate(ctx, c.client, actualAddon, func() error {
-		actualAddon.Labels = labels.Merge(actualAddon.Labels, addon.Labels)
-		actualAddon.Spec = addon.Spec
+	if err := c.applier.Apply(ctx, desired); err != nil {
+		return nil, fmt.Errorf("applying ReferenceAddon: %w", err)
+	}
+
+	return desired, nil
+}
+
+func (c *ReferenceAddonClientImpl) Delete(ctx context.Context, addon *refv1alpha1.ReferenceAddon) error {
+	if err := c.client.Delete(ctx, addon); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("deleting ReferenceAddon: %w", err)
+	}
+
+	return nil
+}
+
+// AddFinalizer adds the given finalizer to an existing ReferenceAddon.
+// Unlike CreateOrUpdate it never creates the ReferenceAddon.
+func (c *ReferenceAddonClientImpl) AddFinalizer(ctx context.Context, addon *refv1alpha1.ReferenceAddon, finalizer string) error {
+	patch := client.MergeFrom(addon.DeepCopy())
 
+	if !controllerutil.AddFinalizer(addon, finalizer) {
 		return nil
-	}); err != nil {
-		return nil, fmt.Errorf("creating/updating ReferenceAddon: %w", err)
 	}
 
-	return actualAddon, nil
+	if err := c.client.Patch(ctx, addon, patch); err != nil {
+		return fmt.Errorf("adding finalizer %q: %w", finalizer, err)
+	}
+
+	return nil
+}
+
+func (c *ReferenceAddonClientImpl) RemoveFinalizer(ctx context.Context, addon *refv1alpha1.ReferenceAddon, finalizer string) error {
+	patch := client.MergeFrom(addon.DeepCopy())
+
+	if !controllerutil.RemoveFinalizer(addon, finalizer) {
+		return nil
+	}
+
+	if err := c.client.Patch(ctx, addon, patch); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("removing finalizer %q: %w", finalizer, err)
+	}
+
+	return nil
 }
 
 func (c *ReferenceAddonClientImpl) UpdateStatus(ctx context.Context, addon *refv1alpha1.ReferenceAddon) error {
@@ -0,0 +1,1313 @@
+package referenceaddon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/funcr"
+	refv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
+	"github.com/openshift/reference-addon/internal/controllers"
+	internaltesting "github.com/openshift/reference-addon/internal/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReferenceAddonClientImplInterfaces(t *testing.T) {
+	t.Parallel()
+
+	require.Implements(t, new(ReferenceAddonClient), new(ReferenceAddonClientImpl))
+}
+
+func TestReferenceAddonClientImpl_CreateOrUpdate(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, refv1alpha1.AddToScheme(scheme))
+
+	for name, tc := range map[string]struct {
+		ActualAddon        *refv1alpha1.ReferenceAddon
+		DesiredAddon       refv1alpha1.ReferenceAddon
+		ExpectedSpec       refv1alpha1.ReferenceAddonSpec
+		ExpectedFinalizers []string
+	}{
+		"addon does not exist": {
+			DesiredAddon: refv1alpha1.ReferenceAddon{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "test-namespace",
+				},
+				Spec: refv1alpha1.ReferenceAddonSpec{
+					Size: controllers.StringPtr("small"),
+				},
+			},
+			ExpectedSpec: refv1alpha1.ReferenceAddonSpec{
+				Size: controllers.StringPtr("small"),
+			},
+		},
+		"addon exists without finalizer": {
+			ActualAddon: &refv1alpha1.ReferenceAddon{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "test-namespace",
+				},
+			},
+			DesiredAddon: refv1alpha1.ReferenceAddon{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test",
+					Namespace:  "test-namespace",
+					Finalizers: []string{refv1alpha1.ReferenceAddonFinalizer},
+				},
+			},
+			ExpectedFinalizers: []string{refv1alpha1.ReferenceAddonFinalizer},
+		},
+		"addon exists with user provided spec": {
+			ActualAddon: &refv1alpha1.ReferenceAddon{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "test-namespace",
+				},
+				Spec: refv1alpha1.ReferenceAddonSpec{
+					ApplyNetworkPolicies: controllers.BoolPtr(true),
+				},
+			},
+			DesiredAddon: refv1alpha1.ReferenceAddon{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "test-namespace",
+				},
+			},
+			ExpectedSpec: refv1alpha1.ReferenceAddonSpec{
+				ApplyNetworkPolicies: controllers.BoolPtr(true),
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			builder := fake.
+				NewClientBuilder().
+				WithScheme(scheme).
+				WithInterceptorFuncs(internaltesting.ServerSideApplyFuncs())
+
+			if tc.ActualAddon != nil {
+				builder = builder.WithObjects(tc.ActualAddon)
+			}
+
+			c := builder.Build()
+
+			addonClient := NewReferenceAddonClient(c)
+
+			_, err := addonClient.CreateOrUpdate(context.Background(), tc.DesiredAddon)
+			require.NoError(t, err)
+
+			var actual refv1alpha1.ReferenceAddon
+
+			require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(&tc.DesiredAddon), &actual))
+
+			assert.Equal(t, tc.ExpectedSpec, actual.Spec)
+			assert.Equal(t, tc.ExpectedFinalizers, actual.Finalizers)
+		})
+	}
+}
+
+func TestReferenceAddonClientImpl_RemoveFinalizer(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, refv1alpha1.AddToScheme(scheme))
+
+	addon := &refv1alpha1.ReferenceAddon{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test",
+			Namespace:  "test-namespace",
+			Finalizers: []string{refv1alpha1.ReferenceAddonFinalizer},
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(addon).
+		Build()
+
+	addonClient := NewReferenceAddonClient(c)
+
+	require.NoError(t, addonClient.Delete(context.Background(), addon))
+
+	actual, err := addonClient.Get(context.Background(), client.ObjectKeyFromObject(addon))
+	require.NoError(t, err)
+	require.False(t, actual.DeletionTimestamp.IsZero())
+
+	require.NoError(t, addonClient.RemoveFinalizer(context.Background(), actual, refv1alpha1.ReferenceAddonFinalizer))
+
+	_, err = addonClient.Get(context.Background(), client.ObjectKeyFromObject(addon))
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestReferenceAddonClientImpl_AddFinalizer(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, refv1alpha1.AddToScheme(scheme))
+
+	addon := &refv1alpha1.ReferenceAddon{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-namespace",
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(addon).
+		Build()
+
+	addonClient := NewReferenceAddonClient(c)
+
+	actual, err := addonClient.Get(context.Background(), client.ObjectKeyFromObject(addon))
+	require.NoError(t, err)
+
+	require.NoError(t, addonClient.AddFinalizer(context.Background(), actual, refv1alpha1.ReferenceAddonFinalizer))
+
+	actual, err = addonClient.Get(context.Background(), client.ObjectKeyFromObject(addon))
+	require.NoError(t, err)
+	assert.Equal(t, []string{refv1alpha1.ReferenceAddonFinalizer}, actual.Finalizers)
+
+	missing := addon.DeepCopy()
+	missing.Name = "missing"
+
+	err = addonClient.AddFinalizer(context.Background(), missing, refv1alpha1.ReferenceAddonFinalizer)
+	require.Error(t, err)
+	assert.True(t, apierrors.IsNotFound(err), "missing ReferenceAddons are not created")
+}
+
+func TestReferenceAddonReconciler_Degraded(t *testing.T) {
+	t.Parallel()
+
+	addon := &refv1alpha1.ReferenceAddon{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-namespace",
+		},
+	}
+
+	var addonClient referenceAddonClientMock
+	addonClient.
+		On("CreateOrUpdate", mock.Anything, mock.Anything).
+		Return(addon, nil)
+	addonClient.
+		On("UpdateStatus", mock.Anything, addon).
+		Return(nil)
+
+	var getter parameterGetterMock
+	getter.
+		On("GetParameters", mock.Anything).
+		Return(NewPhaseRequestParameters(), nil)
+
+	var phase phaseMock
+	phase.
+		On("Name").
+		Return("test")
+	phase.
+		On("Execute", mock.Anything, mock.Anything).
+		Return(PhaseResultFailure("test failure")).
+		Times(2)
+	phase.
+		On("Execute", mock.Anything, mock.Anything).
+		Return(PhaseResultSuccess())
+
+	var signaler uninstallSignalerMock
+	signaler.
+		On("SignalUninstall", mock.Anything).
+		Return(false)
+
+	r := &ReferenceAddonReconciler{
+		client: &addonClient,
+		pipelines: map[string]*addonPipeline{
+			"": {
+				paramGetter:   &getter,
+				signaler:      &signaler,
+				orderedPhases: []Phase{&phase},
+			},
+		},
+		failures:    make(map[types.NamespacedName]phaseFailure),
+		paramErrors: make(map[types.NamespacedName]string),
+	}
+	r.cfg.Option(WithDegradedThreshold(2), WithReferenceAddonMode(ReferenceAddonModeBootstrap))
+	r.cfg.Default()
+
+	reconcile := func() {
+		t.Helper()
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{})
+		require.NoError(t, err)
+	}
+
+	reconcile()
+
+	degraded := meta.FindStatusCondition(addon.Status.Conditions, refv1alpha1.ReferenceAddonConditionDegraded.String())
+	require.NotNil(t, degraded)
+	assert.Equal(t, metav1.ConditionTrue, degraded.Status)
+	assert.Contains(t, degraded.Message, "test failure")
+	assert.Contains(t, degraded.Message, "test")
+	assertCondition(t, addon.Status.Conditions, refv1alpha1.ReferenceAddonConditionAvailable, refv1alpha1.ReferenceAddonAvailableReasonPending)
+
+	reconcile()
+
+	assertCondition(t, addon.Status.Conditions, refv1alpha1.ReferenceAddonConditionDegraded, refv1alpha1.ReferenceAddonDegradedReasonPhaseFailed)
+	assertCondition(t, addon.Status.Conditions, refv1alpha1.ReferenceAddonConditionAvailable, refv1alpha1.ReferenceAddonAvailableReasonDegraded)
+
+	reconcile()
+
+	assertCondition(t, addon.Status.Conditions, refv1alpha1.ReferenceAddonConditionDegraded, refv1alpha1.ReferenceAddonDegradedReasonAsExpected)
+	assertCondition(t, addon.Status.Conditions, refv1alpha1.ReferenceAddonConditionAvailable, refv1alpha1.ReferenceAddonAvailableReasonReady)
+
+	phase.AssertExpectations(t)
+}
+
+func TestReferenceAddonReconciler_PhaseError(t *testing.T) {
+	t.Parallel()
+
+	addon := &refv1alpha1.ReferenceAddon{}
+
+	var addonClient referenceAddonClientMock
+	addonClient.
+		On("CreateOrUpdate", mock.Anything, mock.Anything).
+		Return(addon, nil)
+	addonClient.
+		On("UpdateStatus", mock.Anything, addon).
+		Return(nil)
+
+	var getter parameterGetterMock
+	getter.
+		On("GetParameters", mock.Anything).
+		Return(NewPhaseRequestParameters(), nil)
+
+	var phase phaseMock
+	phase.
+		On("Name").
+		Return("test")
+	phase.
+		On("Execute", mock.Anything, mock.Anything).
+		Return(PhaseResultError(errors.New("test error")))
+
+	var signaler uninstallSignalerMock
+	signaler.
+		On("SignalUninstall", mock.Anything).
+		Return(false)
+
+	r := &ReferenceAddonReconciler{
+		client: &addonClient,
+		pipelines: map[string]*addonPipeline{
+			"": {
+				paramGetter:   &getter,
+				signaler:      &signaler,
+				orderedPhases: []Phase{&phase},
+			},
+		},
+		failures:    make(map[types.NamespacedName]phaseFailure),
+		paramErrors: make(map[types.NamespacedName]string),
+	}
+	r.cfg.Option(WithReferenceAddonMode(ReferenceAddonModeBootstrap))
+	r.cfg.Default()
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{})
+	require.Error(t, err)
+
+	assertCondition(t, addon.Status.Conditions, refv1alpha1.ReferenceAddonConditionDegraded, refv1alpha1.ReferenceAddonDegradedReasonPhaseErrored)
+}
+
+func TestReferenceAddonReconciler_UninstallSchedule(t *testing.T) {
+	t.Parallel()
+
+	addon := &refv1alpha1.ReferenceAddon{}
+
+	var addonClient referenceAddonClientMock
+	addonClient.
+		On("CreateOrUpdate", mock.Anything, mock.Anything).
+		Return(addon, nil)
+	addonClient.
+		On("UpdateStatus", mock.Anything, addon).
+		Return(nil)
+
+	var getter parameterGetterMock
+	getter.
+		On("GetParameters", mock.Anything).
+		Return(NewPhaseRequestParameters(), nil)
+
+	var signaler uninstallSignalerMock
+	signaler.
+		On("SignalUninstall", mock.Anything).
+		Return(false)
+
+	scheduledAt := metav1.Now()
+
+	var phase phaseMock
+	phase.
+		On("Name").
+		Return("test")
+	phase.
+		On("Execute", mock.Anything, mock.Anything).
+		Return(PhaseResultSuccess(
+			WithUninstallSchedule{At: &scheduledAt},
+			WithRequeueAfter(time.Minute),
+		)).
+		Once()
+	phase.
+		On("Execute", mock.Anything, mock.Anything).
+		Return(PhaseResultSuccess(WithUninstallSchedule{}))
+
+	r := &ReferenceAddonReconciler{
+		client: &addonClient,
+		pipelines: map[string]*addonPipeline{
+			"": {
+				paramGetter:   &getter,
+				signaler:      &signaler,
+				orderedPhases: []Phase{&phase},
+			},
+		},
+		failures:    make(map[types.NamespacedName]phaseFailure),
+		paramErrors: make(map[types.NamespacedName]string),
+	}
+	r.cfg.Option(WithReferenceAddonMode(ReferenceAddonModeBootstrap))
+	r.cfg.Default()
+
+	res, err := r.Reconcile(context.Background(), ctrl.Request{})
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Minute, res.RequeueAfter)
+	assert.Equal(t, &scheduledAt, addon.Status.UninstallScheduledAt)
+
+	res, err = r.Reconcile(context.Background(), ctrl.Request{})
+	require.NoError(t, err)
+
+	assert.Zero(t, res.RequeueAfter)
+	assert.Nil(t, addon.Status.UninstallScheduledAt)
+}
+
+func TestReferenceAddonReconciler_ParameterErrorEvents(t *testing.T) {
+	t.Parallel()
+
+	addon := &refv1alpha1.ReferenceAddon{}
+
+	var addonClient referenceAddonClientMock
+	addonClient.
+		On("CreateOrUpdate", mock.Anything, mock.Anything).
+		Return(addon, nil)
+	addonClient.
+		On("UpdateStatus", mock.Anything, addon).
+		Return(nil)
+
+	var getter parameterGetterMock
+	getter.
+		On("GetParameters", mock.Anything).
+		Return(NewPhaseRequestParameters(), errors.New("invalid bool value")).
+		Times(2)
+	getter.
+		On("GetParameters", mock.Anything).
+		Return(NewPhaseRequestParameters(), nil).
+		Once()
+	getter.
+		On("GetParameters", mock.Anything).
+		Return(NewPhaseRequestParameters(), errors.New("invalid bool value"))
+
+	var signaler uninstallSignalerMock
+	signaler.
+		On("SignalUninstall", mock.Anything).
+		Return(false)
+
+	recorder := record.NewFakeRecorder(10)
+
+	r := &ReferenceAddonReconciler{
+		client: &addonClient,
+		pipelines: map[string]*addonPipeline{
+			"": {
+				paramGetter: &getter,
+				signaler:    &signaler,
+			},
+		},
+		failures:    make(map[types.NamespacedName]phaseFailure),
+		paramErrors: make(map[types.NamespacedName]string),
+	}
+	r.cfg.Option(WithEventRecorder{Recorder: recorder}, WithReferenceAddonMode(ReferenceAddonModeBootstrap))
+	r.cfg.Default()
+
+	for i := 0; i < 4; i++ {
+		_, err := r.Reconcile(context.Background(), ctrl.Request{})
+		require.NoError(t, err)
+	}
+
+	close(recorder.Events)
+
+	var events []string
+
+	for e := range recorder.Events {
+		events = append(events, e)
+	}
+
+	assert.Equal(t, []string{
+		"Warning InvalidParameters invalid bool value",
+		"Warning InvalidParameters invalid bool value",
+	}, events)
+}
+
+func TestReferenceAddonReconciler_ParameterSourceNotFoundLogging(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Err                 error
+		ExpectedErrorLogged bool
+	}{
+		"secret not found": {
+			Err: apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "addon-parameters"),
+		},
+		"parameter source not found": {
+			Err: fmt.Errorf("%w: none of [test] exist", ErrParameterSourceNotFound),
+		},
+		"invalid parameters": {
+			Err:                 errors.New("invalid bool value"),
+			ExpectedErrorLogged: true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := &refv1alpha1.ReferenceAddon{}
+
+			var addonClient referenceAddonClientMock
+			addonClient.
+				On("CreateOrUpdate", mock.Anything, mock.Anything).
+				Return(addon, nil)
+			addonClient.
+				On("UpdateStatus", mock.Anything, addon).
+				Return(nil)
+
+			var getter parameterGetterMock
+			getter.
+				On("GetParameters", mock.Anything).
+				Return(NewPhaseRequestParameters(), tc.Err)
+
+			var signaler uninstallSignalerMock
+			signaler.
+				On("SignalUninstall", mock.Anything).
+				Return(false)
+
+			var errorLogged bool
+
+			log := funcr.New(func(_, args string) {
+				if strings.Contains(args, `"error"=`) {
+					errorLogged = true
+				}
+			}, funcr.Options{})
+
+			r := &ReferenceAddonReconciler{
+				client: &addonClient,
+				pipelines: map[string]*addonPipeline{
+					"": {
+						paramGetter: &getter,
+						signaler:    &signaler,
+					},
+				},
+				failures:    make(map[types.NamespacedName]phaseFailure),
+				paramErrors: make(map[types.NamespacedName]string),
+			}
+			r.cfg.Option(
+				WithLog{Log: log},
+				WithEventRecorder{Recorder: record.NewFakeRecorder(10)},
+				WithReferenceAddonMode(ReferenceAddonModeBootstrap),
+			)
+			r.cfg.Default()
+
+			_, err := r.Reconcile(context.Background(), ctrl.Request{})
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.ExpectedErrorLogged, errorLogged)
+		})
+	}
+}
+
+func TestReferenceAddonReconciler_LastValidParameters(t *testing.T) {
+	t.Parallel()
+
+	addon := &refv1alpha1.ReferenceAddon{}
+
+	var addonClient referenceAddonClientMock
+	addonClient.
+		On("CreateOrUpdate", mock.Anything, mock.Anything).
+		Return(addon, nil)
+	addonClient.
+		On("UpdateStatus", mock.Anything, addon).
+		Return(nil)
+
+	invalid := &ParameterError{Key: applyNetworkPoliciesID, Err: ErrInvalidBoolValue}
+
+	var getter parameterGetterMock
+	getter.
+		On("GetParameters", mock.Anything).
+		Return(NewPhaseRequestParameters(), errors.New("connection refused")).
+		Once()
+	getter.
+		On("GetParameters", mock.Anything).
+		Return(NewPhaseRequestParameters(WithApplyNetworkPolicies{Value: controllers.BoolPtr(true)}), nil).
+		Once()
+	getter.
+		On("GetParameters", mock.Anything).
+		Return(NewPhaseRequestParameters(), fmt.Errorf("parsing addon parameters: %w", invalid))
+
+	applied := func(expected bool) interface{} {
+		return mock.MatchedBy(func(req PhaseRequest) bool {
+			_, ok := req.Params.GetApplyNetworkPolicies()
+
+			return ok == expected
+		})
+	}
+
+	var phase phaseMock
+	phase.
+		On("Name").
+		Return("test")
+	phase.
+		On("Execute", mock.Anything, applied(false)).
+		Return(PhaseResultSuccess()).
+		Once()
+	phase.
+		On("Execute", mock.Anything, applied(true)).
+		Return(PhaseResultSuccess()).
+		Twice()
+
+	var signaler uninstallSignalerMock
+	signaler.
+		On("SignalUninstall", mock.Anything).
+		Return(false)
+
+	r := &ReferenceAddonReconciler{
+		client: &addonClient,
+		pipelines: map[string]*addonPipeline{
+			"": {
+				paramGetter:   &getter,
+				signaler:      &signaler,
+				orderedPhases: []Phase{&phase},
+			},
+		},
+		failures:    make(map[types.NamespacedName]phaseFailure),
+		paramErrors: make(map[types.NamespacedName]string),
+	}
+	r.cfg.Option(WithReferenceAddonMode(ReferenceAddonModeBootstrap))
+	r.cfg.Default()
+
+	reconcile := func() {
+		t.Helper()
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{})
+		require.NoError(t, err)
+	}
+
+	reconcile()
+
+	assertCondition(t, addon.Status.Conditions, refv1alpha1.ReferenceAddonConditionParametersValid, refv1alpha1.ParametersValidReasonLookupFailed)
+
+	reconcile()
+
+	assertCondition(t, addon.Status.Conditions, refv1alpha1.ReferenceAddonConditionParametersValid, refv1alpha1.ParametersValidReasonValid)
+
+	reconcile()
+
+	assertCondition(t, addon.Status.Conditions, refv1alpha1.ReferenceAddonConditionParametersValid, refv1alpha1.ParametersValidReasonInvalidValue)
+
+	cond := meta.FindStatusCondition(addon.Status.Conditions, refv1alpha1.ReferenceAddonConditionParametersValid.String())
+	assert.Contains(t, cond.Message, applyNetworkPoliciesID)
+
+	phase.AssertExpectations(t)
+}
+
+func TestReferenceAddonReconciler_ObservedParameters(t *testing.T) {
+	t.Parallel()
+
+	addon := &refv1alpha1.ReferenceAddon{
+		ObjectMeta: metav1.ObjectMeta{
+			Generation: 1,
+		},
+	}
+
+	var addonClient referenceAddonClientMock
+	addonClient.
+		On("CreateOrUpdate", mock.Anything, mock.Anything).
+		Return(addon, nil)
+	addonClient.
+		On("UpdateStatus", mock.Anything, addon).
+		Return(nil)
+
+	small := NewPhaseRequestParameters(WithSize{Value: controllers.StringPtr("small")})
+	large := NewPhaseRequestParameters(WithSize{Value: controllers.StringPtr("large")})
+
+	var getter parameterGetterMock
+	getter.
+		On("GetParameters", mock.Anything).
+		Return(small, nil).
+		Twice()
+	getter.
+		On("GetParameters", mock.Anything).
+		Return(large, nil)
+
+	unchanged := func(expected bool) interface{} {
+		return mock.MatchedBy(func(req PhaseRequest) bool {
+			return req.Unchanged == expected
+		})
+	}
+
+	var phase phaseMock
+	phase.
+		On("Name").
+		Return("test")
+	phase.
+		On("Execute", mock.Anything, unchanged(false)).
+		Return(PhaseResultSuccess()).
+		Twice()
+	phase.
+		On("Execute", mock.Anything, unchanged(true)).
+		Return(PhaseResultSuccess()).
+		Once()
+
+	var signaler uninstallSignalerMock
+	signaler.
+		On("SignalUninstall", mock.Anything).
+		Return(false)
+
+	r := &ReferenceAddonReconciler{
+		client: &addonClient,
+		pipelines: map[string]*addonPipeline{
+			"": {
+				paramGetter:   &getter,
+				signaler:      &signaler,
+				orderedPhases: []Phase{&phase},
+			},
+		},
+		failures:    make(map[types.NamespacedName]phaseFailure),
+		paramErrors: make(map[types.NamespacedName]string),
+	}
+	r.cfg.Option(WithReferenceAddonMode(ReferenceAddonModeBootstrap))
+	r.cfg.Default()
+
+	reconcile := func() {
+		t.Helper()
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{})
+		require.NoError(t, err)
+	}
+
+	reconcile()
+
+	assert.Equal(t, small.Hash(), addon.Status.ObservedParametersHash)
+	assert.Equal(t, int64(1), addon.Status.ObservedGeneration)
+	assert.Equal(t, []refv1alpha1.ObservedParameter{
+		{Key: sizeParameterID, Value: "small"},
+	}, addon.Status.Parameters)
+
+	reconcile()
+	reconcile()
+
+	assert.Equal(t, large.Hash(), addon.Status.ObservedParametersHash)
+
+	phase.AssertExpectations(t)
+}
+
+func TestReferenceAddonReconciler_InvalidSpecParameters(t *testing.T) {
+	t.Parallel()
+
+	addon := &refv1alpha1.ReferenceAddon{
+		Spec: refv1alpha1.ReferenceAddonSpec{
+			AllowedCIDRs: []string{"not-a-cidr"},
+		},
+	}
+
+	var addonClient referenceAddonClientMock
+	addonClient.
+		On("CreateOrUpdate", mock.Anything, mock.Anything).
+		Return(addon, nil)
+	addonClient.
+		On("UpdateStatus", mock.Anything, addon).
+		Return(nil)
+
+	var getter parameterGetterMock
+	getter.
+		On("GetParameters", mock.Anything).
+		Return(NewPhaseRequestParameters(WithAllowedCIDRs{"10.0.0.0/8"}), nil)
+
+	var phase phaseMock
+	phase.
+		On("Name").
+		Return("test")
+	phase.
+		On("Execute", mock.Anything, mock.MatchedBy(func(req PhaseRequest) bool {
+			cidrs, _ := req.Params.GetAllowedCIDRs()
+
+			return assert.ObjectsAreEqual([]string{"10.0.0.0/8"}, cidrs)
+		})).
+		Return(PhaseResultSuccess())
+
+	var signaler uninstallSignalerMock
+	signaler.
+		On("SignalUninstall", mock.Anything).
+		Return(false)
+
+	recorder := record.NewFakeRecorder(10)
+
+	r := &ReferenceAddonReconciler{
+		client: &addonClient,
+		pipelines: map[string]*addonPipeline{
+			"": {
+				paramGetter:   &getter,
+				signaler:      &signaler,
+				orderedPhases: []Phase{&phase},
+			},
+		},
+		failures:    make(map[types.NamespacedName]phaseFailure),
+		paramErrors: make(map[types.NamespacedName]string),
+	}
+	r.cfg.Option(WithEventRecorder{Recorder: recorder}, WithReferenceAddonMode(ReferenceAddonModeBootstrap))
+	r.cfg.Default()
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{})
+	require.NoError(t, err)
+
+	assertCondition(t, addon.Status.Conditions, refv1alpha1.ReferenceAddonConditionParametersValid, refv1alpha1.ParametersValidReasonInvalidValue)
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, `spec: parameter "allowedcidrs"`)
+
+	phase.AssertExpectations(t)
+}
+
+func TestReferenceAddonReconciler_Teardown(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		TeardownResult          PhaseResult
+		UninstallPending        bool
+		TeardownAcknowledged    bool
+		UpdateStatusError       error
+		ExpectAcknowledgement   bool
+		ExpectFinalizerReleased bool
+		ExpectRequeue           bool
+		AssertError             require.ErrorAssertionFunc
+	}{
+		"teardown succeeds": {
+			TeardownResult:          PhaseResultSuccess(),
+			ExpectFinalizerReleased: true,
+			AssertError:             require.NoError,
+		},
+		"teardown fails": {
+			TeardownResult: PhaseResultError(errors.New("test error")),
+			AssertError:    require.Error,
+		},
+		"status update fails": {
+			TeardownResult:    PhaseResultSuccess(),
+			UpdateStatusError: errors.New("test error"),
+			AssertError:       require.Error,
+		},
+		"uninstall teardown acknowledged": {
+			TeardownResult:          PhaseResultSuccess(),
+			UninstallPending:        true,
+			TeardownAcknowledged:    true,
+			ExpectAcknowledgement:   true,
+			ExpectFinalizerReleased: true,
+			AssertError:             require.NoError,
+		},
+		"uninstall teardown not acknowledged": {
+			TeardownResult:        PhaseResultSuccess(),
+			UninstallPending:      true,
+			ExpectAcknowledgement: true,
+			ExpectRequeue:         true,
+			AssertError:           require.NoError,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			now := metav1.Now()
+
+			addon := &refv1alpha1.ReferenceAddon{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "test",
+					Namespace:         "test-namespace",
+					DeletionTimestamp: &now,
+					Finalizers:        []string{refv1alpha1.ReferenceAddonFinalizer},
+				},
+			}
+
+			if tc.UninstallPending {
+				meta.SetStatusCondition(&addon.Status.Conditions, newUninstallPendingCondition(
+					refv1alpha1.UninstallPendingReasonInProgress, "uninstalling",
+				))
+			}
+
+			var addonClient referenceAddonClientMock
+			addonClient.
+				On("CreateOrUpdate", mock.Anything, mock.Anything).
+				Return(addon, nil)
+			addonClient.
+				On("UpdateStatus", mock.Anything, addon).
+				Return(tc.UpdateStatusError)
+
+			if tc.ExpectFinalizerReleased {
+				addonClient.
+					On("RemoveFinalizer", mock.Anything, addon, refv1alpha1.ReferenceAddonFinalizer).
+					Return(nil)
+			}
+
+			var getter parameterGetterMock
+			getter.
+				On("GetParameters", mock.Anything).
+				Return(NewPhaseRequestParameters(), nil)
+
+			var signaler uninstallSignalerMock
+			signaler.
+				On("SignalUninstall", mock.Anything).
+				Return(false)
+
+			var teardown phaseMock
+			teardown.
+				On("Name").
+				Return("teardown")
+			teardown.
+				On("Execute", mock.Anything, mock.Anything).
+				Return(tc.TeardownResult)
+
+			var ack teardownAcknowledgerMock
+			if tc.ExpectAcknowledgement {
+				ack.
+					On("TeardownAcknowledged", mock.Anything).
+					Return(tc.TeardownAcknowledged, nil)
+			}
+
+			// Regular phases must not run while tearing down.
+			var phase phaseMock
+
+			r := &ReferenceAddonReconciler{
+				client: &addonClient,
+				pipelines: map[string]*addonPipeline{
+					"": {
+						paramGetter:   &getter,
+						signaler:      &signaler,
+						orderedPhases: []Phase{&phase},
+						teardownPhase: &teardown,
+						teardownAck:   &ack,
+					},
+				},
+				failures:    make(map[types.NamespacedName]phaseFailure),
+				paramErrors: make(map[types.NamespacedName]string),
+			}
+			r.cfg.Option(WithReferenceAddonMode(ReferenceAddonModeBootstrap))
+			r.cfg.Default()
+
+			res, err := r.Reconcile(context.Background(), ctrl.Request{})
+			tc.AssertError(t, err)
+
+			assert.Equal(t, tc.ExpectRequeue, res.Requeue)
+			assertCondition(t, addon.Status.Conditions, refv1alpha1.ReferenceAddonConditionAvailable, refv1alpha1.ReferenceAddonAvailableReasonUninstalling)
+
+			if !tc.ExpectFinalizerReleased {
+				addonClient.AssertNotCalled(t, "RemoveFinalizer", mock.Anything, mock.Anything, mock.Anything)
+			}
+
+			addonClient.AssertExpectations(t)
+			teardown.AssertExpectations(t)
+			ack.AssertExpectations(t)
+			phase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReferenceAddonReconciler_UninstalledAddonNotRecreated(t *testing.T) {
+	t.Parallel()
+
+	var addonClient referenceAddonClientMock
+	addonClient.
+		On("Get", mock.Anything, mock.Anything).
+		Return((*refv1alpha1.ReferenceAddon)(nil), apierrors.NewNotFound(schema.GroupResource{}, "test"))
+
+	var getter parameterGetterMock
+	getter.
+		On("GetParameters", mock.Anything).
+		Return(NewPhaseRequestParameters(), nil)
+
+	var signaler uninstallSignalerMock
+	signaler.
+		On("SignalUninstall", mock.Anything).
+		Return(true)
+
+	r := &ReferenceAddonReconciler{
+		client: &addonClient,
+		pipelines: map[string]*addonPipeline{
+			"": {
+				paramGetter: &getter,
+				signaler:    &signaler,
+			},
+		},
+		failures:    make(map[types.NamespacedName]phaseFailure),
+		paramErrors: make(map[types.NamespacedName]string),
+	}
+	r.cfg.Option(WithReferenceAddonMode(ReferenceAddonModeBootstrap))
+	r.cfg.Default()
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{})
+	require.NoError(t, err)
+
+	addonClient.AssertExpectations(t)
+	addonClient.AssertNotCalled(t, "CreateOrUpdate", mock.Anything, mock.Anything)
+}
+
+func TestNewReferenceAddonReconciler_InstallMode(t *testing.T) {
+	t.Parallel()
+
+	factory := WithParameterGetterFactory{
+		Factory: func(string) (ParameterGetter, error) {
+			return &parameterGetterMock{}, nil
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		Options     []ReferenceAddonReconcilerOption
+		AssertError require.ErrorAssertionFunc
+	}{
+		"default": {
+			AssertError: require.NoError,
+		},
+		"all namespaces": {
+			Options: []ReferenceAddonReconcilerOption{
+				WithInstallMode(controllers.InstallModeAllNamespaces),
+				factory,
+			},
+			AssertError: require.NoError,
+		},
+		"multi namespace without parameter getter factory": {
+			Options: []ReferenceAddonReconcilerOption{
+				WithInstallMode(controllers.InstallModeMultiNamespace),
+			},
+			AssertError: require.Error,
+		},
+		"unknown": {
+			Options: []ReferenceAddonReconcilerOption{
+				WithInstallMode("SingleNamespace"),
+			},
+			AssertError: func(t require.TestingT, err error, _ ...interface{}) {
+				require.ErrorIs(t, err, controllers.ErrUnknownInstallMode)
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := append([]ReferenceAddonReconcilerOption{
+				WithAddonNamespace("test-namespace"),
+				WithOperatorName("test"),
+				WithDeleteLabel("test-delete"),
+			}, tc.Options...)
+
+			_, err := NewReferenceAddonReconciler(fake.NewClientBuilder().Build(), &parameterGetterMock{}, opts...)
+			tc.AssertError(t, err)
+		})
+	}
+}
+
+func TestReferenceAddonReconciler_MultiNamespace(t *testing.T) {
+	t.Parallel()
+
+	addon := &refv1alpha1.ReferenceAddon{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "namespace-a",
+		},
+	}
+	addonKey := client.ObjectKeyFromObject(addon)
+	missingKey := types.NamespacedName{Name: "test", Namespace: "namespace-b"}
+
+	var addonClient referenceAddonClientMock
+	addonClient.
+		On("Get", mock.Anything, addonKey).
+		Return(addon, nil)
+	addonClient.
+		On("Get", mock.Anything, missingKey).
+		Return((*refv1alpha1.ReferenceAddon)(nil), apierrors.NewNotFound(schema.GroupResource{}, "test"))
+	addonClient.
+		On("AddFinalizer", mock.Anything, addon, refv1alpha1.ReferenceAddonFinalizer).
+		Return(nil)
+	addonClient.
+		On("UpdateStatus", mock.Anything, addon).
+		Return(nil)
+
+	phases := make(map[string]*phaseMock)
+	builds := make(map[string]int)
+
+	newPipeline := func(namespace string) (*addonPipeline, error) {
+		builds[namespace]++
+
+		var getter parameterGetterMock
+		getter.
+			On("GetParameters", mock.Anything).
+			Return(NewPhaseRequestParameters(), nil)
+
+		var signaler uninstallSignalerMock
+		signaler.
+			On("SignalUninstall", mock.Anything).
+			Return(false)
+
+		var phase phaseMock
+		phase.
+			On("Name").
+			Return("test")
+		phase.
+			On("Execute", mock.Anything, mock.MatchedBy(func(req PhaseRequest) bool {
+				return req.Addon.Namespace == namespace
+			})).
+			Return(PhaseResultSuccess())
+
+		phases[namespace] = &phase
+
+		return &addonPipeline{
+			paramGetter:   &getter,
+			signaler:      &signaler,
+			orderedPhases: []Phase{&phase},
+		}, nil
+	}
+
+	r := &ReferenceAddonReconciler{
+		client:      &addonClient,
+		pipelines:   make(map[string]*addonPipeline),
+		newPipeline: newPipeline,
+		failures:    make(map[types.NamespacedName]phaseFailure),
+		paramErrors: make(map[types.NamespacedName]string),
+	}
+	r.cfg.Option(
+		WithInstallMode(controllers.InstallModeMultiNamespace),
+		WithOperatorName("test"),
+	)
+	r.cfg.Default()
+
+	for _, key := range []types.NamespacedName{addonKey, missingKey, addonKey, missingKey} {
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+		require.NoError(t, err)
+	}
+
+	require.Len(t, phases, 2)
+	assert.Equal(t, map[string]int{"namespace-a": 1, "namespace-b": 2}, builds,
+		"pipelines are kept while the ReferenceAddon exists and dropped once it is gone")
+	assert.NotContains(t, r.pipelines, "namespace-b")
+
+	phases["namespace-a"].AssertNumberOfCalls(t, "Execute", 2)
+	phases["namespace-b"].AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+
+	assertCondition(t, addon.Status.Conditions, refv1alpha1.ReferenceAddonConditionAvailable, refv1alpha1.ReferenceAddonAvailableReasonReady)
+
+	addonClient.AssertExpectations(t)
+	addonClient.AssertNotCalled(t, "CreateOrUpdate", mock.Anything, mock.Anything)
+}
+
+func TestReferenceAddonReconciler_ReferenceAddonMode(t *testing.T) {
+	t.Parallel()
+
+	key := types.NamespacedName{Name: "test", Namespace: "test-namespace"}
+	notFound := apierrors.NewNotFound(schema.GroupResource{}, "test")
+
+	for name, tc := range map[string]struct {
+		Mode            ReferenceAddonMode
+		Existing        *refv1alpha1.ReferenceAddon
+		ExpectCreate    bool
+		ExpectAdopt     bool
+		ExpectReconcile bool
+	}{
+		"adopt/absent": {
+			Mode: ReferenceAddonModeAdopt,
+		},
+		"adopt/present": {
+			Mode: ReferenceAddonModeAdopt,
+			Existing: &refv1alpha1.ReferenceAddon{
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			},
+			ExpectAdopt:     true,
+			ExpectReconcile: true,
+		},
+		"bootstrap/absent": {
+			Mode:            ReferenceAddonModeBootstrap,
+			ExpectCreate:    true,
+			ExpectReconcile: true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var addonClient referenceAddonClientMock
+
+			if tc.Existing != nil {
+				addonClient.
+					On("Get", mock.Anything, key).
+					Return(tc.Existing, nil)
+			} else {
+				addonClient.
+					On("Get", mock.Anything, key).
+					Return((*refv1alpha1.ReferenceAddon)(nil), notFound).
+					Maybe()
+			}
+
+			if tc.ExpectAdopt {
+				addonClient.
+					On("AddFinalizer", mock.Anything, tc.Existing, refv1alpha1.ReferenceAddonFinalizer).
+					Return(nil)
+			}
+
+			if tc.ExpectCreate {
+				addonClient.
+					On("CreateOrUpdate", mock.Anything, mock.MatchedBy(func(ra refv1alpha1.ReferenceAddon) bool {
+						return client.ObjectKeyFromObject(&ra) == key
+					})).
+					Return(&refv1alpha1.ReferenceAddon{}, nil)
+			}
+
+			if tc.ExpectReconcile {
+				addonClient.
+					On("UpdateStatus", mock.Anything, mock.Anything).
+					Return(nil)
+			}
+
+			var getter parameterGetterMock
+			getter.
+				On("GetParameters", mock.Anything).
+				Return(NewPhaseRequestParameters(), nil)
+
+			var signaler uninstallSignalerMock
+			signaler.
+				On("SignalUninstall", mock.Anything).
+				Return(false)
+
+			r := &ReferenceAddonReconciler{
+				client: &addonClient,
+				pipelines: map[string]*addonPipeline{
+					key.Namespace: {
+						paramGetter: &getter,
+						signaler:    &signaler,
+					},
+				},
+				failures:    make(map[types.NamespacedName]phaseFailure),
+				paramErrors: make(map[types.NamespacedName]string),
+			}
+			r.cfg.Option(
+				WithReferenceAddonMode(tc.Mode),
+				WithAddonNamespace(key.Namespace),
+				WithOperatorName(key.Name),
+			)
+			r.cfg.Default()
+
+			_, err := r.Reconcile(context.Background(), ctrl.Request{})
+			require.NoError(t, err)
+
+			addonClient.AssertExpectations(t)
+
+			if !tc.ExpectCreate {
+				addonClient.AssertNotCalled(t, "CreateOrUpdate", mock.Anything, mock.Anything)
+			}
+
+			if !tc.ExpectReconcile {
+				addonClient.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+type referenceAddonClientMock struct {
+	mock.Mock
+}
+
+func (m *referenceAddonClientMock) CreateOrUpdate(ctx context.Context, addon refv1alpha1.ReferenceAddon) (*refv1alpha1.ReferenceAddon, error) {
+	args := m.Called(ctx, addon)
+
+	return args.Get(0).(*refv1alpha1.ReferenceAddon), args.Error(1)
+}
+
+func (m *referenceAddonClientMock) Get(ctx context.Context, key types.NamespacedName) (*refv1alpha1.ReferenceAddon, error) {
+	args := m.Called(ctx, key)
+
+	return args.Get(0).(*refv1alpha1.ReferenceAddon), args.Error(1)
+}
+
+func (m *referenceAddonClientMock) Delete(ctx context.Context, addon *refv1alpha1.ReferenceAddon) error {
+	args := m.Called(ctx, addon)
+
+	return args.Error(0)
+}
+
+func (m *referenceAddonClientMock) AddFinalizer(ctx context.Context, addon *refv1alpha1.ReferenceAddon, finalizer string) error {
+	args := m.Called(ctx, addon, finalizer)
+
+	return args.Error(0)
+}
+
+func (m *referenceAddonClientMock) RemoveFinalizer(ctx context.Context, addon *refv1alpha1.ReferenceAddon, finalizer string) error {
+	args := m.Called(ctx, addon, finalizer)
+
+	return args.Error(0)
+}
+
+func (m *referenceAddonClientMock) UpdateStatus(ctx context.Context, addon *refv1alpha1.ReferenceAddon) error {
+	args := m.Called(ctx, addon)
+
+	return args.Error(0)
+}
+
+type parameterGetterMock struct {
+	mock.Mock
+}
+
+func (m *parameterGetterMock) GetParameters(ctx context.Context) (PhaseRequestParameters, error) {
+	args := m.Called(ctx)
+
+	return args.Get(0).(PhaseRequestParameters), args.Error(1)
+}
+
+type phaseMock struct {
+	mock.Mock
+}
+
+func (m *phaseMock) Name() string {
+	args := m.Called()
+
+	return args.String(0)
+}
+
+func (m *phaseMock) Execute(ctx context.Context, req PhaseRequest) PhaseResult {
+	args := m.Called(ctx, req)
+
+	return args.Get(0).(PhaseResult)
+}
+
+type teardownAcknowledgerMock struct {
+	mock.Mock
+}
+
+func (m *teardownAcknowledgerMock) TeardownAcknowledged(ctx context.Context) (bool, error) {
+	args := m.Called(ctx)
+
+	return args.Bool(0), args.Error(1)
+}
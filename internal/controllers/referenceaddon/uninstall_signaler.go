@@ -0,0 +1,537 @@
+package referenceaddon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	av1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	refv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
+	"github.com/openshift/reference-addon/internal/controllers"
+	"go.uber.org/multierr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// UninstallSignalerKind identifies an UninstallSignaler implementation.
+type UninstallSignalerKind string
+
+const (
+	// UninstallSignalerKindConfigMap signals an uninstall when the
+	// ConfigMap named after the operator carries the delete label.
+	UninstallSignalerKindConfigMap UninstallSignalerKind = "configmap"
+	// UninstallSignalerKindAnnotation signals an uninstall when the
+	// ReferenceAddon carries the uninstall annotation.
+	UninstallSignalerKindAnnotation UninstallSignalerKind = "annotation"
+	// UninstallSignalerKindAddonInstance signals an uninstall when the
+	// AddonInstance has been marked for deletion.
+	UninstallSignalerKindAddonInstance UninstallSignalerKind = "addoninstance"
+	// UninstallSignalerKindUninstallRequest signals an uninstall when an
+	// UninstallRequest named after the operator exists.
+	UninstallSignalerKindUninstallRequest UninstallSignalerKind = "uninstallrequest"
+	// UninstallSignalerKindParameter signals an uninstall when the addon
+	// parameters set the uninstall parameter to "true".
+	UninstallSignalerKindParameter UninstallSignalerKind = "parameter"
+)
+
+var ErrUnknownUninstallSignalerKind = errors.New("unknown uninstall signaler kind")
+
+// UninstallSignalMode determines how the results of multiple
+// signalers are combined.
+type UninstallSignalMode string
+
+const (
+	// UninstallSignalModeAny signals an uninstall when any signaler does.
+	UninstallSignalModeAny UninstallSignalMode = "any"
+	// UninstallSignalModeAll signals an uninstall only when all signalers do.
+	UninstallSignalModeAll UninstallSignalMode = "all"
+)
+
+var ErrUnknownUninstallSignalMode = errors.New("unknown uninstall signal mode")
+
+const (
+	// DefaultUninstallAnnotation is the ReferenceAddon annotation
+	// checked by the annotation signaler.
+	DefaultUninstallAnnotation = "reference.addons.managed.openshift.io/uninstall"
+	// DefaultUninstallParameterID is the addon parameter
+	// checked by the parameter signaler.
+	DefaultUninstallParameterID = "uninstall"
+)
+
+// NewUninstallSignaler combines signalers of the given kinds into a
+// single UninstallSignaler using the given mode.
+func NewUninstallSignaler(
+	client client.Client,
+	kinds []UninstallSignalerKind,
+	mode UninstallSignalMode,
+	opts ...UninstallSignalerOption,
+) (*CompositeUninstallSignaler, error) {
+	var cfg UninstallSignalerConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	signalers := make([]UninstallSignaler, 0, len(kinds))
+
+	for _, kind := range kinds {
+		var (
+			signaler UninstallSignaler
+			err      error
+		)
+
+		switch kind {
+		case UninstallSignalerKindConfigMap:
+			signaler, err = NewConfigMapUninstallSignaler(
+				client,
+				WithLog{Log: cfg.Log},
+				WithAddonNamespace(cfg.AddonNamespace),
+				WithOperatorName(cfg.OperatorName),
+				WithDeleteLabel(cfg.DeleteLabel),
+			)
+		case UninstallSignalerKindAnnotation:
+			signaler, err = NewAnnotationUninstallSignaler(
+				client,
+				WithLog{Log: cfg.Log},
+				WithAddonNamespace(cfg.AddonNamespace),
+				WithOperatorName(cfg.OperatorName),
+			)
+		case UninstallSignalerKindAddonInstance:
+			signaler, err = NewAddonInstanceUninstallSignaler(
+				client,
+				WithLog{Log: cfg.Log},
+				WithAddonInstanceNamespace(cfg.AddonInstanceNamespace),
+				WithAddonInstanceName(cfg.AddonInstanceName),
+			)
+		case UninstallSignalerKindUninstallRequest:
+			signaler, err = NewUninstallRequestUninstallSignaler(
+				client,
+				WithLog{Log: cfg.Log},
+				WithAddonNamespace(cfg.AddonNamespace),
+				WithOperatorName(cfg.OperatorName),
+			)
+		case UninstallSignalerKindParameter:
+			signaler, err = NewParameterUninstallSignaler(
+				WithLog{Log: cfg.Log},
+				WithParameterGetter{Getter: cfg.ParameterGetter},
+			)
+		default:
+			err = fmt.Errorf("%w: %q", ErrUnknownUninstallSignalerKind, kind)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("initializing %q uninstall signaler: %w", kind, err)
+		}
+
+		signalers = append(signalers, signaler)
+	}
+
+	return NewCompositeUninstallSignaler(mode, signalers...)
+}
+
+type UninstallSignalerConfig struct {
+	Log                    logr.Logger
+	AddonNamespace         string
+	AddonInstanceNamespace string
+	AddonInstanceName      string
+	OperatorName           string
+	DeleteLabel            string
+	ParameterGetter        ParameterGetter
+}
+
+func (c *UninstallSignalerConfig) Option(opts ...UninstallSignalerOption) {
+	for _, opt := range opts {
+		opt.ConfigureUninstallSignaler(c)
+	}
+}
+
+func (c *UninstallSignalerConfig) Default() {
+	if c.Log.GetSink() == nil {
+		c.Log = logr.Discard()
+	}
+}
+
+type UninstallSignalerOption interface {
+	ConfigureUninstallSignaler(*UninstallSignalerConfig)
+}
+
+func NewCompositeUninstallSignaler(mode UninstallSignalMode, signalers ...UninstallSignaler) (*CompositeUninstallSignaler, error) {
+	switch mode {
+	case UninstallSignalModeAny, UninstallSignalModeAll:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownUninstallSignalMode, mode)
+	}
+
+	if len(signalers) == 0 {
+		return nil, fmt.Errorf("validating signalers: %w", controllers.ErrEmptyOptionValue)
+	}
+
+	return &CompositeUninstallSignaler{
+		mode:      mode,
+		signalers: signalers,
+	}, nil
+}
+
+// CompositeUninstallSignaler combines the signals of
+// multiple UninstallSignalers.
+type CompositeUninstallSignaler struct {
+	mode      UninstallSignalMode
+	signalers []UninstallSignaler
+}
+
+func (s *CompositeUninstallSignaler) SignalUninstall(ctx context.Context) bool {
+	for _, signaler := range s.signalers {
+		signaled := signaler.SignalUninstall(ctx)
+
+		if signaled && s.mode == UninstallSignalModeAny {
+			return true
+		}
+
+		if !signaled && s.mode == UninstallSignalModeAll {
+			return false
+		}
+	}
+
+	return s.mode == UninstallSignalModeAll
+}
+
+func NewAnnotationUninstallSignaler(client client.Client, opts ...AnnotationUninstallSignalerOption) (*AnnotationUninstallSignaler, error) {
+	var cfg AnnotationUninstallSignalerConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("validating config: %w", err)
+	}
+
+	return &AnnotationUninstallSignaler{
+		cfg: cfg,
+
+		client: client,
+	}, nil
+}
+
+// AnnotationUninstallSignaler signals an uninstall when the
+// ReferenceAddon is annotated with the uninstall annotation
+// set to "true".
+type AnnotationUninstallSignaler struct {
+	cfg AnnotationUninstallSignalerConfig
+
+	client client.Client
+}
+
+func (s *AnnotationUninstallSignaler) SignalUninstall(ctx context.Context) bool {
+	tgt := types.NamespacedName{
+		Namespace: s.cfg.AddonNamespace,
+		Name:      s.cfg.OperatorName,
+	}
+
+	var addon refv1alpha1.ReferenceAddon
+
+	if err := s.client.Get(ctx, tgt, &addon); err != nil {
+		logSignalerError(s.cfg.Log, err, "getting ReferenceAddon", tgt)
+
+		return false
+	}
+
+	val, ok := addon.Annotations[s.cfg.Annotation]
+	if !ok {
+		return false
+	}
+
+	signaled, err := parseBool(val)
+	if err != nil {
+		s.cfg.Log.Error(err, "parsing uninstall annotation", "annotation", s.cfg.Annotation)
+
+		return false
+	}
+
+	return signaled
+}
+
+type AnnotationUninstallSignalerConfig struct {
+	Log            logr.Logger
+	AddonNamespace string
+	OperatorName   string
+	Annotation     string
+}
+
+func (c *AnnotationUninstallSignalerConfig) Option(opts ...AnnotationUninstallSignalerOption) {
+	for _, opt := range opts {
+		opt.ConfigureAnnotationUninstallSignaler(c)
+	}
+}
+
+func (c *AnnotationUninstallSignalerConfig) Default() {
+	if c.Log.GetSink() == nil {
+		c.Log = logr.Discard()
+	}
+
+	if c.Annotation == "" {
+		c.Annotation = DefaultUninstallAnnotation
+	}
+}
+
+func (c *AnnotationUninstallSignalerConfig) Validate() error {
+	var finalErr error
+
+	if err := controllers.ValidateOptionValue(c.AddonNamespace); err != nil {
+		multierr.AppendInto(&finalErr, fmt.Errorf("validating AddonNamespace: %w", err))
+	}
+
+	if err := controllers.ValidateOptionValue(c.OperatorName); err != nil {
+		multierr.AppendInto(&finalErr, fmt.Errorf("validating OperatorName: %w", err))
+	}
+
+	return finalErr
+}
+
+type AnnotationUninstallSignalerOption interface {
+	ConfigureAnnotationUninstallSignaler(*AnnotationUninstallSignalerConfig)
+}
+
+func NewAddonInstanceUninstallSignaler(client client.Client, opts ...AddonInstanceUninstallSignalerOption) (*AddonInstanceUninstallSignaler, error) {
+	var cfg AddonInstanceUninstallSignalerConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("validating config: %w", err)
+	}
+
+	return &AddonInstanceUninstallSignaler{
+		cfg: cfg,
+
+		client: client,
+	}, nil
+}
+
+// AddonInstanceUninstallSignaler signals an uninstall once the
+// addon-operator has marked the AddonInstance for deletion.
+type AddonInstanceUninstallSignaler struct {
+	cfg AddonInstanceUninstallSignalerConfig
+
+	client client.Client
+}
+
+func (s *AddonInstanceUninstallSignaler) SignalUninstall(ctx context.Context) bool {
+	tgt := types.NamespacedName{
+		Namespace: s.cfg.AddonInstanceNamespace,
+		Name:      s.cfg.AddonInstanceName,
+	}
+
+	var ai av1alpha1.AddonInstance
+
+	if err := s.client.Get(ctx, tgt, &ai); err != nil {
+		logSignalerError(s.cfg.Log, err, "getting AddonInstance", tgt)
+
+		return false
+	}
+
+	return ai.Spec.MarkedForDeletion
+}
+
+type AddonInstanceUninstallSignalerConfig struct {
+	Log                    logr.Logger
+	AddonInstanceNamespace string
+	AddonInstanceName      string
+}
+
+func (c *AddonInstanceUninstallSignalerConfig) Option(opts ...AddonInstanceUninstallSignalerOption) {
+	for _, opt := range opts {
+		opt.ConfigureAddonInstanceUninstallSignaler(c)
+	}
+}
+
+func (c *AddonInstanceUninstallSignalerConfig) Default() {
+	if c.Log.GetSink() == nil {
+		c.Log = logr.Discard()
+	}
+}
+
+func (c *AddonInstanceUninstallSignalerConfig) Validate() error {
+	var finalErr error
+
+	if err := controllers.ValidateOptionValue(c.AddonInstanceNamespace); err != nil {
+		multierr.AppendInto(&finalErr, fmt.Errorf("validating AddonInstanceNamespace: %w", err))
+	}
+
+	if err := controllers.ValidateOptionValue(c.AddonInstanceName); err != nil {
+		multierr.AppendInto(&finalErr, fmt.Errorf("validating AddonInstanceName: %w", err))
+	}
+
+	return finalErr
+}
+
+type AddonInstanceUninstallSignalerOption interface {
+	ConfigureAddonInstanceUninstallSignaler(*AddonInstanceUninstallSignalerConfig)
+}
+
+func NewUninstallRequestUninstallSignaler(client client.Client, opts ...UninstallRequestUninstallSignalerOption) (*UninstallRequestUninstallSignaler, error) {
+	var cfg UninstallRequestUninstallSignalerConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("validating config: %w", err)
+	}
+
+	return &UninstallRequestUninstallSignaler{
+		cfg: cfg,
+
+		client: client,
+	}, nil
+}
+
+// UninstallRequestUninstallSignaler signals an uninstall when an
+// UninstallRequest named after the operator exists in the addon namespace.
+type UninstallRequestUninstallSignaler struct {
+	cfg UninstallRequestUninstallSignalerConfig
+
+	client client.Client
+}
+
+func (s *UninstallRequestUninstallSignaler) SignalUninstall(ctx context.Context) bool {
+	tgt := types.NamespacedName{
+		Namespace: s.cfg.AddonNamespace,
+		Name:      s.cfg.OperatorName,
+	}
+
+	var req refv1alpha1.UninstallRequest
+
+	if err := s.client.Get(ctx, tgt, &req); err != nil {
+		logSignalerError(s.cfg.Log, err, "getting UninstallRequest", tgt)
+
+		return false
+	}
+
+	return req.DeletionTimestamp.IsZero()
+}
+
+type UninstallRequestUninstallSignalerConfig struct {
+	Log            logr.Logger
+	AddonNamespace string
+	OperatorName   string
+}
+
+func (c *UninstallRequestUninstallSignalerConfig) Option(opts ...UninstallRequestUninstallSignalerOption) {
+	for _, opt := range opts {
+		opt.ConfigureUninstallRequestUninstallSignaler(c)
+	}
+}
+
+func (c *UninstallRequestUninstallSignalerConfig) Default() {
+	if c.Log.GetSink() == nil {
+		c.Log = logr.Discard()
+	}
+}
+
+func (c *UninstallRequestUninstallSignalerConfig) Validate() error {
+	var finalErr error
+
+	if err := controllers.ValidateOptionValue(c.AddonNamespace); err != nil {
+		multierr.AppendInto(&finalErr, fmt.Errorf("validating AddonNamespace: %w", err))
+	}
+
+	if err := controllers.ValidateOptionValue(c.OperatorName); err != nil {
+		multierr.AppendInto(&finalErr, fmt.Errorf("validating OperatorName: %w", err))
+	}
+
+	return finalErr
+}
+
+type UninstallRequestUninstallSignalerOption interface {
+	ConfigureUninstallRequestUninstallSignaler(*UninstallRequestUninstallSignalerConfig)
+}
+
+func NewParameterUninstallSignaler(opts ...ParameterUninstallSignalerOption) (*ParameterUninstallSignaler, error) {
+	var cfg ParameterUninstallSignalerConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("validating config: %w", err)
+	}
+
+	return &ParameterUninstallSignaler{
+		cfg: cfg,
+	}, nil
+}
+
+// ParameterUninstallSignaler signals an uninstall when the addon
+// parameters set the uninstall parameter to "true". Parameters are
+// read through the ParameterGetter so that the value is parsed and
+// validated by the parameter registry.
+type ParameterUninstallSignaler struct {
+	cfg ParameterUninstallSignalerConfig
+}
+
+func (s *ParameterUninstallSignaler) SignalUninstall(ctx context.Context) bool {
+	params, err := s.cfg.ParameterGetter.GetParameters(ctx)
+	if isParameterSourceNotFound(err) {
+		return false
+	} else if err != nil {
+		s.cfg.Log.Error(err, "getting addon parameters")
+
+		return false
+	}
+
+	val, ok := params.Get(s.cfg.ParameterID)
+	if !ok {
+		return false
+	}
+
+	signaled, ok := val.(bool)
+
+	return ok && signaled
+}
+
+type ParameterUninstallSignalerConfig struct {
+	Log             logr.Logger
+	ParameterGetter ParameterGetter
+	ParameterID     string
+}
+
+func (c *ParameterUninstallSignalerConfig) Option(opts ...ParameterUninstallSignalerOption) {
+	for _, opt := range opts {
+		opt.ConfigureParameterUninstallSignaler(c)
+	}
+}
+
+func (c *ParameterUninstallSignalerConfig) Default() {
+	if c.Log.GetSink() == nil {
+		c.Log = logr.Discard()
+	}
+
+	if c.ParameterID == "" {
+		c.ParameterID = DefaultUninstallParameterID
+	}
+}
+
+func (c *ParameterUninstallSignalerConfig) Validate() error {
+	if c.ParameterGetter == nil {
+		return fmt.Errorf("validating ParameterGetter: %w", controllers.ErrEmptyOptionValue)
+	}
+
+	return nil
+}
+
+type ParameterUninstallSignalerOption interface {
+	ConfigureParameterUninstallSignaler(*ParameterUninstallSignalerConfig)
+}
+
+// logSignalerError logs errors encountered while retrieving the object
+// an uninstall is signaled by. An absent object is expected whenever
+// no uninstall has been requested and is not logged.
+func logSignalerError(log logr.Logger, err error, msg string, key types.NamespacedName) {
+	if apierrors.IsNotFound(err) {
+		return
+	}
+
+	log.Error(err, msg, "namespace", key.Namespace, "name", key.Name)
+}
@@ -0,0 +1,411 @@
+package referenceaddon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	av1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	refv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestUninstallSignalerInterfaces(t *testing.T) {
+	t.Parallel()
+
+	require.Implements(t, new(UninstallSignaler), new(CompositeUninstallSignaler))
+	require.Implements(t, new(UninstallSignaler), new(AnnotationUninstallSignaler))
+	require.Implements(t, new(UninstallSignaler), new(AddonInstanceUninstallSignaler))
+	require.Implements(t, new(UninstallSignaler), new(UninstallRequestUninstallSignaler))
+	require.Implements(t, new(UninstallSignaler), new(ParameterUninstallSignaler))
+}
+
+func TestCompositeUninstallSignaler(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Mode         UninstallSignalMode
+		Signals      []bool
+		AssertResult assert.BoolAssertionFunc
+	}{
+		"any/none signaled": {
+			Mode:         UninstallSignalModeAny,
+			Signals:      []bool{false, false},
+			AssertResult: assert.False,
+		},
+		"any/one signaled": {
+			Mode:         UninstallSignalModeAny,
+			Signals:      []bool{false, true},
+			AssertResult: assert.True,
+		},
+		"all/one signaled": {
+			Mode:         UninstallSignalModeAll,
+			Signals:      []bool{true, false},
+			AssertResult: assert.False,
+		},
+		"all/all signaled": {
+			Mode:         UninstallSignalModeAll,
+			Signals:      []bool{true, true},
+			AssertResult: assert.True,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			signalers := make([]UninstallSignaler, 0, len(tc.Signals))
+
+			for _, signal := range tc.Signals {
+				var signaler uninstallSignalerMock
+
+				signaler.
+					On("SignalUninstall", mock.Anything).
+					Return(signal).
+					Maybe()
+
+				signalers = append(signalers, &signaler)
+			}
+
+			composite, err := NewCompositeUninstallSignaler(tc.Mode, signalers...)
+			require.NoError(t, err)
+
+			tc.AssertResult(t, composite.SignalUninstall(context.Background()))
+		})
+	}
+}
+
+func TestNewUninstallSignaler(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Kinds             []UninstallSignalerKind
+		Mode              UninstallSignalMode
+		NoParameterGetter bool
+		AssertError       require.ErrorAssertionFunc
+	}{
+		"all kinds": {
+			Kinds: []UninstallSignalerKind{
+				UninstallSignalerKindConfigMap,
+				UninstallSignalerKindAnnotation,
+				UninstallSignalerKindAddonInstance,
+				UninstallSignalerKindUninstallRequest,
+				UninstallSignalerKindParameter,
+			},
+			Mode:        UninstallSignalModeAll,
+			AssertError: require.NoError,
+		},
+		"parameter kind without parameter getter": {
+			Kinds:             []UninstallSignalerKind{UninstallSignalerKindParameter},
+			Mode:              UninstallSignalModeAny,
+			NoParameterGetter: true,
+			AssertError:       require.Error,
+		},
+		"unknown kind": {
+			Kinds:       []UninstallSignalerKind{"unknown"},
+			Mode:        UninstallSignalModeAny,
+			AssertError: require.Error,
+		},
+		"unknown mode": {
+			Kinds:       []UninstallSignalerKind{UninstallSignalerKindConfigMap},
+			Mode:        "unknown",
+			AssertError: require.Error,
+		},
+		"no kinds": {
+			Mode:        UninstallSignalModeAny,
+			AssertError: require.Error,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := []UninstallSignalerOption{
+				WithAddonNamespace("test-namespace"),
+				WithAddonInstanceNamespace("test-namespace"),
+				WithAddonInstanceName("addon-instance"),
+				WithOperatorName("test-operator"),
+				WithDeleteLabel("test-delete-label"),
+			}
+			if !tc.NoParameterGetter {
+				opts = append(opts, WithParameterGetter{Getter: &parameterGetterMock{}})
+			}
+
+			_, err := NewUninstallSignaler(
+				fake.NewClientBuilder().Build(),
+				tc.Kinds,
+				tc.Mode,
+				opts...,
+			)
+			tc.AssertError(t, err)
+		})
+	}
+}
+
+func TestUninstallSignalers(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, refv1alpha1.AddToScheme(scheme))
+	require.NoError(t, av1alpha1.AddToScheme(scheme))
+
+	meta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test-namespace",
+		}
+	}
+
+	newSignaler := func(t *testing.T, c client.Client, kind UninstallSignalerKind) UninstallSignaler {
+		t.Helper()
+
+		signaler, err := NewUninstallSignaler(
+			c,
+			[]UninstallSignalerKind{kind},
+			UninstallSignalModeAny,
+			WithAddonNamespace("test-namespace"),
+			WithParameterGetter{
+				Getter: NewSecretParameterGetter(
+					c,
+					WithNamespace("test-namespace"),
+					WithName("test-secret"),
+				),
+			},
+			WithAddonInstanceNamespace("test-namespace"),
+			WithAddonInstanceName("addon-instance"),
+			WithOperatorName("test-operator"),
+		)
+		require.NoError(t, err)
+
+		return signaler
+	}
+
+	for name, tc := range map[string]struct {
+		Kind         UninstallSignalerKind
+		Objects      []client.Object
+		AssertResult assert.BoolAssertionFunc
+	}{
+		"annotation/not present": {
+			Kind: UninstallSignalerKindAnnotation,
+			Objects: []client.Object{
+				&refv1alpha1.ReferenceAddon{ObjectMeta: meta("test-operator")},
+			},
+			AssertResult: assert.False,
+		},
+		"annotation/present": {
+			Kind: UninstallSignalerKindAnnotation,
+			Objects: []client.Object{
+				&refv1alpha1.ReferenceAddon{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-operator",
+						Namespace: "test-namespace",
+						Annotations: map[string]string{
+							DefaultUninstallAnnotation: "true",
+						},
+					},
+				},
+			},
+			AssertResult: assert.True,
+		},
+		"annotation/invalid value": {
+			Kind: UninstallSignalerKindAnnotation,
+			Objects: []client.Object{
+				&refv1alpha1.ReferenceAddon{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-operator",
+						Namespace: "test-namespace",
+						Annotations: map[string]string{
+							DefaultUninstallAnnotation: "yes",
+						},
+					},
+				},
+			},
+			AssertResult: assert.False,
+		},
+		"addoninstance/not marked for deletion": {
+			Kind: UninstallSignalerKindAddonInstance,
+			Objects: []client.Object{
+				&av1alpha1.AddonInstance{ObjectMeta: meta("addon-instance")},
+			},
+			AssertResult: assert.False,
+		},
+		"addoninstance/marked for deletion": {
+			Kind: UninstallSignalerKindAddonInstance,
+			Objects: []client.Object{
+				&av1alpha1.AddonInstance{
+					ObjectMeta: meta("addon-instance"),
+					Spec: av1alpha1.AddonInstanceSpec{
+						MarkedForDeletion: true,
+					},
+				},
+			},
+			AssertResult: assert.True,
+		},
+		"uninstallrequest/not present": {
+			Kind:         UninstallSignalerKindUninstallRequest,
+			AssertResult: assert.False,
+		},
+		"uninstallrequest/present": {
+			Kind: UninstallSignalerKindUninstallRequest,
+			Objects: []client.Object{
+				&refv1alpha1.UninstallRequest{ObjectMeta: meta("test-operator")},
+			},
+			AssertResult: assert.True,
+		},
+		"uninstallrequest/misnamed": {
+			Kind: UninstallSignalerKindUninstallRequest,
+			Objects: []client.Object{
+				&refv1alpha1.UninstallRequest{ObjectMeta: meta("misnamed")},
+			},
+			AssertResult: assert.False,
+		},
+		"parameter/not present": {
+			Kind: UninstallSignalerKindParameter,
+			Objects: []client.Object{
+				&corev1.Secret{ObjectMeta: meta("test-secret")},
+			},
+			AssertResult: assert.False,
+		},
+		"parameter/present": {
+			Kind: UninstallSignalerKindParameter,
+			Objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: meta("test-secret"),
+					Data: map[string][]byte{
+						DefaultUninstallParameterID: []byte("true"),
+					},
+				},
+			},
+			AssertResult: assert.True,
+		},
+		"parameter/secret not present": {
+			Kind:         UninstallSignalerKindParameter,
+			AssertResult: assert.False,
+		},
+		"parameter/invalid value": {
+			Kind: UninstallSignalerKindParameter,
+			Objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: meta("test-secret"),
+					Data: map[string][]byte{
+						DefaultUninstallParameterID: []byte("yes"),
+					},
+				},
+			},
+			AssertResult: assert.False,
+		},
+		"parameter/false": {
+			Kind: UninstallSignalerKindParameter,
+			Objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: meta("test-secret"),
+					Data: map[string][]byte{
+						DefaultUninstallParameterID: []byte("false"),
+					},
+				},
+			},
+			AssertResult: assert.False,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tc.Objects...).
+				Build()
+
+			tc.AssertResult(t, newSignaler(t, c, tc.Kind).SignalUninstall(context.Background()))
+		})
+	}
+}
+
+func TestUninstallSignalers_LogErrors(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Err               error
+		AssertErrorLogged assert.BoolAssertionFunc
+	}{
+		"not found": {
+			Err:               apierrors.NewNotFound(schema.GroupResource{}, "test"),
+			AssertErrorLogged: assert.False,
+		},
+		"lookup failure": {
+			Err:               errors.New("test error"),
+			AssertErrorLogged: assert.True,
+		},
+	} {
+		tc := tc
+
+		for _, kind := range []UninstallSignalerKind{
+			UninstallSignalerKindConfigMap,
+			UninstallSignalerKindAnnotation,
+			UninstallSignalerKindAddonInstance,
+			UninstallSignalerKindUninstallRequest,
+			UninstallSignalerKindParameter,
+		} {
+			kind := kind
+
+			t.Run(fmt.Sprintf("%s/%s", name, kind), func(t *testing.T) {
+				t.Parallel()
+
+				c := fake.NewClientBuilder().
+					WithInterceptorFuncs(interceptor.Funcs{
+						Get: func(context.Context, client.WithWatch, client.ObjectKey, client.Object, ...client.GetOption) error {
+							return tc.Err
+						},
+					}).
+					Build()
+
+				var getter parameterGetterMock
+				getter.
+					On("GetParameters", mock.Anything).
+					Return(NewPhaseRequestParameters(), tc.Err)
+
+				var errorLogged bool
+
+				log := funcr.New(func(_, args string) {
+					if strings.Contains(args, `"error"=`) {
+						errorLogged = true
+					}
+				}, funcr.Options{})
+
+				signaler, err := NewUninstallSignaler(
+					c,
+					[]UninstallSignalerKind{kind},
+					UninstallSignalModeAny,
+					WithLog{Log: log},
+					WithAddonNamespace("test-namespace"),
+					WithAddonInstanceNamespace("test-namespace"),
+					WithAddonInstanceName("addon-instance"),
+					WithOperatorName("test-operator"),
+					WithDeleteLabel("test-delete-label"),
+					WithParameterGetter{Getter: &getter},
+				)
+				require.NoError(t, err)
+
+				assert.False(t, signaler.SignalUninstall(context.Background()))
+				tc.AssertErrorLogged(t, errorLogged)
+			})
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package status
+
+import (
+	av1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	addoninstance "github.com/openshift/addon-operator/pkg/client"
+	rv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AddonInstanceConditionAvailable mirrors the 'Available' condition of
+// the ReferenceAddon. The AddonInstance API does not define the type
+// so it is reported alongside the conditions it does define.
+const AddonInstanceConditionAvailable av1alpha1.AddonInstanceCondition = "Available"
+
+const (
+	// installedReasonSetupInProgress is reported while the
+	// ReferenceAddon has not become available for the first time.
+	installedReasonSetupInProgress av1alpha1.AddonInstanceInstalledReason = "SetupInProgress"
+	// availableReasonNotReported is reported while the ReferenceAddon
+	// does not report an 'Available' condition.
+	availableReasonNotReported = "NotReported"
+	// availableReasonNotFound is reported while the ReferenceAddon
+	// does not exist since it has not been created by a user yet.
+	availableReasonNotFound = "NotFound"
+)
+
+// availabilityMapping describes the AddonInstance conditions reported
+// for one reason of the ReferenceAddon 'Available' condition.
+type availabilityMapping struct {
+	// Installed is the status of the 'Installed' condition. An empty
+	// status leaves the previously reported condition unchanged so
+	// that an installed addon which degrades is still installed.
+	Installed        metav1.ConditionStatus
+	InstalledReason  av1alpha1.AddonInstanceInstalledReason
+	InstalledMessage string
+	// Available is the status of the 'Available' condition which
+	// is reported using the reason and message of the ReferenceAddon.
+	Available metav1.ConditionStatus
+}
+
+// availabilityMappings maps the reasons of the ReferenceAddon 'Available'
+// condition to the AddonInstance 'Installed' and 'Available' conditions.
+// Reasons missing from the table are reported as 'Available=Unknown'.
+var availabilityMappings = map[rv1alpha1.ReferenceAddonAvailableReason]availabilityMapping{
+	rv1alpha1.ReferenceAddonAvailableReasonReady: {
+		Installed:        metav1.ConditionTrue,
+		InstalledReason:  av1alpha1.AddonInstanceInstalledReasonSetupComplete,
+		InstalledMessage: "All Components Available",
+		Available:        metav1.ConditionTrue,
+	},
+	rv1alpha1.ReferenceAddonAvailableReasonPending: {
+		Installed:        metav1.ConditionFalse,
+		InstalledReason:  installedReasonSetupInProgress,
+		InstalledMessage: "Waiting for all components to become available",
+		Available:        metav1.ConditionFalse,
+	},
+	rv1alpha1.ReferenceAddonAvailableReasonDegraded: {
+		Available: metav1.ConditionFalse,
+	},
+	rv1alpha1.ReferenceAddonAvailableReasonUninstalling: {
+		Available: metav1.ConditionFalse,
+	},
+}
+
+// addonInstanceConditions maps the conditions of the ReferenceAddon
+// to the conditions reported on the AddonInstance.
+func addonInstanceConditions(ra rv1alpha1.ReferenceAddon) []metav1.Condition {
+	conditions := availabilityConditions(ra)
+	conditions = append(conditions, degradedCondition(ra))
+
+	// Uninstall progress takes precedence over the Degraded
+	// condition derived from the reconcile phases.
+	for _, cond := range uninstallConditions(ra) {
+		setCondition(&conditions, cond)
+	}
+
+	return conditions
+}
+
+func availabilityConditions(ra rv1alpha1.ReferenceAddon) []metav1.Condition {
+	available := meta.FindStatusCondition(
+		ra.Status.Conditions,
+		rv1alpha1.ReferenceAddonConditionAvailable.String(),
+	)
+	if available == nil {
+		return []metav1.Condition{
+			newAvailableCondition(
+				metav1.ConditionUnknown,
+				availableReasonNotReported,
+				"ReferenceAddon has not reported availability",
+			),
+		}
+	}
+
+	mapping, ok := availabilityMappings[rv1alpha1.ReferenceAddonAvailableReason(available.Reason)]
+	if !ok {
+		mapping.Available = metav1.ConditionUnknown
+	}
+
+	var conditions []metav1.Condition
+
+	if mapping.Installed != "" {
+		conditions = append(conditions, addoninstance.NewAddonInstanceConditionInstalled(
+			mapping.Installed,
+			mapping.InstalledReason,
+			mapping.InstalledMessage,
+		))
+	}
+
+	return append(conditions, newAvailableCondition(
+		mapping.Available,
+		available.Reason,
+		available.Message,
+	))
+}
+
+// degradedCondition reports a failing reconcile phase of the
+// ReferenceAddon as 'Degraded' using the phase's reason and message.
+func degradedCondition(ra rv1alpha1.ReferenceAddon) metav1.Condition {
+	degraded := meta.FindStatusCondition(
+		ra.Status.Conditions,
+		rv1alpha1.ReferenceAddonConditionDegraded.String(),
+	)
+	if degraded == nil {
+		return addoninstance.NewAddonInstanceConditionDegraded(
+			metav1.ConditionFalse,
+			degradedReasonAsExpected,
+			"No failures reported",
+		)
+	}
+
+	if degraded.Status != metav1.ConditionTrue {
+		return addoninstance.NewAddonInstanceConditionDegraded(
+			metav1.ConditionFalse,
+			degradedReasonAsExpected,
+			degraded.Message,
+		)
+	}
+
+	return addoninstance.NewAddonInstanceConditionDegraded(
+		metav1.ConditionTrue,
+		degraded.Reason,
+		degraded.Message,
+	)
+}
+
+// absentConditions are reported while the ReferenceAddon does not
+// exist. The 'Installed' condition is left unchanged since the
+// ReferenceAddon may be deleted and recreated by users.
+func absentConditions() []metav1.Condition {
+	return []metav1.Condition{
+		newAvailableCondition(
+			metav1.ConditionUnknown,
+			availableReasonNotFound,
+			"ReferenceAddon does not exist",
+		),
+		addoninstance.NewAddonInstanceConditionDegraded(
+			metav1.ConditionFalse,
+			degradedReasonAsExpected,
+			"No failures reported",
+		),
+	}
+}
+
+func newAvailableCondition(status metav1.ConditionStatus, reason, msg string) metav1.Condition {
+	return metav1.Condition{
+		Type:    AddonInstanceConditionAvailable.String(),
+		Status:  status,
+		Reason:  reason,
+		Message: msg,
+	}
+}
+
+// setCondition replaces the condition of the same type in
+// conds or appends cond if no such condition exists.
+func setCondition(conds *[]metav1.Condition, cond metav1.Condition) {
+	for i := range *conds {
+		if (*conds)[i].Type == cond.Type {
+			(*conds)[i] = cond
+
+			return
+		}
+	}
+
+	*conds = append(*conds, cond)
+}
@@ -0,0 +1,145 @@
+package status
+
+import (
+	"testing"
+
+	av1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	rv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAddonInstanceConditions(t *testing.T) {
+	t.Parallel()
+
+	available := func(reason rv1alpha1.ReferenceAddonAvailableReason, msg string) metav1.Condition {
+		return metav1.Condition{
+			Type:    rv1alpha1.ReferenceAddonConditionAvailable.String(),
+			Status:  reason.Status(),
+			Reason:  reason.String(),
+			Message: msg,
+		}
+	}
+	degraded := func(reason rv1alpha1.ReferenceAddonDegradedReason, msg string) metav1.Condition {
+		return metav1.Condition{
+			Type:    rv1alpha1.ReferenceAddonConditionDegraded.String(),
+			Status:  reason.Status(),
+			Reason:  reason.String(),
+			Message: msg,
+		}
+	}
+	expected := func(condT av1alpha1.AddonInstanceCondition, status metav1.ConditionStatus, reason, msg string) metav1.Condition {
+		return metav1.Condition{
+			Type:    condT.String(),
+			Status:  status,
+			Reason:  reason,
+			Message: msg,
+		}
+	}
+
+	const (
+		installed        = av1alpha1.AddonInstanceConditionInstalled
+		addonDegraded    = av1alpha1.AddonInstanceConditionDegraded
+		readyToBeDeleted = av1alpha1.AddonInstanceConditionReadyToBeDeleted
+	)
+
+	for name, tc := range map[string]struct {
+		Conditions         []metav1.Condition
+		Report             *rv1alpha1.UninstallReport
+		ExpectedConditions []metav1.Condition
+	}{
+		"no conditions reported": {
+			ExpectedConditions: []metav1.Condition{
+				expected(AddonInstanceConditionAvailable, metav1.ConditionUnknown, "NotReported", "ReferenceAddon has not reported availability"),
+				expected(addonDegraded, metav1.ConditionFalse, "AsExpected", "No failures reported"),
+			},
+		},
+		"pending": {
+			Conditions: []metav1.Condition{
+				available(rv1alpha1.ReferenceAddonAvailableReasonPending, "starting reconciliation"),
+			},
+			ExpectedConditions: []metav1.Condition{
+				expected(installed, metav1.ConditionFalse, "SetupInProgress", "Waiting for all components to become available"),
+				expected(AddonInstanceConditionAvailable, metav1.ConditionFalse, "Pending", "starting reconciliation"),
+				expected(addonDegraded, metav1.ConditionFalse, "AsExpected", "No failures reported"),
+			},
+		},
+		"ready": {
+			Conditions: []metav1.Condition{
+				available(rv1alpha1.ReferenceAddonAvailableReasonReady, "all reconcile phases completed successfully"),
+				degraded(rv1alpha1.ReferenceAddonDegradedReasonAsExpected, "all reconcile phases completed successfully"),
+			},
+			ExpectedConditions: []metav1.Condition{
+				expected(installed, metav1.ConditionTrue, "SetupComplete", "All Components Available"),
+				expected(AddonInstanceConditionAvailable, metav1.ConditionTrue, "Ready", "all reconcile phases completed successfully"),
+				expected(addonDegraded, metav1.ConditionFalse, "AsExpected", "all reconcile phases completed successfully"),
+			},
+		},
+		"phase failing": {
+			Conditions: []metav1.Condition{
+				available(rv1alpha1.ReferenceAddonAvailableReasonReady, "all reconcile phases completed successfully"),
+				degraded(rv1alpha1.ReferenceAddonDegradedReasonPhaseFailed, `phase "test": test failure`),
+			},
+			ExpectedConditions: []metav1.Condition{
+				expected(installed, metav1.ConditionTrue, "SetupComplete", "All Components Available"),
+				expected(AddonInstanceConditionAvailable, metav1.ConditionTrue, "Ready", "all reconcile phases completed successfully"),
+				expected(addonDegraded, metav1.ConditionTrue, "PhaseFailed", `phase "test": test failure`),
+			},
+		},
+		"degraded": {
+			Conditions: []metav1.Condition{
+				available(rv1alpha1.ReferenceAddonAvailableReasonDegraded, `phase "test" failed 3 consecutive times`),
+				degraded(rv1alpha1.ReferenceAddonDegradedReasonPhaseErrored, `phase "test": test error`),
+			},
+			ExpectedConditions: []metav1.Condition{
+				expected(AddonInstanceConditionAvailable, metav1.ConditionFalse, "Degraded", `phase "test" failed 3 consecutive times`),
+				expected(addonDegraded, metav1.ConditionTrue, "PhaseErrored", `phase "test": test error`),
+			},
+		},
+		"uninstalling": {
+			Conditions: []metav1.Condition{
+				available(rv1alpha1.ReferenceAddonAvailableReasonUninstalling, "uninstallation started"),
+				degraded(rv1alpha1.ReferenceAddonDegradedReasonPhaseFailed, `phase "test": test failure`),
+				{
+					Type:   rv1alpha1.ReferenceAddonConditionUninstallPending.String(),
+					Status: metav1.ConditionTrue,
+					Reason: rv1alpha1.UninstallPendingReasonInProgress.String(),
+				},
+			},
+			ExpectedConditions: []metav1.Condition{
+				expected(AddonInstanceConditionAvailable, metav1.ConditionFalse, "Uninstalling", "uninstallation started"),
+				expected(addonDegraded, metav1.ConditionFalse, "AsExpected", "Uninstall progressing"),
+				expected(readyToBeDeleted, metav1.ConditionFalse, "AddonNotReadyToBeDeleted", "Uninstall in progress"),
+			},
+		},
+		"unknown reason": {
+			Conditions: []metav1.Condition{
+				{
+					Type:    rv1alpha1.ReferenceAddonConditionAvailable.String(),
+					Status:  metav1.ConditionFalse,
+					Reason:  "Unknown",
+					Message: "test",
+				},
+			},
+			ExpectedConditions: []metav1.Condition{
+				expected(AddonInstanceConditionAvailable, metav1.ConditionUnknown, "Unknown", "test"),
+				expected(addonDegraded, metav1.ConditionFalse, "AsExpected", "No failures reported"),
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ra := rv1alpha1.ReferenceAddon{
+				Status: rv1alpha1.ReferenceAddonStatus{
+					Conditions: tc.Conditions,
+					Uninstall:  tc.Report,
+				},
+			}
+
+			assert.Equal(t, tc.ExpectedConditions, addonInstanceConditions(ra))
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/openshift/reference-addon/internal/controllers"
+	"k8s.io/utils/clock"
 )
 
 type WithLog struct{ Log logr.Logger }
@@ -12,6 +14,12 @@ func (w WithLog) ConfigureStatusControllerReconciler(c *StatusControllerReconcil
 	c.Log = w.Log
 }
 
+type WithInstallMode controllers.InstallMode
+
+func (w WithInstallMode) ConfigureStatusControllerReconciler(c *StatusControllerReconcilerConfig) {
+	c.InstallMode = controllers.InstallMode(w)
+}
+
 type WithAddonInstanceNamespace string
 
 func (w WithAddonInstanceNamespace) ConfigureStatusControllerReconciler(c *StatusControllerReconcilerConfig) {
@@ -41,3 +49,63 @@ type WithHeartbeatInterval time.Duration
 func (w WithHeartbeatInterval) ConfigureStatusControllerReconciler(c *StatusControllerReconcilerConfig) {
 	c.HeartBeatInterval = time.Duration(w)
 }
+
+type WithHeartbeatMode HeartbeatMode
+
+func (w WithHeartbeatMode) ConfigureStatusControllerReconciler(c *StatusControllerReconcilerConfig) {
+	c.HeartbeatMode = HeartbeatMode(w)
+}
+
+type WithHeartbeatJitter float64
+
+func (w WithHeartbeatJitter) ConfigureStatusControllerReconciler(c *StatusControllerReconcilerConfig) {
+	c.HeartbeatJitter = float64(w)
+}
+
+type WithHeartbeatMinInterval time.Duration
+
+func (w WithHeartbeatMinInterval) ConfigureStatusControllerReconciler(c *StatusControllerReconcilerConfig) {
+	c.HeartbeatMinInterval = time.Duration(w)
+}
+
+type WithHeartbeatMaxInterval time.Duration
+
+func (w WithHeartbeatMaxInterval) ConfigureStatusControllerReconciler(c *StatusControllerReconcilerConfig) {
+	c.HeartbeatMaxInterval = time.Duration(w)
+}
+
+type WithHeartbeatBackoffBase time.Duration
+
+func (w WithHeartbeatBackoffBase) ConfigureStatusControllerReconciler(c *StatusControllerReconcilerConfig) {
+	c.HeartbeatBackoffBase = time.Duration(w)
+}
+
+type WithHeartbeatBackoffMax time.Duration
+
+func (w WithHeartbeatBackoffMax) ConfigureStatusControllerReconciler(c *StatusControllerReconcilerConfig) {
+	c.HeartbeatBackoffMax = time.Duration(w)
+}
+
+type WithHeartbeatStaleIntervals int
+
+func (w WithHeartbeatStaleIntervals) ConfigureStatusControllerReconciler(c *StatusControllerReconcilerConfig) {
+	c.HeartbeatStaleIntervals = int(w)
+}
+
+type WithHeartbeatRecorder struct{ Recorder HeartbeatRecorder }
+
+func (w WithHeartbeatRecorder) ConfigureStatusControllerReconciler(c *StatusControllerReconcilerConfig) {
+	c.HeartbeatRecorder = w.Recorder
+}
+
+type WithElected struct{ Elected <-chan struct{} }
+
+func (w WithElected) ConfigureStatusControllerReconciler(c *StatusControllerReconcilerConfig) {
+	c.Elected = w.Elected
+}
+
+type WithClock struct{ Clock clock.PassiveClock }
+
+func (w WithClock) ConfigureStatusControllerReconciler(c *StatusControllerReconcilerConfig) {
+	c.Clock = w.Clock
+}
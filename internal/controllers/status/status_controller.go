@@ -3,7 +3,12 @@ package status
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand"
+	"net/http"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -12,9 +17,11 @@ import (
 	addoninstance "github.com/openshift/addon-operator/pkg/client"
 	rv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
 	"github.com/openshift/reference-addon/internal/controllers"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -27,6 +34,22 @@ type StatusControllerReconciler struct {
 	cfg                 StatusControllerReconcilerConfig
 	client              client.Client
 	addonInstanceClient addoninstance.AddonInstanceClient
+
+	lock       sync.Mutex
+	heartbeats map[types.NamespacedName]*heartbeat
+	// elected is set once the election of this replica
+	// as leader has been observed.
+	elected bool
+}
+
+// heartbeat tracks the pulses sent to a single AddonInstance.
+type heartbeat struct {
+	// lastPulse is the time of the last successful pulse or the time
+	// the AddonInstance was first tracked if no pulse succeeded yet.
+	lastPulse time.Time
+	// period is the most recently determined heartbeat period.
+	period   time.Duration
+	failures int
 }
 
 // Grabbing namespace/name needs to be an option
@@ -36,21 +59,93 @@ func NewStatusControllerReconciler(client client.Client, opts ...StatusControlle
 	cfg.Option(opts...)
 	cfg.Default()
 
-	return &StatusControllerReconciler{
+	if err := cfg.InstallMode.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch cfg.HeartbeatMode {
+	case HeartbeatModeEnforce, HeartbeatModeAdopt:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownHeartbeatMode, cfg.HeartbeatMode)
+	}
+
+	if cfg.HeartbeatMinInterval > cfg.HeartbeatMaxInterval {
+		return nil, fmt.Errorf(
+			"minimum heartbeat interval %s exceeds maximum heartbeat interval %s",
+			cfg.HeartbeatMinInterval, cfg.HeartbeatMaxInterval,
+		)
+	}
+
+	if cfg.HeartbeatJitter < 0 || cfg.HeartbeatJitter >= 1 {
+		return nil, fmt.Errorf("heartbeat jitter %v must be within [0, 1)", cfg.HeartbeatJitter)
+	}
+
+	r := &StatusControllerReconciler{
 		cfg:                 cfg,
 		client:              client,
 		addonInstanceClient: addoninstance.NewAddonInstanceClient(client),
-	}, nil
+		heartbeats:          make(map[types.NamespacedName]*heartbeat),
+	}
+
+	if !cfg.InstallMode.IsMultiNamespace() {
+		// The AddonInstance is tracked from the start so that
+		// HeartbeatCheck fails if it is never pulsed successfully.
+		r.heartbeat(r.addonInstanceKey(reconcile.Request{}))
+	}
+
+	r.observeElection()
+
+	return r, nil
 }
 
+// HeartbeatMode determines who owns the heartbeat
+// period configured on the AddonInstance.
+type HeartbeatMode string
+
+const (
+	// HeartbeatModeEnforce overwrites the heartbeat period of the
+	// AddonInstance with the configured heartbeat interval.
+	HeartbeatModeEnforce HeartbeatMode = "enforce"
+	// HeartbeatModeAdopt pulses at the heartbeat period configured on
+	// the AddonInstance and only falls back to the configured heartbeat
+	// interval while the AddonInstance does not configure a period.
+	HeartbeatModeAdopt HeartbeatMode = "adopt"
+)
+
+var ErrUnknownHeartbeatMode = errors.New("unknown heartbeat mode")
+
 type StatusControllerReconcilerConfig struct {
 	Log logr.Logger
 
+	// InstallMode determines whether the AddonInstance and ReferenceAddon
+	// of the configured namespaces or of every namespace are reconciled.
+	InstallMode             controllers.InstallMode
 	AddonInstanceNamespace  string
 	AddonInstanceName       string
 	ReferenceAddonNamespace string
 	ReferenceAddonName      string
 	HeartBeatInterval       time.Duration
+	HeartbeatMode           HeartbeatMode
+	// HeartbeatJitter is the maximum fraction of the heartbeat period by
+	// which pulses are sent early so that addons do not pulse in lockstep.
+	HeartbeatJitter float64
+	// HeartbeatMinInterval and HeartbeatMaxInterval bound the
+	// heartbeat periods adopted from AddonInstances.
+	HeartbeatMinInterval time.Duration
+	HeartbeatMaxInterval time.Duration
+	// HeartbeatBackoffBase is the delay before retrying a failed pulse
+	// which doubles with every consecutive failure up to HeartbeatBackoffMax.
+	HeartbeatBackoffBase time.Duration
+	HeartbeatBackoffMax  time.Duration
+	// HeartbeatStaleIntervals is the number of heartbeat periods without
+	// a successful pulse after which HeartbeatCheck fails.
+	HeartbeatStaleIntervals int
+	HeartbeatRecorder       HeartbeatRecorder
+	// Elected is closed once this replica has been elected leader.
+	// Pulses are only sent by the leader so HeartbeatCheck passes
+	// until then. Defaults to a closed channel.
+	Elected <-chan struct{}
+	Clock   clock.PassiveClock
 }
 
 type StatusControllerReconcilerOption interface {
@@ -68,27 +163,85 @@ func (c *StatusControllerReconcilerConfig) Default() {
 	if c.Log.GetSink() == nil {
 		c.Log = logr.Discard()
 	}
+	if c.InstallMode == "" {
+		c.InstallMode = controllers.InstallModeOwnNamespace
+	}
+
 	if c.HeartBeatInterval == 0 {
 		c.HeartBeatInterval = 10 * time.Second
 	}
+
+	if c.HeartbeatMode == "" {
+		c.HeartbeatMode = HeartbeatModeEnforce
+	}
+
+	if c.HeartbeatMinInterval <= 0 {
+		c.HeartbeatMinInterval = time.Second
+	}
+
+	if c.HeartbeatMaxInterval <= 0 {
+		c.HeartbeatMaxInterval = 5 * time.Minute
+	}
+
+	if c.HeartbeatBackoffBase <= 0 {
+		c.HeartbeatBackoffBase = time.Second
+	}
+
+	if c.HeartbeatBackoffMax <= 0 {
+		c.HeartbeatBackoffMax = time.Minute
+	}
+
+	if c.HeartbeatStaleIntervals <= 0 {
+		c.HeartbeatStaleIntervals = 3
+	}
+
+	if c.HeartbeatRecorder == nil {
+		c.HeartbeatRecorder = nopHeartbeatRecorder{}
+	}
+
+	if c.Elected == nil {
+		elected := make(chan struct{})
+		close(elected)
+
+		c.Elected = elected
+	}
+
+	if c.Clock == nil {
+		c.Clock = clock.RealClock{}
+	}
 }
 
+type HeartbeatRecorder interface {
+	// RecordPulse records the latency and outcome of sending a pulse
+	// to the AddonInstance with the given namespace and name.
+	RecordPulse(namespace, name string, latency time.Duration, err error)
+	// ForgetAddonInstance removes the recorded pulses of a
+	// deleted AddonInstance.
+	ForgetAddonInstance(namespace, name string)
+}
+
+type nopHeartbeatRecorder struct{}
+
+func (nopHeartbeatRecorder) RecordPulse(string, string, time.Duration, error) {}
+
+func (nopHeartbeatRecorder) ForgetAddonInstance(string, string) {}
+
 // Watch reference addon actions to trigger addon instance
 func (r *StatusControllerReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	requestObject := types.NamespacedName{
-		Name:      r.cfg.AddonInstanceName,
-		Namespace: r.cfg.AddonInstanceNamespace,
-	}
-
-	referenceAddonHandler := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+	referenceAddonHandler := handler.EnqueueRequestsFromMapFunc(func(_ context.Context, obj client.Object) []reconcile.Request {
 		return []reconcile.Request{
 			{
-				NamespacedName: requestObject,
+				NamespacedName: r.addonInstanceKey(reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace()},
+				}),
 			},
 		}
 	})
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&av1alpha1.AddonInstance{}).
+		For(
+			&av1alpha1.AddonInstance{},
+			builder.WithPredicates(controllers.HasName(r.cfg.AddonInstanceName)),
+		).
 		Watches(
 			&rv1alpha1.ReferenceAddon{},
 			referenceAddonHandler,
@@ -99,56 +252,303 @@ func (r *StatusControllerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 // Utilize info gathered from SetupWithManager to perform logic against
 func (r *StatusControllerReconciler) Reconcile(ctx context.Context, req reconcile.Request) (ctrl.Result, error) {
-	ai, err := r.getAddonInstance(ctx)
-	if err != nil {
+	key := r.addonInstanceKey(req)
+
+	ai, err := r.getAddonInstance(ctx, key)
+	if apierrors.IsNotFound(err) && r.cfg.InstallMode.IsMultiNamespace() {
+		// The AddonInstance will be reconciled once it is created
+		// and must not be reported as stale in the meantime.
+		r.forget(key)
+
+		return ctrl.Result{}, nil
+	} else if err != nil {
 		r.cfg.Log.Error(err, "getting addon instance")
 
-		return ctrl.Result{RequeueAfter: r.cfg.HeartBeatInterval}, nil
+		return ctrl.Result{RequeueAfter: r.jitter(r.fallbackPeriod())}, nil
 	}
 
-	if ai.Spec.HeartbeatUpdatePeriod.Duration != r.cfg.HeartBeatInterval {
-		r.cfg.Log.Info("patching heartbeat interval")
+	period := r.heartbeatPeriod(ai)
+
+	r.setPeriod(key, period)
+
+	if r.cfg.HeartbeatMode == HeartbeatModeEnforce && ai.Spec.HeartbeatUpdatePeriod.Duration != period {
+		r.cfg.Log.Info("patching heartbeat interval", "period", period)
 
-		if err := r.patchHeartbeatInterval(ctx, ai); err != nil {
+		// The AddonInstance is updated by the patch so that the
+		// pulse is sent using the latest resource version.
+		if err := r.patchHeartbeatInterval(ctx, &ai, period); err != nil {
 			r.cfg.Log.Error(err, "patching heartbeat interval")
 
-			return ctrl.Result{RequeueAfter: r.cfg.HeartBeatInterval}, nil
+			return ctrl.Result{RequeueAfter: r.jitter(period)}, nil
 		}
 	}
 
-	refAddon, err := r.getReferenceAddon(ctx)
-	if err != nil {
+	var conditions []metav1.Condition
+
+	refAddon, err := r.getReferenceAddon(ctx, r.referenceAddonKey(req))
+
+	switch {
+	case apierrors.IsNotFound(err) && hasReadyToBeDeletedCondition(ai):
+		// The ReferenceAddon is only removed once teardown has completed.
+		r.cfg.Log.Info("ReferenceAddon removed after teardown")
+
+		conditions = teardownCompleteConditions()
+	case apierrors.IsNotFound(err):
+		r.cfg.Log.Info("ReferenceAddon not found")
+
+		conditions = absentConditions()
+	case err != nil:
 		r.cfg.Log.Error(err, "getting reference addon")
 
-		return ctrl.Result{RequeueAfter: r.cfg.HeartBeatInterval}, nil
+		return ctrl.Result{RequeueAfter: r.jitter(period)}, nil
+	default:
+		conditions = r.getConditions(refAddon)
 	}
 
-	conditions := r.getConditions(refAddon)
+	start := r.cfg.Clock.Now()
+
+	err = r.addonInstanceClient.SendPulse(ctx, ai, addoninstance.WithConditions(conditions))
 
-	if err := r.addonInstanceClient.SendPulse(ctx, ai, addoninstance.WithConditions(conditions)); err != nil {
-		r.cfg.Log.Error(err, "sending pulse to addon instance")
+	r.cfg.HeartbeatRecorder.RecordPulse(key.Namespace, key.Name, r.cfg.Clock.Since(start), err)
 
-		return ctrl.Result{}, err
+	if err != nil {
+		failures := r.recordPulseFailure(key)
+		backoff := r.pulseBackoff(failures)
+
+		r.cfg.Log.Error(err, "sending pulse to addon instance", "failures", failures, "retryAfter", backoff)
+
+		// The error is not returned so that the retry follows the
+		// pulse backoff rather than the controller's rate limiter.
+		return ctrl.Result{RequeueAfter: backoff}, nil
 	}
 
+	r.recordPulseSuccess(key, start)
+
 	r.cfg.Log.Info("successfully reconciled AddonInstance")
 
-	return ctrl.Result{RequeueAfter: r.cfg.HeartBeatInterval}, nil
+	return ctrl.Result{RequeueAfter: r.jitter(period)}, nil
 }
 
-func (r *StatusControllerReconciler) getAddonInstance(ctx context.Context) (av1alpha1.AddonInstance, error) {
-	log := r.cfg.Log.WithValues(
-		"namespace", r.cfg.AddonInstanceNamespace,
-		"name", r.cfg.AddonInstanceName,
-	)
+// addonInstanceKey returns the key of the AddonInstance pulsed for the
+// given request. In multi-namespace install modes every namespace holds
+// its own AddonInstance while in OwnNamespace mode the configured
+// AddonInstance is pulsed for every request.
+func (r *StatusControllerReconciler) addonInstanceKey(req reconcile.Request) types.NamespacedName {
+	namespace := r.cfg.AddonInstanceNamespace
+	if r.cfg.InstallMode.IsMultiNamespace() {
+		namespace = req.Namespace
+	}
 
-	addonInstanceKey := client.ObjectKey{
-		Namespace: r.cfg.AddonInstanceNamespace,
+	return types.NamespacedName{
 		Name:      r.cfg.AddonInstanceName,
+		Namespace: namespace,
+	}
+}
+
+// referenceAddonKey returns the key of the ReferenceAddon whose
+// conditions are reported to the AddonInstance of the given request.
+func (r *StatusControllerReconciler) referenceAddonKey(req reconcile.Request) types.NamespacedName {
+	namespace := r.cfg.ReferenceAddonNamespace
+	if r.cfg.InstallMode.IsMultiNamespace() {
+		namespace = req.Namespace
+	}
+
+	return types.NamespacedName{
+		Name:      r.cfg.ReferenceAddonName,
+		Namespace: namespace,
+	}
+}
+
+// heartbeat returns the heartbeat of the given AddonInstance and
+// starts tracking it if necessary. The caller must hold r.lock.
+func (r *StatusControllerReconciler) heartbeat(key types.NamespacedName) *heartbeat {
+	hb, ok := r.heartbeats[key]
+	if !ok {
+		hb = &heartbeat{
+			lastPulse: r.cfg.Clock.Now(),
+			period:    r.fallbackPeriod(),
+		}
+
+		r.heartbeats[key] = hb
+	}
+
+	return hb
+}
+
+func (r *StatusControllerReconciler) forget(key types.NamespacedName) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.heartbeats, key)
+
+	r.cfg.HeartbeatRecorder.ForgetAddonInstance(key.Namespace, key.Name)
+}
+
+func (r *StatusControllerReconciler) setPeriod(key types.NamespacedName, period time.Duration) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.heartbeat(key).period = period
+}
+
+func (r *StatusControllerReconciler) recordPulseFailure(key types.NamespacedName) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	hb := r.heartbeat(key)
+	hb.failures++
+
+	return hb.failures
+}
+
+func (r *StatusControllerReconciler) recordPulseSuccess(key types.NamespacedName, at time.Time) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	hb := r.heartbeat(key)
+	hb.failures = 0
+	hb.lastPulse = at
+}
+
+// pulseBackoff returns the delay before retrying a pulse after the given
+// number of consecutive failures. The delay starts at 'HeartbeatBackoffBase'
+// and doubles with each failure up to 'HeartbeatBackoffMax'.
+func (r *StatusControllerReconciler) pulseBackoff(failures int) time.Duration {
+	backoff := r.cfg.HeartbeatBackoffBase
+
+	for i := 1; i < failures && backoff < r.cfg.HeartbeatBackoffMax; i++ {
+		backoff *= 2
 	}
 
+	if backoff > r.cfg.HeartbeatBackoffMax {
+		return r.cfg.HeartbeatBackoffMax
+	}
+
+	return backoff
+}
+
+// HeartbeatCheck is a readyz check which fails once no pulse has been sent
+// successfully to any tracked AddonInstance for 'HeartbeatStaleIntervals'
+// heartbeat periods. The check passes while this replica is not the leader.
+func (r *StatusControllerReconciler) HeartbeatCheck(_ *http.Request) error {
+	if !r.observeElection() {
+		return nil
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	keys := make([]types.NamespacedName, 0, len(r.heartbeats))
+
+	for key := range r.heartbeats {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+	var errs []error
+
+	for _, key := range keys {
+		hb := r.heartbeats[key]
+
+		threshold := time.Duration(r.cfg.HeartbeatStaleIntervals) * hb.period
+
+		if since := r.cfg.Clock.Since(hb.lastPulse); since > threshold {
+			errs = append(errs, fmt.Errorf(
+				"AddonInstance %q: no successful heartbeat for %s which exceeds %d heartbeat periods of %s",
+				key, since.Round(time.Second), r.cfg.HeartbeatStaleIntervals, hb.period,
+			))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// observeElection reports whether this replica has been elected leader.
+// AddonInstances tracked while on standby have not been pulsed by this
+// replica so their staleness is measured from when the election is
+// first observed.
+func (r *StatusControllerReconciler) observeElection() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.elected {
+		return true
+	}
+
+	select {
+	case <-r.cfg.Elected:
+	default:
+		return false
+	}
+
+	r.elected = true
+
+	now := r.cfg.Clock.Now()
+
+	for _, hb := range r.heartbeats {
+		hb.lastPulse = now
+	}
+
+	return true
+}
+
+// heartbeatPeriod returns the period at which pulses are sent to the
+// given AddonInstance bounded by the configured minimum and maximum.
+func (r *StatusControllerReconciler) heartbeatPeriod(ai av1alpha1.AddonInstance) time.Duration {
+	configured := ai.Spec.HeartbeatUpdatePeriod.Duration
+
+	if r.cfg.HeartbeatMode != HeartbeatModeAdopt || configured <= 0 {
+		return r.fallbackPeriod()
+	}
+
+	period := r.bound(configured)
+	if period != configured {
+		r.cfg.Log.Info("bounding heartbeat period of AddonInstance",
+			"configured", configured,
+			"period", period,
+		)
+	}
+
+	return period
+}
+
+// fallbackPeriod returns the configured heartbeat interval which,
+// unlike periods adopted from an AddonInstance, is never bounded.
+func (r *StatusControllerReconciler) fallbackPeriod() time.Duration {
+	return r.cfg.HeartBeatInterval
+}
+
+func (r *StatusControllerReconciler) bound(period time.Duration) time.Duration {
+	switch {
+	case period < r.cfg.HeartbeatMinInterval:
+		return r.cfg.HeartbeatMinInterval
+	case period > r.cfg.HeartbeatMaxInterval:
+		return r.cfg.HeartbeatMaxInterval
+	default:
+		return period
+	}
+}
+
+// jitter shortens the given period by a random fraction of at most
+// 'HeartbeatJitter'. Pulses are only ever sent early so that jitter
+// never causes a heartbeat to be late.
+func (r *StatusControllerReconciler) jitter(period time.Duration) time.Duration {
+	if r.cfg.HeartbeatJitter <= 0 {
+		return period
+	}
+
+	return period - time.Duration(rand.Float64()*r.cfg.HeartbeatJitter*float64(period))
+}
+
+func (r *StatusControllerReconciler) getAddonInstance(ctx context.Context, key types.NamespacedName) (av1alpha1.AddonInstance, error) {
+	log := r.cfg.Log.WithValues(
+		"namespace", key.Namespace,
+		"name", key.Name,
+	)
+
 	var addonInstance av1alpha1.AddonInstance
-	if err := r.client.Get(ctx, addonInstanceKey, &addonInstance); err != nil {
+	if err := r.client.Get(ctx, key, &addonInstance); err != nil {
 		return addonInstance, fmt.Errorf("getting addon instance: %w", err)
 	}
 
@@ -157,14 +557,14 @@ func (r *StatusControllerReconciler) getAddonInstance(ctx context.Context) (av1a
 	return addonInstance, nil
 }
 
-func (r *StatusControllerReconciler) patchHeartbeatInterval(ctx context.Context, ai av1alpha1.AddonInstance) error {
+func (r *StatusControllerReconciler) patchHeartbeatInterval(ctx context.Context, ai *av1alpha1.AddonInstance, period time.Duration) error {
 	patch := map[string]interface{}{
 		"metadata": map[string]interface{}{
 			"resourceVersion": ai.GetResourceVersion(),
 		},
 		"spec": map[string]interface{}{
 			"heartbeatUpdatePeriod": metav1.Duration{
-				Duration: r.cfg.HeartBeatInterval,
+				Duration: period,
 			},
 		},
 	}
@@ -174,24 +574,19 @@ func (r *StatusControllerReconciler) patchHeartbeatInterval(ctx context.Context,
 		return fmt.Errorf("marshalling raw patch: %w", err)
 	}
 
-	return r.client.Patch(ctx, &ai, client.RawPatch(types.MergePatchType, patchJson))
+	return r.client.Patch(ctx, ai, client.RawPatch(types.MergePatchType, patchJson))
 }
 
-func (r *StatusControllerReconciler) getReferenceAddon(ctx context.Context) (rv1alpha1.ReferenceAddon, error) {
+func (r *StatusControllerReconciler) getReferenceAddon(ctx context.Context, key types.NamespacedName) (rv1alpha1.ReferenceAddon, error) {
 	log := r.cfg.Log.WithValues(
-		"namespace", r.cfg.ReferenceAddonNamespace,
-		"name", r.cfg.ReferenceAddonName,
+		"namespace", key.Namespace,
+		"name", key.Name,
 	)
 
 	log.Info("getting reference addon")
 
-	referenceAddonKey := client.ObjectKey{
-		Namespace: r.cfg.ReferenceAddonNamespace,
-		Name:      r.cfg.ReferenceAddonName,
-	}
-
 	var referenceAddon rv1alpha1.ReferenceAddon
-	if err := r.client.Get(ctx, referenceAddonKey, &referenceAddon); err != nil {
+	if err := r.client.Get(ctx, key, &referenceAddon); err != nil {
 		return referenceAddon, fmt.Errorf("getting reference addon: %w", err)
 	}
 
@@ -201,23 +596,119 @@ func (r *StatusControllerReconciler) getReferenceAddon(ctx context.Context) (rv1
 }
 
 func (r *StatusControllerReconciler) getConditions(ra rv1alpha1.ReferenceAddon) []metav1.Condition {
-	var conditions []metav1.Condition
+	conditions := addonInstanceConditions(ra)
+
+	for _, cond := range conditions {
+		r.cfg.Log.Info("mapped AddonInstance condition",
+			"type", cond.Type,
+			"status", cond.Status,
+			"reason", cond.Reason,
+		)
+	}
+
+	return conditions
+}
+
+const (
+	degradedReasonAsExpected      = "AsExpected"
+	degradedReasonTeardownFailed  = "TeardownFailed"
+	degradedReasonUninstallFailed = "UninstallFailed"
+)
+
+// uninstallConditions reports the progress of an uninstall using the
+// ReadyToBeDeleted and Degraded AddonInstance conditions. No conditions
+// are returned while an uninstall has not been requested so that deleting
+// the ReferenceAddon alone is not reported as an uninstall.
+func uninstallConditions(ra rv1alpha1.ReferenceAddon) []metav1.Condition {
+	uninstallPending := meta.FindStatusCondition(
+		ra.Status.Conditions,
+		rv1alpha1.ReferenceAddonConditionUninstallPending.String(),
+	)
+	if uninstallPending == nil || uninstallPending.Status != metav1.ConditionTrue {
+		return nil
+	}
 
-	isAvailable := meta.IsStatusConditionTrue(
+	tearingDown := meta.FindStatusCondition(
 		ra.Status.Conditions,
-		rv1alpha1.ReferenceAddonConditionAvailable.String(),
+		rv1alpha1.ReferenceAddonConditionTearingDown.String(),
 	)
 
-	// Check if Reference addon is available for the first time
-	if isAvailable {
-		r.cfg.Log.Info("Reference Addon Successfully Installed")
+	var (
+		report          = ra.Status.Uninstall
+		complete        = report != nil && report.Complete
+		failedStep      = report.FailedStep()
+		uninstallFailed = uninstallPending.Reason == rv1alpha1.UninstallPendingReasonFailed.String()
+	)
+
+	var conditions []metav1.Condition
+
+	if complete {
+		conditions = append(conditions, addoninstance.NewAddonInstanceConditionReadyToBeDeleted(
+			metav1.ConditionTrue,
+			av1alpha1.AddonInstanceReasonReadyToBeDeleted,
+			"All teardown steps completed",
+		))
+	} else {
+		msg := "Uninstall in progress"
+		if tearingDown != nil {
+			msg = fmt.Sprintf("Teardown step %q: %s", tearingDown.Reason, tearingDown.Message)
+		}
+
+		conditions = append(conditions, addoninstance.NewAddonInstanceConditionReadyToBeDeleted(
+			metav1.ConditionFalse,
+			av1alpha1.AddonInstanceReasonNotReadyToBeDeleted,
+			msg,
+		))
+	}
 
-		conditions = append(conditions, addoninstance.NewAddonInstanceConditionInstalled(
-			"True",
-			av1alpha1.AddonInstanceInstalledReasonSetupComplete,
-			"All Components Available",
+	switch {
+	case failedStep != nil:
+		conditions = append(conditions, addoninstance.NewAddonInstanceConditionDegraded(
+			metav1.ConditionTrue,
+			degradedReasonTeardownFailed,
+			fmt.Sprintf("Teardown step %q failed: %s", failedStep.Name, failedStep.Message),
+		))
+	case uninstallFailed:
+		conditions = append(conditions, addoninstance.NewAddonInstanceConditionDegraded(
+			metav1.ConditionTrue,
+			degradedReasonUninstallFailed,
+			uninstallPending.Message,
+		))
+	default:
+		conditions = append(conditions, addoninstance.NewAddonInstanceConditionDegraded(
+			metav1.ConditionFalse,
+			degradedReasonAsExpected,
+			"Uninstall progressing",
 		))
 	}
 
 	return conditions
 }
+
+// teardownCompleteConditions are reported once the ReferenceAddon
+// has been removed following a completed teardown.
+func teardownCompleteConditions() []metav1.Condition {
+	return []metav1.Condition{
+		addoninstance.NewAddonInstanceConditionInstalled(
+			metav1.ConditionFalse,
+			av1alpha1.AddonInstanceInstalledReasonTeardownComplete,
+			"All Components Removed",
+		),
+		addoninstance.NewAddonInstanceConditionReadyToBeDeleted(
+			metav1.ConditionTrue,
+			av1alpha1.AddonInstanceReasonReadyToBeDeleted,
+			"All teardown steps completed",
+		),
+		addoninstance.NewAddonInstanceConditionDegraded(
+			metav1.ConditionFalse,
+			degradedReasonAsExpected,
+			"Teardown completed",
+		),
+	}
+}
+
+func hasReadyToBeDeletedCondition(ai av1alpha1.AddonInstance) bool {
+	condT := av1alpha1.AddonInstanceConditionReadyToBeDeleted.String()
+
+	return meta.IsStatusConditionTrue(ai.Status.Conditions, condT)
+}
@@ -0,0 +1,733 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	av1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	rv1alpha1 "github.com/openshift/reference-addon/apis/reference/v1alpha1"
+	"github.com/openshift/reference-addon/internal/controllers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestUninstallConditions(t *testing.T) {
+	t.Parallel()
+
+	uninstallPending := metav1.Condition{
+		Type:   rv1alpha1.ReferenceAddonConditionUninstallPending.String(),
+		Status: metav1.ConditionTrue,
+		Reason: rv1alpha1.UninstallPendingReasonInProgress.String(),
+	}
+	tearingDown := metav1.Condition{
+		Type:    rv1alpha1.ReferenceAddonConditionTearingDown.String(),
+		Status:  metav1.ConditionTrue,
+		Reason:  rv1alpha1.TearingDownReasonDeletingCSVs.String(),
+		Message: "failed: test error",
+	}
+
+	for name, tc := range map[string]struct {
+		Conditions               []metav1.Condition
+		Report                   *rv1alpha1.UninstallReport
+		ExpectedReadyToBeDeleted metav1.ConditionStatus
+		ExpectedDegraded         metav1.ConditionStatus
+	}{
+		"uninstall not requested": {
+			Conditions: []metav1.Condition{
+				{
+					Type:   rv1alpha1.ReferenceAddonConditionUninstallPending.String(),
+					Status: metav1.ConditionFalse,
+					Reason: rv1alpha1.UninstallPendingReasonNotRequested.String(),
+				},
+			},
+		},
+		"uninstall in progress": {
+			Conditions:               []metav1.Condition{uninstallPending},
+			ExpectedReadyToBeDeleted: metav1.ConditionFalse,
+			ExpectedDegraded:         metav1.ConditionFalse,
+		},
+		"teardown step failed": {
+			Conditions: []metav1.Condition{uninstallPending, tearingDown},
+			Report: &rv1alpha1.UninstallReport{
+				Steps: []rv1alpha1.UninstallStepReport{
+					{
+						Name:   rv1alpha1.TearingDownReasonRemovingNetworkPolicies,
+						Result: rv1alpha1.UninstallStepResultSucceeded,
+					},
+					{
+						Name:    rv1alpha1.TearingDownReasonDeletingCSVs,
+						Result:  rv1alpha1.UninstallStepResultFailed,
+						Message: "test error",
+					},
+				},
+			},
+			ExpectedReadyToBeDeleted: metav1.ConditionFalse,
+			ExpectedDegraded:         metav1.ConditionTrue,
+		},
+		"teardown complete": {
+			Conditions: []metav1.Condition{uninstallPending, tearingDown},
+			Report: &rv1alpha1.UninstallReport{
+				Complete: true,
+			},
+			ExpectedReadyToBeDeleted: metav1.ConditionTrue,
+			ExpectedDegraded:         metav1.ConditionFalse,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ra := rv1alpha1.ReferenceAddon{
+				Status: rv1alpha1.ReferenceAddonStatus{
+					Conditions: tc.Conditions,
+					Uninstall:  tc.Report,
+				},
+			}
+
+			conds := uninstallConditions(ra)
+
+			assertConditionStatus(t, conds, av1alpha1.AddonInstanceConditionReadyToBeDeleted, tc.ExpectedReadyToBeDeleted)
+			assertConditionStatus(t, conds, av1alpha1.AddonInstanceConditionDegraded, tc.ExpectedDegraded)
+		})
+	}
+}
+
+func TestHasReadyToBeDeletedCondition(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Conditions []metav1.Condition
+		Expected   bool
+	}{
+		"no conditions": {},
+		"uninstall in progress": {
+			Conditions: []metav1.Condition{
+				{
+					Type:   av1alpha1.AddonInstanceConditionReadyToBeDeleted.String(),
+					Status: metav1.ConditionFalse,
+				},
+			},
+		},
+		"teardown complete": {
+			Conditions: []metav1.Condition{
+				{
+					Type:   av1alpha1.AddonInstanceConditionReadyToBeDeleted.String(),
+					Status: metav1.ConditionTrue,
+				},
+			},
+			Expected: true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var ai av1alpha1.AddonInstance
+
+			ai.Status.Conditions = tc.Conditions
+
+			assert.Equal(t, tc.Expected, hasReadyToBeDeletedCondition(ai))
+		})
+	}
+}
+
+func TestNewStatusControllerReconciler(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Options     []StatusControllerReconcilerOption
+		AssertError require.ErrorAssertionFunc
+	}{
+		"defaults": {
+			AssertError: require.NoError,
+		},
+		"adopt mode": {
+			Options:     []StatusControllerReconcilerOption{WithHeartbeatMode(HeartbeatModeAdopt)},
+			AssertError: require.NoError,
+		},
+		"unknown mode": {
+			Options:     []StatusControllerReconcilerOption{WithHeartbeatMode("unknown")},
+			AssertError: require.Error,
+		},
+		"minimum exceeds maximum": {
+			Options: []StatusControllerReconcilerOption{
+				WithHeartbeatMinInterval(time.Minute),
+				WithHeartbeatMaxInterval(time.Second),
+			},
+			AssertError: require.Error,
+		},
+		"jitter out of range": {
+			Options:     []StatusControllerReconcilerOption{WithHeartbeatJitter(1)},
+			AssertError: require.Error,
+		},
+		"all namespaces": {
+			Options:     []StatusControllerReconcilerOption{WithInstallMode(controllers.InstallModeAllNamespaces)},
+			AssertError: require.NoError,
+		},
+		"unknown install mode": {
+			Options:     []StatusControllerReconcilerOption{WithInstallMode("SingleNamespace")},
+			AssertError: require.Error,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewStatusControllerReconciler(fake.NewClientBuilder().Build(), tc.Options...)
+			tc.AssertError(t, err)
+		})
+	}
+}
+
+func TestStatusControllerReconciler_HeartbeatPeriod(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Mode           HeartbeatMode
+		Interval       time.Duration
+		Configured     time.Duration
+		ExpectedPeriod time.Duration
+	}{
+		"enforce/period configured": {
+			Mode:           HeartbeatModeEnforce,
+			Interval:       10 * time.Second,
+			Configured:     30 * time.Second,
+			ExpectedPeriod: 10 * time.Second,
+		},
+		"enforce/interval above maximum": {
+			Mode:           HeartbeatModeEnforce,
+			Interval:       time.Hour,
+			Configured:     30 * time.Second,
+			ExpectedPeriod: time.Hour,
+		},
+		"adopt/period configured": {
+			Mode:           HeartbeatModeAdopt,
+			Interval:       10 * time.Second,
+			Configured:     30 * time.Second,
+			ExpectedPeriod: 30 * time.Second,
+		},
+		"adopt/period not configured": {
+			Mode:           HeartbeatModeAdopt,
+			Interval:       10 * time.Second,
+			ExpectedPeriod: 10 * time.Second,
+		},
+		"adopt/period below minimum": {
+			Mode:           HeartbeatModeAdopt,
+			Interval:       10 * time.Second,
+			Configured:     time.Millisecond,
+			ExpectedPeriod: 5 * time.Second,
+		},
+		"adopt/period above maximum": {
+			Mode:           HeartbeatModeAdopt,
+			Interval:       10 * time.Second,
+			Configured:     time.Hour,
+			ExpectedPeriod: time.Minute,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := NewStatusControllerReconciler(
+				fake.NewClientBuilder().Build(),
+				WithHeartbeatInterval(tc.Interval),
+				WithHeartbeatMode(tc.Mode),
+				WithHeartbeatMinInterval(5*time.Second),
+				WithHeartbeatMaxInterval(time.Minute),
+			)
+			require.NoError(t, err)
+
+			var ai av1alpha1.AddonInstance
+			ai.Spec.HeartbeatUpdatePeriod = metav1.Duration{Duration: tc.Configured}
+
+			assert.Equal(t, tc.ExpectedPeriod, r.heartbeatPeriod(ai))
+		})
+	}
+}
+
+func TestStatusControllerReconciler_Jitter(t *testing.T) {
+	t.Parallel()
+
+	r, err := NewStatusControllerReconciler(
+		fake.NewClientBuilder().Build(),
+		WithHeartbeatJitter(0.2),
+	)
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		jittered := r.jitter(10 * time.Second)
+
+		assert.LessOrEqual(t, jittered, 10*time.Second, "pulses are never late")
+		assert.GreaterOrEqual(t, jittered, 8*time.Second)
+	}
+}
+
+func TestStatusControllerReconciler_ReconcileHeartbeatPeriod(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, av1alpha1.AddToScheme(scheme))
+	require.NoError(t, rv1alpha1.AddToScheme(scheme))
+
+	for name, tc := range map[string]struct {
+		Mode            HeartbeatMode
+		ExpectedPeriod  time.Duration
+		ExpectedRequeue time.Duration
+	}{
+		"enforce": {
+			Mode:            HeartbeatModeEnforce,
+			ExpectedPeriod:  10 * time.Second,
+			ExpectedRequeue: 10 * time.Second,
+		},
+		"adopt": {
+			Mode:            HeartbeatModeAdopt,
+			ExpectedPeriod:  30 * time.Second,
+			ExpectedRequeue: 30 * time.Second,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ai := &av1alpha1.AddonInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "addon-instance",
+					Namespace: "test-namespace",
+				},
+				Spec: av1alpha1.AddonInstanceSpec{
+					HeartbeatUpdatePeriod: metav1.Duration{Duration: 30 * time.Second},
+				},
+			}
+
+			c := fake.
+				NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(ai).
+				WithStatusSubresource(ai).
+				Build()
+
+			r, err := NewStatusControllerReconciler(
+				c,
+				WithAddonInstanceName("addon-instance"),
+				WithAddonInstanceNamespace("test-namespace"),
+				WithReferenceAddonName("test"),
+				WithReferenceAddonNamespace("test-namespace"),
+				WithHeartbeatInterval(10*time.Second),
+				WithHeartbeatMode(tc.Mode),
+			)
+			require.NoError(t, err)
+
+			res, err := r.Reconcile(context.Background(), reconcile.Request{})
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.ExpectedRequeue, res.RequeueAfter)
+
+			var actual av1alpha1.AddonInstance
+
+			require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(ai), &actual))
+			assert.Equal(t, tc.ExpectedPeriod, actual.Spec.HeartbeatUpdatePeriod.Duration)
+		})
+	}
+}
+
+func TestStatusControllerReconciler_ReferenceAddonAbsent(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, av1alpha1.AddToScheme(scheme))
+	require.NoError(t, rv1alpha1.AddToScheme(scheme))
+
+	ai := &av1alpha1.AddonInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "addon-instance",
+			Namespace: "test-namespace",
+		},
+	}
+
+	c := fake.
+		NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(ai).
+		WithStatusSubresource(ai).
+		Build()
+
+	r, err := NewStatusControllerReconciler(
+		c,
+		WithAddonInstanceName("addon-instance"),
+		WithAddonInstanceNamespace("test-namespace"),
+		WithReferenceAddonName("test"),
+		WithReferenceAddonNamespace("test-namespace"),
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	_, err = r.Reconcile(ctx, reconcile.Request{})
+	require.NoError(t, err)
+
+	var actual av1alpha1.AddonInstance
+
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(ai), &actual))
+
+	available := meta.FindStatusCondition(actual.Status.Conditions, AddonInstanceConditionAvailable.String())
+	require.NotNil(t, available)
+	assert.Equal(t, metav1.ConditionUnknown, available.Status)
+	assert.Equal(t, "NotFound", available.Reason)
+
+	assertConditionStatus(t, actual.Status.Conditions, av1alpha1.AddonInstanceConditionInstalled, "")
+	assertConditionStatus(t, actual.Status.Conditions, av1alpha1.AddonInstanceConditionReadyToBeDeleted, "")
+	assert.NotNil(t, actual.Status.LastHeartbeatTime, "pulses are sent while the ReferenceAddon is absent")
+}
+
+func TestStatusControllerReconciler_PulseBackoff(t *testing.T) {
+	t.Parallel()
+
+	r, err := NewStatusControllerReconciler(
+		fake.NewClientBuilder().Build(),
+		WithHeartbeatBackoffBase(time.Second),
+		WithHeartbeatBackoffMax(10*time.Second),
+	)
+	require.NoError(t, err)
+
+	for failures, expected := range map[int]time.Duration{
+		1:   time.Second,
+		2:   2 * time.Second,
+		3:   4 * time.Second,
+		4:   8 * time.Second,
+		5:   10 * time.Second,
+		100: 10 * time.Second,
+	} {
+		assert.Equal(t, expected, r.pulseBackoff(failures), "failures: %d", failures)
+	}
+}
+
+func TestStatusControllerReconciler_PulseFailure(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, av1alpha1.AddToScheme(scheme))
+	require.NoError(t, rv1alpha1.AddToScheme(scheme))
+
+	ai := &av1alpha1.AddonInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "addon-instance",
+			Namespace: "test-namespace",
+		},
+		Spec: av1alpha1.AddonInstanceSpec{
+			HeartbeatUpdatePeriod: metav1.Duration{Duration: 10 * time.Second},
+		},
+	}
+	ra := &rv1alpha1.ReferenceAddon{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-namespace",
+		},
+	}
+
+	var failPulse atomic.Bool
+
+	failPulse.Store(true)
+
+	c := fake.
+		NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(ai, ra).
+		WithStatusSubresource(ai).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, sub string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				if failPulse.Load() {
+					return errors.New("test error")
+				}
+
+				return c.SubResource(sub).Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	var recorder heartbeatRecorderMock
+	recorder.
+		On("RecordPulse", "test-namespace", "addon-instance", mock.Anything, mock.MatchedBy(func(err error) bool { return err != nil })).
+		Return().
+		Times(2)
+	recorder.
+		On("RecordPulse", "test-namespace", "addon-instance", mock.Anything, nil).
+		Return().
+		Once()
+
+	clk := clocktesting.NewFakePassiveClock(time.Now())
+
+	r, err := NewStatusControllerReconciler(
+		c,
+		WithAddonInstanceName("addon-instance"),
+		WithAddonInstanceNamespace("test-namespace"),
+		WithReferenceAddonName("test"),
+		WithReferenceAddonNamespace("test-namespace"),
+		WithHeartbeatInterval(10*time.Second),
+		WithHeartbeatBackoffBase(time.Second),
+		WithHeartbeatRecorder{Recorder: &recorder},
+		WithClock{Clock: clk},
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	res, err := r.Reconcile(ctx, reconcile.Request{})
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, res.RequeueAfter)
+
+	res, err = r.Reconcile(ctx, reconcile.Request{})
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Second, res.RequeueAfter, "backoff doubles with consecutive failures")
+
+	clk.SetTime(clk.Now().Add(31 * time.Second))
+
+	require.Error(t, r.HeartbeatCheck(nil), "heartbeats are stale after 3 failed periods")
+
+	failPulse.Store(false)
+
+	res, err = r.Reconcile(ctx, reconcile.Request{})
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, res.RequeueAfter)
+
+	require.NoError(t, r.HeartbeatCheck(nil))
+	assert.Zero(t, r.heartbeats[client.ObjectKeyFromObject(ai)].failures, "failures are reset by a successful pulse")
+
+	recorder.AssertExpectations(t)
+}
+
+func TestStatusControllerReconciler_HeartbeatCheck(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		SinceLastPulse time.Duration
+		AssertError    require.ErrorAssertionFunc
+	}{
+		"recent pulse": {
+			SinceLastPulse: 10 * time.Second,
+			AssertError:    require.NoError,
+		},
+		"at threshold": {
+			SinceLastPulse: 30 * time.Second,
+			AssertError:    require.NoError,
+		},
+		"stale": {
+			SinceLastPulse: 31 * time.Second,
+			AssertError:    require.Error,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			clk := clocktesting.NewFakePassiveClock(time.Now())
+
+			r, err := NewStatusControllerReconciler(
+				fake.NewClientBuilder().Build(),
+				WithHeartbeatInterval(10*time.Second),
+				WithHeartbeatStaleIntervals(3),
+				WithClock{Clock: clk},
+			)
+			require.NoError(t, err)
+
+			clk.SetTime(clk.Now().Add(tc.SinceLastPulse))
+
+			tc.AssertError(t, r.HeartbeatCheck(nil))
+		})
+	}
+}
+
+func TestStatusControllerReconciler_HeartbeatCheckStandby(t *testing.T) {
+	t.Parallel()
+
+	clk := clocktesting.NewFakePassiveClock(time.Now())
+	elected := make(chan struct{})
+
+	r, err := NewStatusControllerReconciler(
+		fake.NewClientBuilder().Build(),
+		WithHeartbeatInterval(10*time.Second),
+		WithHeartbeatStaleIntervals(3),
+		WithElected{Elected: elected},
+		WithClock{Clock: clk},
+	)
+	require.NoError(t, err)
+
+	clk.SetTime(clk.Now().Add(time.Hour))
+
+	require.NoError(t, r.HeartbeatCheck(nil), "standby replicas do not send pulses")
+
+	close(elected)
+
+	require.NoError(t, r.HeartbeatCheck(nil), "staleness is measured from the election")
+
+	clk.SetTime(clk.Now().Add(31 * time.Second))
+
+	require.Error(t, r.HeartbeatCheck(nil))
+}
+
+func TestStatusControllerReconciler_MultiNamespace(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, av1alpha1.AddToScheme(scheme))
+	require.NoError(t, rv1alpha1.AddToScheme(scheme))
+
+	addonInstance := func(ns string) *av1alpha1.AddonInstance {
+		return &av1alpha1.AddonInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "addon-instance",
+				Namespace: ns,
+			},
+			Spec: av1alpha1.AddonInstanceSpec{
+				HeartbeatUpdatePeriod: metav1.Duration{Duration: 10 * time.Second},
+			},
+		}
+	}
+	referenceAddon := func(ns string, reason rv1alpha1.ReferenceAddonAvailableReason) *rv1alpha1.ReferenceAddon {
+		return &rv1alpha1.ReferenceAddon{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: ns,
+			},
+			Status: rv1alpha1.ReferenceAddonStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:   rv1alpha1.ReferenceAddonConditionAvailable.String(),
+						Status: reason.Status(),
+						Reason: reason.String(),
+					},
+				},
+			},
+		}
+	}
+
+	aiA, aiB := addonInstance("namespace-a"), addonInstance("namespace-b")
+
+	c := fake.
+		NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			aiA,
+			aiB,
+			referenceAddon("namespace-a", rv1alpha1.ReferenceAddonAvailableReasonReady),
+			referenceAddon("namespace-b", rv1alpha1.ReferenceAddonAvailableReasonPending),
+		).
+		WithStatusSubresource(aiA, aiB).
+		Build()
+
+	var recorder heartbeatRecorderMock
+	recorder.
+		On("RecordPulse", "namespace-a", "addon-instance", mock.Anything, nil).
+		Return()
+	recorder.
+		On("RecordPulse", "namespace-b", "addon-instance", mock.Anything, nil).
+		Return()
+	recorder.
+		On("ForgetAddonInstance", "namespace-c", "addon-instance").
+		Return().
+		Once()
+
+	clk := clocktesting.NewFakePassiveClock(time.Now())
+
+	r, err := NewStatusControllerReconciler(
+		c,
+		WithInstallMode(controllers.InstallModeAllNamespaces),
+		WithAddonInstanceName("addon-instance"),
+		WithReferenceAddonName("test"),
+		WithHeartbeatInterval(10*time.Second),
+		WithHeartbeatRecorder{Recorder: &recorder},
+		WithClock{Clock: clk},
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, r.HeartbeatCheck(nil), "no AddonInstance is tracked before reconciling")
+
+	ctx := context.Background()
+
+	for _, ns := range []string{"namespace-a", "namespace-b"} {
+		res, err := r.Reconcile(ctx, reconcile.Request{
+			NamespacedName: client.ObjectKey{Name: "addon-instance", Namespace: ns},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 10*time.Second, res.RequeueAfter)
+	}
+
+	res, err := r.Reconcile(ctx, reconcile.Request{
+		NamespacedName: client.ObjectKey{Name: "addon-instance", Namespace: "namespace-c"},
+	})
+	require.NoError(t, err)
+	assert.Zero(t, res, "missing AddonInstances are not requeued")
+	recorder.AssertCalled(t, "ForgetAddonInstance", "namespace-c", "addon-instance")
+
+	for ns, status := range map[string]metav1.ConditionStatus{
+		"namespace-a": metav1.ConditionTrue,
+		"namespace-b": metav1.ConditionFalse,
+	} {
+		var actual av1alpha1.AddonInstance
+
+		require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(addonInstance(ns)), &actual))
+
+		assertConditionStatus(t, actual.Status.Conditions, AddonInstanceConditionAvailable, status)
+	}
+
+	require.Len(t, r.heartbeats, 2, "only existing AddonInstances are tracked")
+	require.NoError(t, r.HeartbeatCheck(nil))
+
+	clk.SetTime(clk.Now().Add(31 * time.Second))
+
+	_, err = r.Reconcile(ctx, reconcile.Request{
+		NamespacedName: client.ObjectKeyFromObject(aiA),
+	})
+	require.NoError(t, err)
+
+	err = r.HeartbeatCheck(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "namespace-b/addon-instance")
+	assert.NotContains(t, err.Error(), "namespace-a/addon-instance")
+
+	recorder.AssertExpectations(t)
+}
+
+type heartbeatRecorderMock struct {
+	mock.Mock
+}
+
+func (m *heartbeatRecorderMock) RecordPulse(namespace, name string, latency time.Duration, err error) {
+	m.Called(namespace, name, latency, err)
+}
+
+func (m *heartbeatRecorderMock) ForgetAddonInstance(namespace, name string) {
+	m.Called(namespace, name)
+}
+
+func assertConditionStatus(t *testing.T, conds []metav1.Condition, condT av1alpha1.AddonInstanceCondition, status metav1.ConditionStatus) {
+	t.Helper()
+
+	cond := meta.FindStatusCondition(conds, condT.String())
+
+	if status == "" {
+		assert.Nil(t, cond)
+
+		return
+	}
+
+	require.NotNil(t, cond)
+	assert.Equal(t, status, cond.Status)
+}
@@ -21,6 +21,34 @@ func RegisterMetrics(reg prometheus.Registerer) error {
 		return fmt.Errorf("registering 'smokeTest' metric: %w", err)
 	}
 
+	if err := reg.Register(namespacedAvailability); err != nil {
+		return fmt.Errorf("registering 'namespacedAvailability' metric: %w", err)
+	}
+
+	if err := reg.Register(namespacedResponseTime); err != nil {
+		return fmt.Errorf("registering 'namespacedResponseTime' metric: %w", err)
+	}
+
+	if err := reg.Register(namespacedSmokeTest); err != nil {
+		return fmt.Errorf("registering 'namespacedSmokeTest' metric: %w", err)
+	}
+
+	if err := reg.Register(networkPolicyDrift); err != nil {
+		return fmt.Errorf("registering 'networkPolicyDrift' metric: %w", err)
+	}
+
+	if err := reg.Register(heartbeatLastSuccess); err != nil {
+		return fmt.Errorf("registering 'heartbeatLastSuccess' metric: %w", err)
+	}
+
+	if err := reg.Register(heartbeatFailures); err != nil {
+		return fmt.Errorf("registering 'heartbeatFailures' metric: %w", err)
+	}
+
+	if err := reg.Register(heartbeatLatency); err != nil {
+		return fmt.Errorf("registering 'heartbeatLatency' metric: %w", err)
+	}
+
 	return nil
 }
 
@@ -45,25 +73,105 @@ var (
 			Help: "smoke test for testing end-to-end metrics flow",
 		},
 	)
+	// The namespaced metrics are only reported for ReferenceAddons outside
+	// of the operator's namespace so that the series of the operator's
+	// namespace keep their labels in every install mode.
+	namespacedAvailability = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: metricPrefix + "namespace_sample_availability",
+			Help: "external url availability per ReferenceAddon namespace 0-not available and 1-available.",
+		},
+		[]string{"namespace", "url"},
+	)
+	namespacedResponseTime = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: metricPrefix + "namespace_sample_response_time",
+			Help: "external url response time taken per ReferenceAddon namespace.",
+		},
+		[]string{"namespace", "url"},
+	)
+	namespacedSmokeTest = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: metricPrefix + "namespace_smoke_test",
+			Help: "smoke test for testing end-to-end metrics flow per ReferenceAddon namespace",
+		},
+		[]string{"namespace"},
+	)
+	networkPolicyDrift = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: metricPrefix + "network_policy_drift_total",
+			Help: "number of times the spec of an applied NetworkPolicy was found to differ from the desired spec.",
+		},
+		[]string{"namespace", "policy"},
+	)
+	heartbeatLastSuccess = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: metricPrefix + "heartbeat_last_success_timestamp_seconds",
+			Help: "unix time of the last heartbeat successfully sent to the addon instance.",
+		},
+		[]string{"namespace", "name"},
+	)
+	heartbeatFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: metricPrefix + "heartbeat_failures_total",
+			Help: "number of heartbeats which could not be sent to the addon instance.",
+		},
+		[]string{"namespace", "name"},
+	)
+	heartbeatLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    metricPrefix + "heartbeat_latency_seconds",
+			Help:    "time taken to send a heartbeat to the addon instance.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"namespace", "name"},
+	)
 )
 
 const metricPrefix = "reference_addon_"
 
-func NewResponseSamplerImpl() *ResponseSamplerImpl {
-	return &ResponseSamplerImpl{}
+// NewResponseSamplerImpl returns a ResponseSamplerImpl reporting samples of
+// the given operator namespace using the unlabeled sample metrics.
+func NewResponseSamplerImpl(addonNamespace string) *ResponseSamplerImpl {
+	return &ResponseSamplerImpl{
+		addonNamespace: addonNamespace,
+	}
 }
 
-type ResponseSamplerImpl struct{}
+type ResponseSamplerImpl struct {
+	addonNamespace string
+}
 
-func (r *ResponseSamplerImpl) RequestSampleResponseData(urls ...string) {
+func (r *ResponseSamplerImpl) RequestSampleResponseData(namespace string, urls ...string) {
 	for _, url := range urls {
 		status, timeTaken := callExternalURL(url)
 
-		availability.WithLabelValues(url).Set(status)
-		responseTime.WithLabelValues(url).Set(timeTaken)
+		if namespace == r.addonNamespace {
+			availability.WithLabelValues(url).Set(status)
+			responseTime.WithLabelValues(url).Set(timeTaken)
+
+			continue
+		}
+
+		namespacedAvailability.WithLabelValues(namespace, url).Set(status)
+		namespacedResponseTime.WithLabelValues(namespace, url).Set(timeTaken)
 	}
 }
 
+func (r *ResponseSamplerImpl) ResetSampleResponseData(namespace string) {
+	if namespace == r.addonNamespace {
+		availability.Reset()
+		responseTime.Reset()
+
+		return
+	}
+
+	labels := prometheus.Labels{"namespace": namespace}
+
+	namespacedAvailability.DeletePartialMatch(labels)
+	namespacedResponseTime.DeletePartialMatch(labels)
+}
+
 func callExternalURL(externalURL string) (float64, float64) {
 	start := time.Now()
 
@@ -82,16 +190,78 @@ func callExternalURL(externalURL string) (float64, float64) {
 	return float64(status), float64(time.Since(start).Milliseconds())
 }
 
-func NewSmokeTester() *SmokeTester {
-	return &SmokeTester{}
+// NewSmokeTester returns a SmokeTester reporting the smoke test of
+// the given operator namespace using the unlabeled smoke test metric.
+func NewSmokeTester(addonNamespace string) *SmokeTester {
+	return &SmokeTester{
+		addonNamespace: addonNamespace,
+	}
+}
+
+type SmokeTester struct {
+	addonNamespace string
+}
+
+func (t *SmokeTester) Enable(namespace string) {
+	t.set(namespace, 1)
+}
+
+func (t *SmokeTester) Disable(namespace string) {
+	t.set(namespace, 0)
+}
+
+func (t *SmokeTester) Reset(namespace string) {
+	if namespace == t.addonNamespace {
+		smokeTest.Set(0)
+
+		return
+	}
+
+	namespacedSmokeTest.DeleteLabelValues(namespace)
+}
+
+func (t *SmokeTester) set(namespace string, val float64) {
+	if namespace == t.addonNamespace {
+		smokeTest.Set(val)
+
+		return
+	}
+
+	namespacedSmokeTest.WithLabelValues(namespace).Set(val)
+}
+
+func NewDriftRecorder() *DriftRecorder {
+	return &DriftRecorder{}
+}
+
+type DriftRecorder struct{}
+
+func (r *DriftRecorder) RecordNetworkPolicyDrift(namespace, name string) {
+	networkPolicyDrift.WithLabelValues(namespace, name).Inc()
+}
+
+func NewHeartbeatRecorder() *HeartbeatRecorder {
+	return &HeartbeatRecorder{}
 }
 
-type SmokeTester struct{}
+type HeartbeatRecorder struct{}
 
-func (t *SmokeTester) Enable() {
-	smokeTest.Set(1)
+func (r *HeartbeatRecorder) RecordPulse(namespace, name string, latency time.Duration, err error) {
+	heartbeatLatency.WithLabelValues(namespace, name).Observe(latency.Seconds())
+
+	if err != nil {
+		heartbeatFailures.WithLabelValues(namespace, name).Inc()
+
+		return
+	}
+
+	heartbeatLastSuccess.WithLabelValues(namespace, name).SetToCurrentTime()
 }
 
-func (t *SmokeTester) Disable() {
-	smokeTest.Set(0)
+func (r *HeartbeatRecorder) ForgetAddonInstance(namespace, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "name": name}
+
+	heartbeatLatency.Delete(labels)
+	heartbeatFailures.Delete(labels)
+	heartbeatLastSuccess.Delete(labels)
 }
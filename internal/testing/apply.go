@@ -0,0 +1,57 @@
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// ServerSideApplyFuncs returns interceptor funcs which allow objects
+// to be server-side applied through the fake client which does not
+// support apply patches.
+func ServerSideApplyFuncs() interceptor.Funcs {
+	return interceptor.Funcs{
+		Patch: EmulateServerSideApply,
+	}
+}
+
+// EmulateServerSideApply creates the object of an apply patch if it does
+// not exist and merges it into the existing object otherwise. Fields are
+// never removed and field ownership is not tracked, so conflicts between
+// field managers are not detected. Other patches are passed through.
+func EmulateServerSideApply(
+	ctx context.Context,
+	c client.WithWatch,
+	obj client.Object,
+	patch client.Patch,
+	opts ...client.PatchOption,
+) error {
+	if patch.Type() != types.ApplyPatchType {
+		return c.Patch(ctx, obj, patch, opts...)
+	}
+
+	actual, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("unexpected object type %T", obj)
+	}
+
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), actual); apierrors.IsNotFound(err) {
+		return c.Create(ctx, obj)
+	} else if err != nil {
+		return err
+	}
+
+	obj.SetResourceVersion("")
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("marshalling applied object: %w", err)
+	}
+
+	return c.Patch(ctx, obj, client.RawPatch(types.MergePatchType, data))
+}
@@ -66,6 +66,19 @@ func (c *TestClient) EventuallyObjectDoesNotExist(ctx context.Context, obj clien
 	return EventuallyWithOffset(1, get, fmt.Sprint(cfg.Timeout)).ShouldNot(Succeed())
 }
 
+func (c *TestClient) ConsistentlyObjectDoesNotExist(ctx context.Context, obj client.Object, opts ...RequestOption) bool {
+	var cfg RequestConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	get := func() error {
+		return c.client.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+	}
+
+	return ConsistentlyWithOffset(1, get, fmt.Sprint(cfg.Timeout)).ShouldNot(Succeed())
+}
+
 type RequestConfig struct {
 	Timeout time.Duration
 }